@@ -12,11 +12,30 @@ const (
 	Multiply = "*"
 	// Divide is a division operator.
 	Divide = "/"
+	// Modulo is a modulo operator whose results have the same signs as
+	// dividends.
+	Modulo = "%"
+	// Power is an exponentiation operator.
+	Power = "^"
+	// Equal is an equality operator.
+	Equal = "=="
+	// NotEqual is an inequality operator.
+	NotEqual = "/="
+	// LessThan is a less-than operator.
+	LessThan = "<"
+	// LessThanOrEqual is a less-than-or-equal operator.
+	LessThanOrEqual = "<="
+	// GreaterThan is a greater-than operator.
+	GreaterThan = ">"
+	// GreaterThanOrEqual is a greater-than-or-equal operator.
+	GreaterThanOrEqual = ">="
 )
 
 // Priority returns operator priority.
 func (o BinaryOperator) Priority() int {
 	switch o {
+	case Equal, NotEqual, LessThan, LessThanOrEqual, GreaterThan, GreaterThanOrEqual:
+		return 0
 	case Add:
 		return 1
 	case Subtract:
@@ -25,7 +44,21 @@ func (o BinaryOperator) Priority() int {
 		return 2
 	case Divide:
 		return 2
+	case Modulo:
+		return 2
+	case Power:
+		return 3
 	}
 
 	panic("unreachable")
 }
+
+// IsRightAssociative returns true if an operator is right-associative.
+func (o BinaryOperator) IsRightAssociative() bool {
+	return o == Power
+}
+
+// IsComparison returns true if an operator is a comparison operator.
+func (o BinaryOperator) IsComparison() bool {
+	return o.Priority() == 0
+}
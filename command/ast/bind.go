@@ -7,15 +7,27 @@ type Bind struct {
 	name       string
 	typ        types.Type
 	expression Expression
+	comment    string
+	pattern    Expression
 }
 
 // NewBind creates a bind.
 func NewBind(n string, t types.Type, e Expression) Bind {
+	return NewBindWithComment(n, t, e, "")
+}
+
+// NewBindWithComment creates a bind with a documentation comment.
+func NewBindWithComment(n string, t types.Type, e Expression, c string) Bind {
 	if t == nil {
 		panic("unreachable")
 	}
 
-	return Bind{n, t, e}
+	return Bind{n, t, e, c, nil}
+}
+
+// NewPatternBind creates a bind destructuring a value with a pattern.
+func NewPatternBind(p Expression, e Expression) Bind {
+	return Bind{"", types.NewUnknown(nil), e, "", p}
 }
 
 // Name returns a name.
@@ -33,9 +45,26 @@ func (b Bind) Expression() Expression {
 	return b.expression
 }
 
+// Comment returns a documentation comment.
+func (b Bind) Comment() string {
+	return b.comment
+}
+
+// Pattern returns a pattern of a bind destructuring a value, or nil
+// otherwise.
+func (b Bind) Pattern() Expression {
+	return b.pattern
+}
+
 // ConvertExpressions converts expressions.
 func (b Bind) ConvertExpressions(f func(Expression) Expression) Bind {
-	return NewBind(b.name, b.typ, b.expression.ConvertExpressions(f).(Expression))
+	return Bind{
+		b.name,
+		b.typ,
+		b.expression.ConvertExpressions(f).(Expression),
+		b.comment,
+		b.pattern,
+	}
 }
 
 // VisitTypes visits types.
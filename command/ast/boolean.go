@@ -0,0 +1,31 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// Boolean is a boolean.
+type Boolean struct {
+	value bool
+}
+
+// NewBoolean creates a boolean.
+func NewBoolean(b bool) Boolean {
+	return Boolean{b}
+}
+
+// Value returns a value.
+func (b Boolean) Value() bool {
+	return b.value
+}
+
+// ConvertExpressions converts expressions.
+func (b Boolean) ConvertExpressions(f func(Expression) Expression) Expression {
+	return f(b)
+}
+
+// VisitTypes visits types.
+func (b Boolean) VisitTypes(f func(types.Type) error) error {
+	return nil
+}
+
+func (Boolean) isExpression() {}
+func (Boolean) isLiteral()    {}
@@ -0,0 +1,24 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// BuiltinTypes returns types of built-in functions.
+// Functions prefixed with $ are used only by generated code.
+func BuiltinTypes() map[string]types.Type {
+	i, n, s := types.NewInt(nil), types.NewNumber(nil), types.NewString(nil)
+	ii := types.NewFunction(i, types.NewFunction(i, i, nil), nil)
+	ns := types.NewList(n, nil)
+
+	return map[string]types.Type{
+		"toInt":           types.NewFunction(n, i, nil),
+		"toNumber":        types.NewFunction(i, n, nil),
+		"div":             ii,
+		"mod":             ii,
+		"$toInt":          types.NewFunction(n, i, nil),
+		"$toNumber":       types.NewFunction(i, n, nil),
+		"$div":            ii,
+		"$mod":            ii,
+		"$toCodePoints":   types.NewFunction(s, ns, nil),
+		"$fromCodePoints": types.NewFunction(ns, s, nil),
+	}
+}
@@ -0,0 +1,54 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// ConstructorApplication is a saturated constructor application.
+type ConstructorApplication struct {
+	typ       types.Algebraic
+	index     int
+	arguments []Expression
+}
+
+// NewConstructorApplication creates a constructor application.
+func NewConstructorApplication(t types.Algebraic, i int, as []Expression) ConstructorApplication {
+	return ConstructorApplication{t, i, as}
+}
+
+// Type returns an algebraic type.
+func (a ConstructorApplication) Type() types.Algebraic {
+	return a.typ
+}
+
+// Index returns a constructor index.
+func (a ConstructorApplication) Index() int {
+	return a.index
+}
+
+// Arguments returns arguments.
+func (a ConstructorApplication) Arguments() []Expression {
+	return a.arguments
+}
+
+// ConvertExpressions converts expressions.
+func (a ConstructorApplication) ConvertExpressions(f func(Expression) Expression) Expression {
+	as := make([]Expression, 0, len(a.arguments))
+
+	for _, e := range a.arguments {
+		as = append(as, e.ConvertExpressions(f))
+	}
+
+	return f(NewConstructorApplication(a.typ, a.index, as))
+}
+
+// VisitTypes visits types.
+func (a ConstructorApplication) VisitTypes(f func(types.Type) error) error {
+	for _, e := range a.arguments {
+		if err := e.VisitTypes(f); err != nil {
+			return err
+		}
+	}
+
+	return f(a.typ)
+}
+
+func (ConstructorApplication) isExpression() {}
@@ -0,0 +1,46 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// ConstructorPattern is a constructor pattern.
+type ConstructorPattern struct {
+	constructor string
+	arguments   []Variable
+}
+
+// NewConstructorPattern creates a constructor pattern.
+func NewConstructorPattern(c string, as []Variable) ConstructorPattern {
+	return ConstructorPattern{c, as}
+}
+
+// Constructor returns a constructor name.
+func (p ConstructorPattern) Constructor() string {
+	return p.constructor
+}
+
+// UnqualifiedConstructor returns a constructor name without a module name.
+func (p ConstructorPattern) UnqualifiedConstructor() string {
+	ss := strings.Split(p.constructor, ".")
+	return ss[len(ss)-1]
+}
+
+// Arguments returns arguments.
+func (p ConstructorPattern) Arguments() []Variable {
+	return p.arguments
+}
+
+// ConvertExpressions converts expressions.
+func (p ConstructorPattern) ConvertExpressions(f func(Expression) Expression) Expression {
+	return f(p)
+}
+
+// VisitTypes visits types.
+func (p ConstructorPattern) VisitTypes(f func(types.Type) error) error {
+	return nil
+}
+
+func (ConstructorPattern) isExpression() {}
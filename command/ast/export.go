@@ -1,16 +1,42 @@
 package ast
 
+import "github.com/raviqqe/lazy-ein/command/debug"
+
 // Export is an export.
 type Export struct {
-	names []string
+	names            []string
+	modules          []ModuleName
+	debugInformation *debug.Information
 }
 
 // NewExport creates an export.
 func NewExport(ss ...string) Export {
-	return Export{ss}
+	return Export{ss, nil, nil}
+}
+
+// NewExportWithModules creates an export which re-exports names imported from
+// modules.
+func NewExportWithModules(ns []ModuleName, ss ...string) Export {
+	return Export{ss, ns, nil}
+}
+
+// WithDebugInformation returns an export with debug information.
+func (e Export) WithDebugInformation(i *debug.Information) Export {
+	return Export{e.names, e.modules, i}
 }
 
-// Names returns names.
+// Names returns names. Qualified names are ones re-exported from imported
+// modules.
 func (e Export) Names() []string {
 	return e.names
 }
+
+// Modules returns names of modules whose imported names are re-exported.
+func (e Export) Modules() []ModuleName {
+	return e.modules
+}
+
+// DebugInformation returns debug information.
+func (e Export) DebugInformation() *debug.Information {
+	return e.debugInformation
+}
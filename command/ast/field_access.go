@@ -0,0 +1,46 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// FieldAccess is a record field access.
+type FieldAccess struct {
+	typ    types.Type
+	record Expression
+	field  string
+}
+
+// NewFieldAccess creates a field access.
+func NewFieldAccess(t types.Type, r Expression, s string) FieldAccess {
+	return FieldAccess{t, r, s}
+}
+
+// Type returns a record type.
+func (a FieldAccess) Type() types.Type {
+	return a.typ
+}
+
+// Record returns a record.
+func (a FieldAccess) Record() Expression {
+	return a.record
+}
+
+// Field returns a field name.
+func (a FieldAccess) Field() string {
+	return a.field
+}
+
+// ConvertExpressions converts expressions.
+func (a FieldAccess) ConvertExpressions(f func(Expression) Expression) Expression {
+	return f(NewFieldAccess(a.typ, a.record.ConvertExpressions(f), a.field))
+}
+
+// VisitTypes visits types.
+func (a FieldAccess) VisitTypes(f func(types.Type) error) error {
+	if err := a.typ.VisitTypes(f); err != nil {
+		return err
+	}
+
+	return a.record.VisitTypes(f)
+}
+
+func (FieldAccess) isExpression() {}
@@ -0,0 +1,30 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// ForeignFunction is a function defined in a foreign language.
+type ForeignFunction struct {
+	name string
+}
+
+// NewForeignFunction creates a foreign function.
+func NewForeignFunction(s string) ForeignFunction {
+	return ForeignFunction{s}
+}
+
+// Name returns a name in a foreign language.
+func (f ForeignFunction) Name() string {
+	return f.name
+}
+
+// ConvertExpressions converts expressions.
+func (f ForeignFunction) ConvertExpressions(ff func(Expression) Expression) Expression {
+	return ff(f)
+}
+
+// VisitTypes visits types.
+func (ForeignFunction) VisitTypes(func(types.Type) error) error {
+	return nil
+}
+
+func (ForeignFunction) isExpression() {}
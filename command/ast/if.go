@@ -0,0 +1,54 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// If is an if expression.
+type If struct {
+	condition Expression
+	then      Expression
+	els       Expression
+}
+
+// NewIf creates an if expression.
+func NewIf(c, t, e Expression) If {
+	return If{c, t, e}
+}
+
+// Condition returns a condition.
+func (i If) Condition() Expression {
+	return i.condition
+}
+
+// Then returns an expression evaluated when a condition is true.
+func (i If) Then() Expression {
+	return i.then
+}
+
+// Else returns an expression evaluated when a condition is false.
+func (i If) Else() Expression {
+	return i.els
+}
+
+// ConvertExpressions converts expressions.
+func (i If) ConvertExpressions(f func(Expression) Expression) Expression {
+	return f(
+		NewIf(
+			i.condition.ConvertExpressions(f),
+			i.then.ConvertExpressions(f),
+			i.els.ConvertExpressions(f),
+		),
+	)
+}
+
+// VisitTypes visits types.
+func (i If) VisitTypes(f func(types.Type) error) error {
+	for _, e := range []Expression{i.condition, i.then, i.els} {
+		if err := e.VisitTypes(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (If) isExpression() {}
@@ -1,16 +1,92 @@
 package ast
 
+import "github.com/raviqqe/lazy-ein/command/debug"
+
 // Import is a module import.
 type Import struct {
-	name ModuleName
+	name             ModuleName
+	alias            string
+	names            []string
+	prelude          bool
+	debugInformation *debug.Information
 }
 
 // NewImport creates a module import.
-func NewImport(n ModuleName) Import {
-	return Import{n}
+func NewImport(n ModuleName, i *debug.Information) Import {
+	return Import{n, "", nil, false, i}
+}
+
+// NewAliasedImport creates a module import with an alias of its qualifier.
+func NewAliasedImport(n ModuleName, a string, i *debug.Information) Import {
+	return Import{n, a, nil, false, i}
+}
+
+// NewSelectiveImport creates a module import of specific names which are
+// referred to without qualification.
+func NewSelectiveImport(n ModuleName, ss []string, i *debug.Information) Import {
+	return Import{n, "", ss, false, i}
+}
+
+// NewPreludeImport creates an implicit import of the prelude module. Its names
+// are referred to without qualification and shadowed by binds and explicitly
+// imported names in an importing module.
+func NewPreludeImport(n ModuleName) Import {
+	return Import{n, "", nil, true, nil}
 }
 
 // Name returns a name.
 func (i Import) Name() ModuleName {
 	return i.name
 }
+
+// Qualifier returns a qualifier of imported names.
+func (i Import) Qualifier() string {
+	if i.alias != "" {
+		return i.alias
+	}
+
+	return i.name.Qualifier()
+}
+
+// Names returns imported names. It returns nil if all exported names are
+// imported.
+func (i Import) Names() []string {
+	return i.names
+}
+
+// IsSelective checks if an import imports specific names only.
+func (i Import) IsSelective() bool {
+	return i.names != nil
+}
+
+// IsPrelude checks if an import is an implicit one of the prelude module.
+func (i Import) IsPrelude() bool {
+	return i.prelude
+}
+
+// IsQualified checks if imported names are qualified.
+func (i Import) IsQualified() bool {
+	return !i.IsSelective() && !i.prelude
+}
+
+// ImportedName returns a name of an exported bind in an importing module.
+func (i Import) ImportedName(s string) (string, bool) {
+	if i.IsQualified() {
+		return i.Qualifier() + "." + s, true
+	} else if i.prelude {
+		return s, true
+	}
+
+	for _, ss := range i.names {
+		if ss == s {
+			return s, true
+		}
+	}
+
+	return "", false
+}
+
+// DebugInformation returns debug information.
+func (i Import) DebugInformation() *debug.Information {
+	return i.debugInformation
+}
@@ -0,0 +1,32 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportQualifier(t *testing.T) {
+	assert.Equal(t, "bar", NewImport("foo/bar", nil).Qualifier())
+	assert.Equal(t, "baz", NewAliasedImport("foo/bar", "baz", nil).Qualifier())
+	assert.Equal(t, "bar", NewImport("foo/bar.json", nil).Qualifier())
+}
+
+func TestImportImportedName(t *testing.T) {
+	for _, c := range []struct {
+		imp  Import
+		name string
+		ok   bool
+	}{
+		{NewImport("foo/bar", nil), "bar.x", true},
+		{NewAliasedImport("foo/bar", "baz", nil), "baz.x", true},
+		{NewSelectiveImport("foo/bar", []string{"x"}, nil), "x", true},
+		{NewSelectiveImport("foo/bar", []string{"y"}, nil), "", false},
+		{NewPreludeImport("foo/prelude"), "x", true},
+	} {
+		s, ok := c.imp.ImportedName("x")
+
+		assert.Equal(t, c.name, s)
+		assert.Equal(t, c.ok, ok)
+	}
+}
@@ -4,15 +4,42 @@ import "github.com/raviqqe/lazy-ein/command/types"
 
 // Module is a module.
 type Module struct {
-	name    ModuleName
-	export  Export
-	imports []Import
-	binds   []Bind
+	name            ModuleName
+	export          Export
+	imports         []Import
+	typeDefinitions []TypeDefinition
+	binds           []Bind
+	noPrelude       bool
 }
 
 // NewModule creates a module.
-func NewModule(n ModuleName, e Export, is []Import, bs []Bind) Module {
-	return Module{n, e, is, bs}
+func NewModule(n ModuleName, e Export, is []Import, ts []TypeDefinition, bs []Bind) Module {
+	return Module{n, e, is, ts, bs, false}
+}
+
+// WithoutPrelude returns a module which opts out of the implicit prelude
+// import.
+func (m Module) WithoutPrelude() Module {
+	m.noPrelude = true
+	return m
+}
+
+// ImportsPrelude checks if a module is yet to import the prelude implicitly.
+func (m Module) ImportsPrelude() bool {
+	return !m.noPrelude
+}
+
+// ImportPrelude adds an import of the prelude module unless the module opts
+// out of it.
+func (m Module) ImportPrelude(n ModuleName) Module {
+	if m.noPrelude {
+		return m
+	}
+
+	m.imports = append([]Import{NewPreludeImport(n)}, m.imports...)
+	m.noPrelude = true
+
+	return m
 }
 
 // Name returns a name.
@@ -30,11 +57,22 @@ func (m Module) Imports() []Import {
 	return m.imports
 }
 
+// TypeDefinitions returns type definitions.
+func (m Module) TypeDefinitions() []TypeDefinition {
+	return m.typeDefinitions
+}
+
 // Binds returns binds.
 func (m Module) Binds() []Bind {
 	return m.binds
 }
 
+// AddBinds returns a module with additional binds.
+func (m Module) AddBinds(bs []Bind) Module {
+	m.binds = append(append([]Bind{}, m.binds...), bs...)
+	return m
+}
+
 // ExportedBinds returns binds.
 func (m Module) ExportedBinds() []Bind {
 	ss := make(map[string]struct{}, len(m.export.Names()))
@@ -72,7 +110,9 @@ func (m Module) ConvertExpressions(f func(Expression) Expression) Module {
 		bs = append(bs, b.ConvertExpressions(f))
 	}
 
-	return NewModule(m.name, m.export, m.imports, bs)
+	m.binds = bs
+
+	return m
 }
 
 // VisitTypes visits types.
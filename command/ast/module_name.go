@@ -6,7 +6,10 @@ import (
 	"strings"
 )
 
-const fileExtension = ".ein"
+const (
+	fileExtension     = ".ein"
+	jsonFileExtension = ".json"
+)
 
 // ModuleName is a unique module name.
 type ModuleName (string)
@@ -19,7 +22,12 @@ func NewModuleName(f, rootDir string) (ModuleName, error) {
 		return "", err
 	}
 
-	f, err = filepath.Abs(strings.TrimSuffix(f, filepath.Ext(f)))
+	// Extensions of JSON files are kept to distinguish them from source files.
+	if e := filepath.Ext(f); e != jsonFileExtension {
+		f = strings.TrimSuffix(f, e)
+	}
+
+	f, err = filepath.Abs(f)
 
 	if err != nil {
 		return "", err
@@ -43,19 +51,34 @@ func NewModuleNameFromString(s string) ModuleName {
 	return ModuleName(s)
 }
 
-// Qualify qualifies a name in a module.
-func (n ModuleName) Qualify(s string) string {
-	return path.Base(string(n)) + "." + s
-}
-
 // FullyQualify qualifies a name in a module.
 func (n ModuleName) FullyQualify(s string) string {
 	return string(n) + "." + s
 }
 
+// IsJSON checks if a module is a JSON file.
+func (n ModuleName) IsJSON() bool {
+	return path.Ext(string(n)) == jsonFileExtension
+}
+
+// Qualifier returns a default qualifier of names in a module.
+func (n ModuleName) Qualifier() string {
+	s := path.Base(string(n))
+
+	if n.IsJSON() {
+		return strings.TrimSuffix(s, jsonFileExtension)
+	}
+
+	return s
+}
+
 // ToPath converts a module name to a path.
 func (n ModuleName) ToPath(rootDir string) string {
-	s := string(n) + fileExtension
+	s := string(n)
+
+	if !n.IsJSON() {
+		s += fileExtension
+	}
 
 	if path.IsAbs(s) {
 		return s
@@ -24,3 +24,19 @@ func TestModuleNameToPath(t *testing.T) {
 
 	assert.Equal(t, "/foo/bar.ein", n.ToPath("/foo"))
 }
+
+func TestNewModuleNameWithJSONFiles(t *testing.T) {
+	n, err := NewModuleName("/foo/bar.json", "/foo")
+	assert.Nil(t, err)
+	assert.Equal(t, ModuleName("bar.json"), n)
+	assert.True(t, n.IsJSON())
+}
+
+func TestModuleNameQualifier(t *testing.T) {
+	assert.Equal(t, "bar", ModuleName("foo/bar").Qualifier())
+	assert.Equal(t, "bar", ModuleName("foo/bar.json").Qualifier())
+}
+
+func TestModuleNameToPathWithJSONFiles(t *testing.T) {
+	assert.Equal(t, "/foo/bar.json", ModuleName("bar.json").ToPath("/foo"))
+}
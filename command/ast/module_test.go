@@ -14,6 +14,7 @@ func TestModuleIsMainModule(t *testing.T) {
 			"",
 			NewExport(),
 			nil,
+			nil,
 			[]Bind{NewBind("main", types.NewNumber(nil), NewNumber(42))},
 		).IsMainModule(),
 	)
@@ -23,6 +24,7 @@ func TestModuleIsMainModule(t *testing.T) {
 			"",
 			NewExport(),
 			nil,
+			nil,
 			[]Bind{NewBind("x", types.NewNumber(nil), NewNumber(42))},
 		).IsMainModule(),
 	)
@@ -36,6 +38,7 @@ func TestModuleExportedBinds(t *testing.T) {
 			"",
 			NewExport("x"),
 			nil,
+			nil,
 			[]Bind{NewBind("x", types.NewNumber(nil), NewNumber(42))},
 		).ExportedBinds(),
 	)
@@ -46,7 +49,36 @@ func TestModuleExportedBinds(t *testing.T) {
 			"",
 			NewExport(),
 			nil,
+			nil,
 			[]Bind{NewBind("x", types.NewNumber(nil), NewNumber(42))},
 		).ExportedBinds(),
 	)
 }
+
+func TestModuleImportPrelude(t *testing.T) {
+	m := NewModule("foo", NewExport(), []Import{NewImport("bar", nil)}, nil, nil)
+
+	assert.True(t, m.ImportsPrelude())
+
+	m = m.ImportPrelude("prelude")
+
+	assert.Equal(t, []Import{NewPreludeImport("prelude"), NewImport("bar", nil)}, m.Imports())
+	assert.False(t, m.ImportsPrelude())
+	assert.Equal(t, m, m.ImportPrelude("prelude"))
+}
+
+func TestModuleImportPreludeWithoutPrelude(t *testing.T) {
+	m := NewModule("foo", NewExport(), nil, nil, nil).WithoutPrelude()
+
+	assert.False(t, m.ImportsPrelude())
+	assert.Equal(t, []Import(nil), m.ImportPrelude("prelude").Imports())
+}
+
+func TestModuleAddBinds(t *testing.T) {
+	b := NewBind("x", types.NewNumber(nil), NewNumber(42))
+	m := NewModule("foo", NewExport(), nil, nil, []Bind{b})
+	bb := NewBind("y", types.NewNumber(nil), NewNumber(42))
+
+	assert.Equal(t, []Bind{b, bb}, m.AddBinds([]Bind{bb}).Binds())
+	assert.Equal(t, []Bind{b}, m.Binds())
+}
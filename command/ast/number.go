@@ -5,11 +5,17 @@ import "github.com/raviqqe/lazy-ein/command/types"
 // Number is a number.
 type Number struct {
 	value float64
+	typ   types.Type
 }
 
 // NewNumber creates a number.
 func NewNumber(n float64) Number {
-	return Number{n}
+	return NewNumberWithType(n, types.NewUnknown(nil))
+}
+
+// NewNumberWithType creates a number with its type.
+func NewNumberWithType(n float64, t types.Type) Number {
+	return Number{n, t}
 }
 
 // Value returns a value.
@@ -17,6 +23,11 @@ func (n Number) Value() float64 {
 	return n.value
 }
 
+// Type returns a type.
+func (n Number) Type() types.Type {
+	return n.typ
+}
+
 // ConvertExpressions converts expressions.
 func (n Number) ConvertExpressions(f func(Expression) Expression) Expression {
 	return f(n)
@@ -24,7 +35,7 @@ func (n Number) ConvertExpressions(f func(Expression) Expression) Expression {
 
 // VisitTypes visits types.
 func (n Number) VisitTypes(f func(types.Type) error) error {
-	return nil
+	return n.typ.VisitTypes(f)
 }
 
 func (Number) isExpression() {}
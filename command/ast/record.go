@@ -0,0 +1,60 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// Record is a record.
+type Record struct {
+	typ    types.Type
+	fields []RecordField
+}
+
+// NewRecord creates a record.
+func NewRecord(t types.Type, fs []RecordField) Record {
+	return Record{t, fs}
+}
+
+// Type returns a type.
+func (r Record) Type() types.Type {
+	return r.typ
+}
+
+// Fields returns fields.
+func (r Record) Fields() []RecordField {
+	return r.fields
+}
+
+// ConvertExpressions converts expressions.
+func (r Record) ConvertExpressions(f func(Expression) Expression) Expression {
+	return f(NewRecord(r.typ, convertRecordFields(r.fields, f)))
+}
+
+// VisitTypes visits types.
+func (r Record) VisitTypes(f func(types.Type) error) error {
+	if err := r.typ.VisitTypes(f); err != nil {
+		return err
+	}
+
+	return visitRecordFields(r.fields, f)
+}
+
+func (Record) isExpression() {}
+
+func convertRecordFields(fs []RecordField, f func(Expression) Expression) []RecordField {
+	ffs := make([]RecordField, 0, len(fs))
+
+	for _, ff := range fs {
+		ffs = append(ffs, ff.ConvertExpressions(f))
+	}
+
+	return ffs
+}
+
+func visitRecordFields(fs []RecordField, f func(types.Type) error) error {
+	for _, ff := range fs {
+		if err := ff.VisitTypes(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
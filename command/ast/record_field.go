@@ -0,0 +1,34 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// RecordField is a record field.
+type RecordField struct {
+	name       string
+	expression Expression
+}
+
+// NewRecordField creates a record field.
+func NewRecordField(n string, e Expression) RecordField {
+	return RecordField{n, e}
+}
+
+// Name returns a name.
+func (f RecordField) Name() string {
+	return f.name
+}
+
+// Expression returns an expression.
+func (f RecordField) Expression() Expression {
+	return f.expression
+}
+
+// ConvertExpressions converts expressions.
+func (f RecordField) ConvertExpressions(ff func(Expression) Expression) RecordField {
+	return RecordField{f.name, f.expression.ConvertExpressions(ff)}
+}
+
+// VisitTypes visits types.
+func (f RecordField) VisitTypes(ff func(types.Type) error) error {
+	return f.expression.VisitTypes(ff)
+}
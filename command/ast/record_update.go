@@ -0,0 +1,50 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// RecordUpdate is a record update.
+type RecordUpdate struct {
+	typ    types.Type
+	record Expression
+	fields []RecordField
+}
+
+// NewRecordUpdate creates a record update.
+func NewRecordUpdate(t types.Type, r Expression, fs []RecordField) RecordUpdate {
+	return RecordUpdate{t, r, fs}
+}
+
+// Type returns a type.
+func (u RecordUpdate) Type() types.Type {
+	return u.typ
+}
+
+// Record returns a record to update.
+func (u RecordUpdate) Record() Expression {
+	return u.record
+}
+
+// Fields returns fields.
+func (u RecordUpdate) Fields() []RecordField {
+	return u.fields
+}
+
+// ConvertExpressions converts expressions.
+func (u RecordUpdate) ConvertExpressions(f func(Expression) Expression) Expression {
+	return f(
+		NewRecordUpdate(u.typ, u.record.ConvertExpressions(f), convertRecordFields(u.fields, f)),
+	)
+}
+
+// VisitTypes visits types.
+func (u RecordUpdate) VisitTypes(f func(types.Type) error) error {
+	if err := u.typ.VisitTypes(f); err != nil {
+		return err
+	} else if err := u.record.VisitTypes(f); err != nil {
+		return err
+	}
+
+	return visitRecordFields(u.fields, f)
+}
+
+func (RecordUpdate) isExpression() {}
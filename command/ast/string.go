@@ -0,0 +1,31 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// String is a string.
+type String struct {
+	value string
+}
+
+// NewString creates a string.
+func NewString(s string) String {
+	return String{s}
+}
+
+// Value returns a value.
+func (s String) Value() string {
+	return s.value
+}
+
+// ConvertExpressions converts expressions.
+func (s String) ConvertExpressions(f func(Expression) Expression) Expression {
+	return f(s)
+}
+
+// VisitTypes visits types.
+func (s String) VisitTypes(f func(types.Type) error) error {
+	return nil
+}
+
+func (String) isExpression() {}
+func (String) isLiteral()    {}
@@ -0,0 +1,52 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// Tuple is a tuple.
+type Tuple struct {
+	typ      types.Type
+	elements []Expression
+}
+
+// NewTuple creates a tuple.
+func NewTuple(t types.Type, es []Expression) Tuple {
+	return Tuple{t, es}
+}
+
+// Type returns a type.
+func (t Tuple) Type() types.Type {
+	return t.typ
+}
+
+// Elements returns elements.
+func (t Tuple) Elements() []Expression {
+	return t.elements
+}
+
+// ConvertExpressions converts expressions.
+func (t Tuple) ConvertExpressions(f func(Expression) Expression) Expression {
+	es := make([]Expression, 0, len(t.elements))
+
+	for _, e := range t.elements {
+		es = append(es, e.ConvertExpressions(f).(Expression))
+	}
+
+	return f(NewTuple(t.typ, es))
+}
+
+// VisitTypes visits types.
+func (t Tuple) VisitTypes(f func(types.Type) error) error {
+	if err := t.typ.VisitTypes(f); err != nil {
+		return err
+	}
+
+	for _, e := range t.elements {
+		if err := e.VisitTypes(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (Tuple) isExpression() {}
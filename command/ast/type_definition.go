@@ -0,0 +1,24 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// TypeDefinition is a type definition.
+type TypeDefinition struct {
+	name string
+	typ  types.Type
+}
+
+// NewTypeDefinition creates a type definition.
+func NewTypeDefinition(n string, t types.Type) TypeDefinition {
+	return TypeDefinition{n, t}
+}
+
+// Name returns a name.
+func (d TypeDefinition) Name() string {
+	return d.name
+}
+
+// Type returns a type.
+func (d TypeDefinition) Type() types.Type {
+	return d.typ
+}
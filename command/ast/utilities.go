@@ -16,9 +16,21 @@ func PatternsEqual(e, ee Expression) bool {
 
 		return PatternsEqual(e.Arguments()[0].Expression(), l.Arguments()[0].Expression()) &&
 			PatternsEqual(NewList(e.Type(), e.Arguments()[1:]), NewList(l.Type(), l.Arguments()[1:]))
+	case Boolean:
+		b, ok := ee.(Boolean)
+		return ok && e.Value() == b.Value()
+	case ConstructorPattern:
+		p, ok := ee.(ConstructorPattern)
+		return ok && e.Constructor() == p.Constructor()
 	case Number:
 		n, ok := ee.(Number)
 		return ok && e.Value() == n.Value()
+	case String:
+		s, ok := ee.(String)
+		return ok && e.Value() == s.Value()
+	case Tuple:
+		_, ok := ee.(Tuple)
+		return ok
 	case Variable:
 		_, ok := ee.(Variable)
 		return ok
@@ -5,11 +5,17 @@ import "github.com/raviqqe/lazy-ein/command/types"
 // Variable is a variable.
 type Variable struct {
 	name string
+	typ  types.Type
 }
 
 // NewVariable creates a variable.
 func NewVariable(s string) Variable {
-	return Variable{s}
+	return NewVariableWithType(s, types.NewUnknown(nil))
+}
+
+// NewVariableWithType creates a variable with its type.
+func NewVariableWithType(s string, t types.Type) Variable {
+	return Variable{s, t}
 }
 
 // Name returns a name.
@@ -17,6 +23,11 @@ func (v Variable) Name() string {
 	return v.name
 }
 
+// Type returns a type.
+func (v Variable) Type() types.Type {
+	return v.typ
+}
+
 // ConvertExpressions converts expressions.
 func (v Variable) ConvertExpressions(f func(Expression) Expression) Expression {
 	return f(v)
@@ -24,7 +35,7 @@ func (v Variable) ConvertExpressions(f func(Expression) Expression) Expression {
 
 // VisitTypes visits types.
 func (v Variable) VisitTypes(f func(types.Type) error) error {
-	return nil
+	return v.typ.VisitTypes(f)
 }
 
 func (Variable) isExpression() {}
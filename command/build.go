@@ -10,24 +10,38 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var buildCommand = cobra.Command{
-	Use:   "build <filename>",
-	Short: "Build a source file into a binary",
-	Args:  cobra.ExactArgs(1),
-	Run: func(_ *cobra.Command, as []string) {
-		if err := runBuildCommand(as[0]); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-
-			if err, ok := err.(debug.Error); ok {
-				fmt.Fprintln(os.Stderr, err.DebugInformation())
+var buildLibraries []string
+
+var buildCommand = func() cobra.Command {
+	c := cobra.Command{
+		Use:   "build <filename>",
+		Short: "Build a source file into a binary",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, as []string) {
+			if err := runBuildCommand(as[0], buildLibraries); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+
+				if err, ok := err.(debug.Error); ok {
+					fmt.Fprintln(os.Stderr, err.DebugInformation())
+				}
+
+				os.Exit(1)
 			}
+		},
+	}
 
-			os.Exit(1)
-		}
-	},
-}
+	c.Flags().StringArrayVarP(
+		&buildLibraries,
+		"library",
+		"l",
+		nil,
+		"Link a library for foreign functions",
+	)
+
+	return c
+}()
 
-func runBuildCommand(f string) error {
+func runBuildCommand(f string, ls []string) error {
 	runtime, err := getRuntimePath()
 
 	if err != nil {
@@ -46,7 +60,7 @@ func runBuildCommand(f string) error {
 		return err
 	}
 
-	return build.Build(f, runtime, root, c)
+	return build.Build(f, runtime, root, c, ls)
 }
 
 func getCacheDirectory() (string, error) {
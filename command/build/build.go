@@ -1,6 +1,14 @@
 package build
 
-// Build builds an executable file or module.
-func Build(f, runtimeDir, rootDir, cacheDir string) error {
-	return newBuilder(runtimeDir, rootDir, cacheDir).Build(f)
+// Build builds an executable file or module. Libraries are linked to
+// executable files for foreign functions. The prelude module is imported
+// implicitly by every module which does not opt out of it.
+func Build(f, runtimeDir, rootDir, cacheDir string, ls []string) error {
+	b, err := newBuilder(runtimeDir, rootDir, cacheDir)
+
+	if err != nil {
+		return err
+	}
+
+	return b.Build(f, ls)
 }
@@ -19,7 +19,7 @@ func TestBuildWithMainModules(t *testing.T) {
 	assert.Nil(t, ioutil.WriteFile(n, []byte(source), 0644))
 	defer os.Remove(n)
 
-	assert.Nil(t, Build(n, "../..", rootDir, cacheDir))
+	assert.Nil(t, Build(n, "../..", rootDir, cacheDir, nil))
 
 	_, err := os.Stat("a.out")
 	assert.Nil(t, err)
@@ -35,7 +35,7 @@ func TestBuildWithSubmodules(t *testing.T) {
 	assert.Nil(t, ioutil.WriteFile(n, nil, 0644))
 	defer os.Remove(n)
 
-	assert.Nil(t, Build(n, "../..", rootDir, cacheDir))
+	assert.Nil(t, Build(n, "../..", rootDir, cacheDir, nil))
 
 	_, err := os.Stat("a.out")
 	assert.Error(t, err)
@@ -56,7 +56,85 @@ func TestBuildWithMainModulesImportingOtherModules(t *testing.T) {
 	)
 	defer os.Remove(n)
 
-	assert.Nil(t, Build(n, "../..", rootDir, cacheDir))
+	assert.Nil(t, Build(n, "../..", rootDir, cacheDir, nil))
+
+	_, err := os.Stat("a.out")
+	assert.Nil(t, err)
+
+	os.Remove("a.out")
+}
+
+func TestBuildWithMainModulesImportingPolymorphicBinds(t *testing.T) {
+	cacheDir, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	n := filepath.Join(rootDir, "foo.ein")
+	assert.Nil(
+		t,
+		ioutil.WriteFile(
+			n,
+			[]byte("export { f }\nf : a -> a\nf x = g x\ng : a -> a\ng x = let y = z in x\nz : Number\nz = 42"),
+			0644,
+		),
+	)
+	defer os.Remove(n)
+
+	n = filepath.Join(rootDir, "main.ein")
+	assert.Nil(
+		t,
+		ioutil.WriteFile(
+			n,
+			[]byte("import \"foo\"\nmain : Number -> Number\nmain x = foo.f (foo.f x) + foo.f 0"),
+			0644,
+		),
+	)
+	defer os.Remove(n)
+
+	assert.Nil(t, Build(n, "../..", rootDir, cacheDir, nil))
+
+	_, err := os.Stat("a.out")
+	assert.Nil(t, err)
+
+	os.Remove("a.out")
+}
+
+func TestBuildErrorWithMainModulesWithoutPrelude(t *testing.T) {
+	cacheDir, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	n := filepath.Join(rootDir, "main.ein")
+	assert.Nil(
+		t,
+		ioutil.WriteFile(
+			n,
+			[]byte("import prelude {}\nmain : Number -> Number\nmain x = sum [x]"),
+			0644,
+		),
+	)
+	defer os.Remove(n)
+
+	assert.Error(t, Build(n, "../..", rootDir, cacheDir, nil))
+
+	_, err := os.Stat("a.out")
+	assert.Error(t, err)
+}
+
+func TestBuildWithForeignFunctions(t *testing.T) {
+	cacheDir, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	n := filepath.Join(rootDir, "main.ein")
+	assert.Nil(
+		t,
+		ioutil.WriteFile(
+			n,
+			[]byte(`foreign import "sin" sin : Number -> Number`+"\n"+source+"\n  + sin 0"),
+			0644,
+		),
+	)
+	defer os.Remove(n)
+
+	assert.Nil(t, Build(n, "../..", rootDir, cacheDir, []string{"m"}))
 
 	_, err := os.Stat("a.out")
 	assert.Nil(t, err)
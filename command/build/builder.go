@@ -5,11 +5,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 
 	"github.com/raviqqe/lazy-ein/command/ast"
 	"github.com/raviqqe/lazy-ein/command/compile"
 	"github.com/raviqqe/lazy-ein/command/compile/metadata"
-	"github.com/raviqqe/lazy-ein/command/parse"
 	"github.com/llvm-mirror/llvm/bindings/go/llvm"
 )
 
@@ -18,17 +18,48 @@ const (
 	maxSizeOptimizationLevel = 2
 )
 
+const preludeFile = "runtime/prelude.ein"
+
 type builder struct {
-	runtimeDirectory, moduleRootDirectory string
-	objectCache                           objectCache
+	runtimeDirectory string
+	moduleParser     moduleParser
+	objectCache      objectCache
+	llvmModules      map[ast.ModuleName]llvm.Module
+	metadataModules  map[ast.ModuleName]metadata.Module
 }
 
-func newBuilder(runtimeDir, rootDir, cacheDir string) builder {
-	return builder{runtimeDir, rootDir, newObjectCache(cacheDir, rootDir)}
+func newBuilder(runtimeDir, rootDir, cacheDir string) (builder, error) {
+	// The prelude file path is made absolute so that it does not depend on
+	// working directories.
+	f, err := filepath.Abs(filepath.Join(runtimeDir, filepath.FromSlash(preludeFile)))
+
+	if err != nil {
+		return builder{}, err
+	}
+
+	p := newModuleParser(rootDir, f)
+
+	return builder{
+		runtimeDir,
+		p,
+		newObjectCache(cacheDir, p),
+		map[ast.ModuleName]llvm.Module{},
+		map[ast.ModuleName]metadata.Module{},
+	}, nil
 }
 
-func (b builder) Build(fname string) error {
-	m, _, err := b.buildModule(fname)
+func (b builder) Build(fname string, ls []string) error {
+	// Import cycles are detected in advance because both module building and
+	// object caching traverse imports recursively.
+	g, n, err := newModuleGraph(b.moduleParser, fname)
+
+	if err != nil {
+		return err
+	} else if err := g.ValidateAcyclicity(n); err != nil {
+		return err
+	}
+
+	m, md, err := b.buildModule(fname)
 
 	if err != nil {
 		return err
@@ -40,6 +71,10 @@ func (b builder) Build(fname string) error {
 		return nil
 	}
 
+	if err := b.linkLLVMModules(m, md.Name()); err != nil {
+		return err
+	}
+
 	bs, err := b.generateModule(m)
 
 	if err != nil {
@@ -58,8 +93,7 @@ func (b builder) Build(fname string) error {
 
 	defer os.Remove(f.Name())
 
-	bs, err = exec.Command(
-		"clang",
+	as := []string{
 		"-Wno-override-module",
 		"-O3",
 		"-flto",
@@ -67,52 +101,83 @@ func (b builder) Build(fname string) error {
 		b.resolveRuntimeLibrary("runtime/target/release/libio.a"),
 		b.resolveRuntimeLibrary("runtime/target/release/libcore.a"),
 		b.resolveRuntimeLibrary("runtime/llvm/atomic.ll"),
-		"-ldl",
-		"-lgc",
-		"-lpthread",
-	).CombinedOutput()
+	}
+
+	// Libraries of foreign functions are linked before system ones so that
+	// they can depend on them.
+	for _, l := range ls {
+		as = append(as, "-l"+l)
+	}
+
+	bs, err = exec.Command("clang", append(as, "-ldl", "-lgc", "-lpthread")...).CombinedOutput()
 
 	os.Stderr.Write(bs)
 
 	return err
 }
 
+// buildModule builds a module without linking its submodules. Each module is
+// built only once even if it is imported by several modules.
 func (b builder) buildModule(f string) (llvm.Module, metadata.Module, error) {
-	m, err := parse.Parse(f, b.moduleRootDirectory)
+	m, err := b.moduleParser.Parse(f)
 
 	if err != nil {
 		return llvm.Module{}, metadata.Module{}, err
+	} else if md, ok := b.metadataModules[m.Name()]; ok {
+		return b.llvmModules[m.Name()], md, nil
 	}
 
-	ms, mds, err := b.buildSubmodules(m)
+	mds, err := b.buildSubmodules(m)
 
 	if err != nil {
 		return llvm.Module{}, metadata.Module{}, err
 	}
 
-	mm, ok, err := b.objectCache.Get(f)
+	// Types of exported binds can be inferred without type signatures.
+	md, err := b.createMetadata(m, mds)
 
 	if err != nil {
 		return llvm.Module{}, metadata.Module{}, err
-	} else if ok {
-		return mm, metadata.NewModule(m), nil
 	}
 
-	mm, err = compile.Compile(m, mds)
+	mm, ok, err := b.objectCache.Get(f)
 
 	if err != nil {
 		return llvm.Module{}, metadata.Module{}, err
+	} else if !ok {
+		if mm, err = b.compileModule(m, mds); err != nil {
+			return llvm.Module{}, metadata.Module{}, err
+		} else if err = b.objectCache.Store(f, mm); err != nil {
+			return llvm.Module{}, metadata.Module{}, err
+		}
 	}
 
-	if err := b.linkLLVMModules(mm, ms); err != nil {
-		return llvm.Module{}, metadata.Module{}, err
+	b.llvmModules[m.Name()] = mm
+	b.metadataModules[m.Name()] = md
+
+	return mm, md, nil
+}
+
+func (b builder) compileModule(m ast.Module, mds []metadata.Module) (llvm.Module, error) {
+	mm, err := compile.Compile(m, mds)
+
+	if err != nil {
+		return llvm.Module{}, err
 	}
 
-	if err = b.objectCache.Store(f, mm); err != nil {
-		return llvm.Module{}, metadata.Module{}, err
+	b.optimize(mm)
+
+	return mm, llvm.VerifyModule(mm, llvm.AbortProcessAction)
+}
+
+func (builder) createMetadata(m ast.Module, ms []metadata.Module) (metadata.Module, error) {
+	m, err := compile.InferTypes(m, ms)
+
+	if err != nil {
+		return metadata.Module{}, err
 	}
 
-	return mm, metadata.NewModule(m), nil
+	return metadata.NewModule(m, ms)
 }
 
 func (b builder) generateModule(m llvm.Module) ([]byte, error) {
@@ -139,22 +204,20 @@ func (b builder) generateModule(m llvm.Module) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func (b builder) buildSubmodules(m ast.Module) ([]llvm.Module, []metadata.Module, error) {
-	ms := make([]llvm.Module, 0, len(m.Imports()))
+func (b builder) buildSubmodules(m ast.Module) ([]metadata.Module, error) {
 	mds := make([]metadata.Module, 0, len(m.Imports()))
 
 	for _, i := range m.Imports() {
-		m, md, err := b.buildModule(i.Name().ToPath(b.moduleRootDirectory))
+		_, md, err := b.buildModule(b.moduleParser.ResolvePath(i.Name()))
 
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
-		ms = append(ms, m)
 		mds = append(mds, md)
 	}
 
-	return ms, mds, nil
+	return mds, nil
 }
 
 func (b builder) resolveRuntimeLibrary(f string) string {
@@ -162,7 +225,7 @@ func (b builder) resolveRuntimeLibrary(f string) string {
 }
 
 func (b builder) isMainModule(f string) (bool, error) {
-	m, err := parse.Parse(f, b.moduleRootDirectory)
+	m, err := b.moduleParser.Parse(f)
 
 	if err != nil {
 		return false, err
@@ -171,9 +234,21 @@ func (b builder) isMainModule(f string) (bool, error) {
 	return m.IsMainModule(), err
 }
 
-func (b builder) linkLLVMModules(m llvm.Module, ms []llvm.Module) error {
-	for _, mm := range ms {
-		if err := llvm.LinkModules(m, mm); err != nil {
+// linkLLVMModules links all modules built so far into a main module so that
+// each of them is linked exactly once.
+func (b builder) linkLLVMModules(m llvm.Module, n ast.ModuleName) error {
+	ns := make([]string, 0, len(b.llvmModules))
+
+	for nn := range b.llvmModules {
+		if nn != n {
+			ns = append(ns, string(nn))
+		}
+	}
+
+	sort.Strings(ns)
+
+	for _, nn := range ns {
+		if err := llvm.LinkModules(m, b.llvmModules[ast.ModuleName(nn)]); err != nil {
 			return err
 		}
 	}
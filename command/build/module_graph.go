@@ -0,0 +1,105 @@
+package build
+
+import (
+	"strings"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// moduleGraph is a dependency graph of modules connected by their imports.
+type moduleGraph struct {
+	imports map[ast.ModuleName][]ast.Import
+}
+
+func newModuleGraph(p moduleParser, f string) (moduleGraph, ast.ModuleName, error) {
+	m, err := p.Parse(f)
+
+	if err != nil {
+		return moduleGraph{}, "", err
+	}
+
+	g := moduleGraph{map[ast.ModuleName][]ast.Import{}}
+
+	if err := g.addModule(p, m); err != nil {
+		return moduleGraph{}, "", err
+	}
+
+	return g, m.Name(), nil
+}
+
+func (g moduleGraph) addModule(p moduleParser, m ast.Module) error {
+	g.imports[m.Name()] = m.Imports()
+
+	for _, i := range m.Imports() {
+		if _, ok := g.imports[i.Name()]; ok {
+			continue
+		}
+
+		m, err := p.Parse(p.ResolvePath(i.Name()))
+
+		if err != nil {
+			return err
+		} else if err := g.addModule(p, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateAcyclicity validates that no module imports itself directly or
+// indirectly. Its error contains positions of all imports in a cycle.
+func (g moduleGraph) ValidateAcyclicity(n ast.ModuleName) error {
+	return g.validateAcyclicity(n, nil, nil, map[ast.ModuleName]struct{}{})
+}
+
+func (g moduleGraph) validateAcyclicity(
+	n ast.ModuleName,
+	ns []ast.ModuleName,
+	is []ast.Import,
+	vs map[ast.ModuleName]struct{},
+) error {
+	if _, ok := vs[n]; ok {
+		return nil
+	}
+
+	for k, nn := range ns {
+		if nn == n {
+			return newCircularImportError(append(ns[k:], n), is[k:])
+		}
+	}
+
+	for _, i := range g.imports[n] {
+		if err := g.validateAcyclicity(i.Name(), append(ns, n), append(is, i), vs); err != nil {
+			return err
+		}
+	}
+
+	vs[n] = struct{}{}
+
+	return nil
+}
+
+func newCircularImportError(ns []ast.ModuleName, is []ast.Import) error {
+	ss := make([]string, 0, len(ns))
+
+	for _, n := range ns {
+		ss = append(ss, string(n))
+	}
+
+	s := "circular imports detected: " + strings.Join(ss, " -> ")
+	var i *debug.Information
+
+	for _, ii := range is {
+		if ii.DebugInformation() == nil {
+			continue
+		} else if i == nil {
+			i = ii.DebugInformation()
+		}
+
+		s += "\n\t" + ii.DebugInformation().String()
+	}
+
+	return debug.NewError("ImportError", s, i)
+}
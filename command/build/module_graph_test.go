@@ -0,0 +1,78 @@
+package build
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/debug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleGraphValidateAcyclicity(t *testing.T) {
+	_, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	for n, s := range map[string]string{
+		"foo.ein": "import \"bar\"\nimport \"baz\"\n",
+		"bar.ein": "import \"baz\"\n",
+		"baz.ein": "",
+	} {
+		n := filepath.Join(rootDir, n)
+		assert.Nil(t, ioutil.WriteFile(n, []byte(s), 0644))
+		defer os.Remove(n)
+	}
+
+	g, n, err := newModuleGraph(newModuleParser(rootDir, ""), filepath.Join(rootDir, "foo.ein"))
+	assert.Nil(t, err)
+
+	assert.Nil(t, g.ValidateAcyclicity(n))
+}
+
+func TestModuleGraphValidateAcyclicityError(t *testing.T) {
+	_, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	for n, s := range map[string]string{
+		"foo.ein": "import \"bar\"\n",
+		"bar.ein": "import \"baz\"\n",
+		"baz.ein": "import \"bar\"\n",
+	} {
+		n := filepath.Join(rootDir, n)
+		assert.Nil(t, ioutil.WriteFile(n, []byte(s), 0644))
+		defer os.Remove(n)
+	}
+
+	g, n, err := newModuleGraph(newModuleParser(rootDir, ""), filepath.Join(rootDir, "foo.ein"))
+	assert.Nil(t, err)
+
+	err = g.ValidateAcyclicity(n)
+
+	assert.Equal(
+		t,
+		"ImportError: circular imports detected: bar -> baz -> bar\n"+
+			"\tbar:2:1:\timport \"baz\"\n"+
+			"\tbaz:2:1:\timport \"bar\"",
+		err.Error(),
+	)
+	assert.Equal(
+		t,
+		debug.NewInformation("bar", 2, 1, "import \"baz\""),
+		err.(debug.Error).DebugInformation(),
+	)
+}
+
+func TestModuleGraphValidateAcyclicityErrorWithSelfImports(t *testing.T) {
+	_, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	n := filepath.Join(rootDir, "foo.ein")
+	assert.Nil(t, ioutil.WriteFile(n, []byte("import \"foo\"\n"), 0644))
+	defer os.Remove(n)
+
+	g, nn, err := newModuleGraph(newModuleParser(rootDir, ""), n)
+	assert.Nil(t, err)
+
+	assert.Error(t, g.ValidateAcyclicity(nn))
+}
@@ -0,0 +1,40 @@
+package build
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/parse"
+)
+
+type moduleParser struct {
+	moduleRootDirectory, preludeFile string
+}
+
+func newModuleParser(rootDir, preludeFile string) moduleParser {
+	return moduleParser{rootDir, preludeFile}
+}
+
+// Parse parses a module file and adds an implicit import of the prelude module
+// to it unless the module opts out of it.
+func (p moduleParser) Parse(f string) (ast.Module, error) {
+	m, err := parse.Parse(f, p.moduleRootDirectory)
+
+	if err != nil {
+		return ast.Module{}, err
+	} else if !m.ImportsPrelude() {
+		return m, nil
+	}
+
+	n, err := ast.NewModuleName(p.preludeFile, p.moduleRootDirectory)
+
+	if err != nil {
+		return ast.Module{}, err
+	} else if m.Name() == n {
+		return m, nil
+	}
+
+	return m.ImportPrelude(n), nil
+}
+
+func (p moduleParser) ResolvePath(n ast.ModuleName) string {
+	return n.ToPath(p.moduleRootDirectory)
+}
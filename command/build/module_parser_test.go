@@ -0,0 +1,80 @@
+package build
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleParserParse(t *testing.T) {
+	_, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	n := filepath.Join(rootDir, "foo.ein")
+	assert.Nil(t, ioutil.WriteFile(n, []byte("import \"bar\"\nx : Number\nx = 42"), 0644))
+	defer os.Remove(n)
+
+	p := filepath.Join(rootDir, "prelude.ein")
+	m, err := newModuleParser(rootDir, p).Parse(n)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(m.Imports()))
+	assert.True(t, m.Imports()[0].IsPrelude())
+
+	for i, n := range []ast.ModuleName{"prelude", "bar"} {
+		assert.Equal(t, n, m.Imports()[i].Name())
+		assert.Equal(t, n.Qualifier(), m.Imports()[i].Qualifier())
+	}
+}
+
+func TestModuleParserParseWithoutPrelude(t *testing.T) {
+	_, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	n := filepath.Join(rootDir, "foo.ein")
+	assert.Nil(
+		t,
+		ioutil.WriteFile(n, []byte("import prelude {}\nimport \"bar\"\nx : Number\nx = 42"), 0644),
+	)
+	defer os.Remove(n)
+
+	m, err := newModuleParser(rootDir, filepath.Join(rootDir, "prelude.ein")).Parse(n)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(m.Imports()))
+	assert.Equal(t, ast.ModuleName("bar"), m.Imports()[0].Name())
+	assert.False(t, m.Imports()[0].IsPrelude())
+}
+
+func TestModuleParserParsePrelude(t *testing.T) {
+	_, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	n := filepath.Join(rootDir, "prelude.ein")
+	assert.Nil(t, ioutil.WriteFile(n, []byte("x : Number\nx = 42"), 0644))
+	defer os.Remove(n)
+
+	m, err := newModuleParser(rootDir, n).Parse(n)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []ast.Import{}, m.Imports())
+}
+
+func TestModuleParserParseWithJSONFiles(t *testing.T) {
+	_, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	n := filepath.Join(rootDir, "config.json")
+	assert.Nil(t, ioutil.WriteFile(n, []byte(`{ "x": 42 }`), 0644))
+	defer os.Remove(n)
+
+	m, err := newModuleParser(rootDir, filepath.Join(rootDir, "prelude.ein")).Parse(n)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ast.ModuleName("config.json"), m.Name())
+	assert.Equal(t, []ast.Import(nil), m.Imports())
+}
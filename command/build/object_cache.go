@@ -9,16 +9,16 @@ import (
 	"path/filepath"
 
 	"github.com/raviqqe/lazy-ein/command/ast"
-	"github.com/raviqqe/lazy-ein/command/parse"
 	"github.com/llvm-mirror/llvm/bindings/go/llvm"
 )
 
 type objectCache struct {
-	cacheDirectory, moduleRootDirectory string
+	cacheDirectory string
+	moduleParser   moduleParser
 }
 
-func newObjectCache(cacheDir, rootDir string) objectCache {
-	return objectCache{cacheDir, rootDir}
+func newObjectCache(cacheDir string, p moduleParser) objectCache {
+	return objectCache{cacheDir, p}
 }
 
 func (c objectCache) Store(fname string, m llvm.Module) error {
@@ -77,7 +77,7 @@ func (c objectCache) generateModuleHash(h hash.Hash, f string) error {
 		return err
 	}
 
-	m, err := parse.Parse(f, c.moduleRootDirectory)
+	m, err := c.moduleParser.Parse(f)
 
 	if err != nil {
 		return err
@@ -95,7 +95,7 @@ func (c objectCache) generateModuleHash(h hash.Hash, f string) error {
 
 func (c objectCache) generateSubmodulesHash(h hash.Hash, m ast.Module) error {
 	for _, i := range m.Imports() {
-		if err := c.generateModuleHash(h, i.Name().ToPath(c.moduleRootDirectory)); err != nil {
+		if err := c.generateModuleHash(h, c.moduleParser.ResolvePath(i.Name())); err != nil {
 			return err
 		}
 	}
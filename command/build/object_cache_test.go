@@ -18,7 +18,7 @@ func TestObjectCacheStore(t *testing.T) {
 	assert.Nil(t, ioutil.WriteFile(n, []byte("main : Number -> Number\nmain x = 42"), 0644))
 	defer os.Remove(n)
 
-	c := newObjectCache(cacheDir, rootDir)
+	c := newObjectCache(cacheDir, newModuleParser(rootDir, getPreludeFile(t)))
 
 	m, ok, err := c.Get(n)
 
@@ -50,7 +50,7 @@ func TestObjectCacheGeneratePath(t *testing.T) {
 	)
 	defer os.Remove(n)
 
-	c := newObjectCache(cacheDir, rootDir)
+	c := newObjectCache(cacheDir, newModuleParser(rootDir, getPreludeFile(t)))
 	s, err := c.generatePath(n)
 
 	assert.Nil(t, err)
@@ -64,6 +64,35 @@ func TestObjectCacheGeneratePath(t *testing.T) {
 	assert.NotEqual(t, s, ss)
 }
 
+func TestObjectCacheGeneratePathWithJSONFiles(t *testing.T) {
+	cacheDir, rootDir, clean := setUpEnvironmentDirectories(t)
+	defer clean()
+
+	j := filepath.Join(rootDir, "config.json")
+	assert.Nil(t, ioutil.WriteFile(j, []byte(`{ "x": 42 }`), 0644))
+	defer os.Remove(j)
+
+	n := filepath.Join(rootDir, "main.ein")
+	assert.Nil(
+		t,
+		ioutil.WriteFile(n, []byte("import \"config.json\"\nmain : Number -> Number\nmain x = config.x"), 0644),
+	)
+	defer os.Remove(n)
+
+	c := newObjectCache(cacheDir, newModuleParser(rootDir, getPreludeFile(t)))
+	s, err := c.generatePath(n)
+
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", s)
+
+	assert.Nil(t, ioutil.WriteFile(j, []byte(`{ "x": 123 }`), 0644))
+
+	ss, err := c.generatePath(n)
+
+	assert.Nil(t, err)
+	assert.NotEqual(t, s, ss)
+}
+
 func TestObjectCacheGeneratePathWithUnnormalizedModulePaths(t *testing.T) {
 	cacheDir, rootDir, clean := setUpEnvironmentDirectories(t)
 	defer clean()
@@ -72,7 +101,7 @@ func TestObjectCacheGeneratePathWithUnnormalizedModulePaths(t *testing.T) {
 	assert.Nil(t, ioutil.WriteFile(n, []byte("export { x }\nx : Number\nx = 42"), 0644))
 	defer os.Remove(n)
 
-	c := newObjectCache(cacheDir, rootDir)
+	c := newObjectCache(cacheDir, newModuleParser(rootDir, getPreludeFile(t)))
 	s, err := c.generatePath(n)
 	assert.Nil(t, err)
 	assert.NotEqual(t, "", s)
@@ -3,6 +3,7 @@ package build
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,3 +21,10 @@ func setUpEnvironmentDirectories(t *testing.T) (string, string, func()) {
 		os.Remove(rootDir)
 	}
 }
+
+func getPreludeFile(t *testing.T) string {
+	f, err := filepath.Abs(filepath.Join("../..", preludeFile))
+	assert.Nil(t, err)
+
+	return f
+}
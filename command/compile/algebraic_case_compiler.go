@@ -0,0 +1,150 @@
+package compile
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+type algebraicCaseCompiler struct {
+	compiler
+}
+
+func newAlgebraicCaseCompiler(c compiler) algebraicCaseCompiler {
+	return algebraicCaseCompiler{c}
+}
+
+func (c algebraicCaseCompiler) Compile(cc ast.Case) (coreast.Expression, error) {
+	t := cc.Type().ToCore().(coretypes.Bindable)
+	arg, err := c.compileExpression(cc.Argument())
+
+	if err != nil {
+		return nil, err
+	}
+
+	as := make([]coreast.AlgebraicAlternative, 0, len(cc.Alternatives()))
+
+	for _, a := range cc.Alternatives() {
+		a, err := c.compileAlternative(cc.Type(), a)
+
+		if err != nil {
+			return nil, err
+		}
+
+		as = append(as, a)
+	}
+
+	d, ok := cc.DefaultAlternative()
+
+	if !ok {
+		return coreast.NewAlgebraicCaseWithoutDefault(arg, as), nil
+	}
+
+	e, err := c.addVariable(d.Variable(), t).compileExpression(d.Expression())
+
+	if err != nil {
+		return nil, err
+	} else if d.Variable() == "" {
+		return coreast.NewAlgebraicCase(arg, as, coreast.NewDefaultAlternative("", e)), nil
+	}
+
+	vs, err := c.compileFreeVariables(cc.Argument())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: Prove that name generation is not necessary here.
+	s := "$argument." + d.Variable()
+
+	return coreast.NewLet(
+		[]coreast.Bind{coreast.NewBind(s, coreast.NewVariableLambda(vs, arg, t))},
+		coreast.NewAlgebraicCase(
+			coreast.NewFunctionApplication(coreast.NewVariable(s), nil),
+			as,
+			coreast.NewDefaultAlternative(
+				"",
+				coreast.NewLet(
+					[]coreast.Bind{
+						coreast.NewBind(
+							d.Variable(),
+							coreast.NewVariableLambda(
+								[]coreast.Argument{coreast.NewArgument(s, t)},
+								coreast.NewFunctionApplication(coreast.NewVariable(s), nil),
+								t,
+							),
+						),
+					},
+					e,
+				),
+			),
+		),
+	), nil
+}
+
+func (c algebraicCaseCompiler) compileAlternative(
+	t types.Type,
+	a ast.Alternative,
+) (coreast.AlgebraicAlternative, error) {
+	if b, ok := a.Pattern().(ast.Boolean); ok {
+		e, err := c.compileExpression(a.Expression())
+
+		if err != nil {
+			return coreast.AlgebraicAlternative{}, err
+		}
+
+		return coreast.NewAlgebraicAlternative(
+			types.NewBoolean(nil).CoreConstructor(b.Value()),
+			nil,
+			e,
+		), nil
+	} else if p, ok := a.Pattern().(ast.Tuple); ok {
+		return c.compileTupleAlternative(t.(types.Tuple), p, a.Expression())
+	}
+
+	p := a.Pattern().(ast.ConstructorPattern)
+	tt := t.(types.Algebraic)
+	i, _ := tt.ConstructorIndex(p.UnqualifiedConstructor())
+	ts := tt.ConstructorElements(i)
+	ss := make([]string, 0, len(p.Arguments()))
+
+	for j, v := range p.Arguments() {
+		ss = append(ss, v.Name())
+		c = c.addVariable(v.Name(), ts[j].ToCore())
+	}
+
+	e, err := c.compileExpression(a.Expression())
+
+	if err != nil {
+		return coreast.AlgebraicAlternative{}, err
+	}
+
+	return coreast.NewAlgebraicAlternative(tt.CoreConstructor(i), ss, e), nil
+}
+
+func (c algebraicCaseCompiler) compileTupleAlternative(
+	t types.Tuple,
+	p ast.Tuple,
+	e ast.Expression,
+) (coreast.AlgebraicAlternative, error) {
+	ss := make([]string, 0, len(p.Elements()))
+
+	for i, e := range p.Elements() {
+		s := e.(ast.Variable).Name()
+		ss = append(ss, s)
+		c = c.addVariable(s, t.Elements()[i].ToCore())
+	}
+
+	ee, err := c.compileExpression(e)
+
+	if err != nil {
+		return coreast.AlgebraicAlternative{}, err
+	}
+
+	return coreast.NewAlgebraicAlternative(t.CoreConstructor(), ss, ee), nil
+}
+
+func (c algebraicCaseCompiler) addVariable(s string, t coretypes.Type) algebraicCaseCompiler {
+	return algebraicCaseCompiler{c.compiler.addVariable(s, t)}
+}
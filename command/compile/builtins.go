@@ -0,0 +1,292 @@
+package compile
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// Built-in functions prefixed with $ are aliases of user-visible ones which
+// cannot be shadowed by binds in modules.
+const (
+	toCodePointsName   = "$toCodePoints"
+	fromCodePointsName = "$fromCodePoints"
+	toIntName          = "$toInt"
+	toNumberName       = "$toNumber"
+	divName            = "$div"
+	modName            = "$mod"
+)
+
+var builtinOperators = map[string]coreast.PrimitiveOperator{
+	"toInt":      coreast.Float64ToInt64,
+	"toNumber":   coreast.Int64ToFloat64,
+	"div":        coreast.DivideInt64,
+	"mod":        coreast.ModuloInt64,
+	toIntName:    coreast.Float64ToInt64,
+	toNumberName: coreast.Int64ToFloat64,
+	divName:      coreast.DivideInt64,
+	modName:      coreast.ModuloInt64,
+}
+
+// findBuiltins finds built-in functions referenced in a module and not
+// shadowed by its top-level binds.
+func (compiler) findBuiltins(m ast.Module) []string {
+	gs := make(map[string]struct{}, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		gs[b.Name()] = struct{}{}
+	}
+
+	f := newFreeVariableFinder(gs)
+	ss := map[string]struct{}{}
+	ts := ast.BuiltinTypes()
+
+	for _, b := range m.Binds() {
+		for _, s := range f.Find(b.Expression()) {
+			if _, ok := ts[s]; ok {
+				ss[s] = struct{}{}
+			}
+		}
+	}
+
+	sss := make([]string, 0, len(ss))
+
+	for s := range ss {
+		sss = append(sss, s)
+	}
+
+	sort.Strings(sss)
+
+	return sss
+}
+
+func (c compiler) compileBuiltin(s string) coreast.Bind {
+	switch s {
+	case toCodePointsName:
+		return c.compileToCodePoints()
+	case fromCodePointsName:
+		return c.compileFromCodePoints()
+	}
+
+	t := ast.BuiltinTypes()[s]
+	ts := []numberType{}
+
+	for {
+		f, ok := t.(types.Function)
+
+		if !ok {
+			break
+		}
+
+		ts = append(ts, f.Argument().(numberType))
+		t = f.Result()
+	}
+
+	return c.compileNumberFunction(
+		s,
+		ts,
+		t.(numberType),
+		func(as []coreast.Atom) coreast.Expression {
+			return coreast.NewPrimitiveOperation(builtinOperators[s], as)
+		},
+	)
+}
+
+// compileNumberFunction compiles a function which unboxes its arguments of
+// numbers, applies a function to them, and boxes its result.
+func (c compiler) compileNumberFunction(
+	s string,
+	ts []numberType,
+	r numberType,
+	f func([]coreast.Atom) coreast.Expression,
+) coreast.Bind {
+	as := make([]coreast.Argument, 0, len(ts))
+	vs := make([]coreast.Atom, 0, len(ts))
+
+	for i, t := range ts {
+		as = append(as, coreast.NewArgument(fmt.Sprintf("$argument-%v", i), t.ToCore()))
+		vs = append(vs, coreast.NewVariable(fmt.Sprintf("$primitive-%v", i)))
+	}
+
+	e := coreast.Expression(
+		coreast.NewPrimitiveCase(
+			f(vs),
+			primitiveType(r),
+			nil,
+			coreast.NewDefaultAlternative(
+				"$result",
+				coreast.NewConstructorApplication(
+					r.CoreConstructor(),
+					[]coreast.Atom{coreast.NewVariable("$result")},
+				),
+			),
+		),
+	)
+
+	for i := len(ts) - 1; i >= 0; i-- {
+		e = c.bindNumberPrimitive(
+			ts[i],
+			coreast.NewFunctionApplication(coreast.NewVariable(as[i].Name()), nil),
+			vs[i].(coreast.Variable).Name(),
+			e,
+		)
+	}
+
+	return coreast.NewBind(
+		s,
+		coreast.NewFunctionLambda(
+			nil,
+			as,
+			coreast.NewLet(
+				[]coreast.Bind{
+					coreast.NewBind(
+						"$boxedResult",
+						coreast.NewVariableLambda(as, e, coretypes.Unbox(r.ToCore()).(coretypes.Algebraic)),
+					),
+				},
+				coreast.NewFunctionApplication(coreast.NewVariable("$boxedResult"), nil),
+			),
+			r.ToCore(),
+		),
+	)
+}
+
+// compileToCodePoints compiles a function which converts a string into a list
+// of boxed code points lazily.
+func (c compiler) compileToCodePoints() coreast.Bind {
+	s := coretypes.Unbox(types.NewString(nil).ToCore()).(coretypes.Algebraic)
+	l := coretypes.Unbox(codePointsType.ToCore()).(coretypes.Algebraic)
+	n := types.NewNumber(nil)
+	a := coreast.NewArgument("$string", coretypes.NewBoxed(s))
+
+	return c.compileCodePointConversion(
+		toCodePointsName,
+		a,
+		l,
+		coreast.NewAlgebraicCaseWithoutDefault(
+			coreast.NewFunctionApplication(coreast.NewVariable(a.Name()), nil),
+			[]coreast.AlgebraicAlternative{
+				coreast.NewAlgebraicAlternative(
+					coreast.NewConstructor(s, 0),
+					[]string{"$head", "$tail"},
+					coreast.NewLet(
+						[]coreast.Bind{
+							coreast.NewBind(
+								"$codePoint",
+								coreast.NewVariableLambda(
+									[]coreast.Argument{coreast.NewArgument("$head", coretypes.NewFloat64())},
+									coreast.NewConstructorApplication(
+										n.CoreConstructor(),
+										[]coreast.Atom{coreast.NewVariable("$head")},
+									),
+									coretypes.Unbox(n.ToCore()).(coretypes.Algebraic),
+								),
+							),
+							c.compileCodePointConversionTail(toCodePointsName, "$codePoints", a, l),
+						},
+						coreast.NewConstructorApplication(
+							coreast.NewConstructor(l, 0),
+							[]coreast.Atom{coreast.NewVariable("$codePoint"), coreast.NewVariable("$codePoints")},
+						),
+					),
+				),
+				coreast.NewAlgebraicAlternative(
+					coreast.NewConstructor(s, 1),
+					nil,
+					coreast.NewConstructorApplication(coreast.NewConstructor(l, 1), nil),
+				),
+			},
+		),
+	)
+}
+
+// compileFromCodePoints compiles a function which converts a list of boxed
+// code points into a string lazily.
+func (c compiler) compileFromCodePoints() coreast.Bind {
+	s := coretypes.Unbox(types.NewString(nil).ToCore()).(coretypes.Algebraic)
+	l := coretypes.Unbox(codePointsType.ToCore()).(coretypes.Algebraic)
+	a := coreast.NewArgument("$codePoints", coretypes.NewBoxed(l))
+
+	return c.compileCodePointConversion(
+		fromCodePointsName,
+		a,
+		s,
+		coreast.NewAlgebraicCaseWithoutDefault(
+			coreast.NewFunctionApplication(coreast.NewVariable(a.Name()), nil),
+			[]coreast.AlgebraicAlternative{
+				coreast.NewAlgebraicAlternative(
+					coreast.NewConstructor(l, 0),
+					[]string{"$head", "$tail"},
+					c.bindNumberPrimitive(
+						types.NewNumber(nil),
+						coreast.NewFunctionApplication(coreast.NewVariable("$head"), nil),
+						"$codePoint",
+						coreast.NewLet(
+							[]coreast.Bind{
+								c.compileCodePointConversionTail(fromCodePointsName, "$string", a, s),
+							},
+							coreast.NewConstructorApplication(
+								coreast.NewConstructor(s, 0),
+								[]coreast.Atom{coreast.NewVariable("$codePoint"), coreast.NewVariable("$string")},
+							),
+						),
+					),
+				),
+				coreast.NewAlgebraicAlternative(
+					coreast.NewConstructor(l, 1),
+					nil,
+					coreast.NewConstructorApplication(coreast.NewConstructor(s, 1), nil),
+				),
+			},
+		),
+	)
+}
+
+func (compiler) compileCodePointConversion(
+	s string,
+	a coreast.Argument,
+	t coretypes.Algebraic,
+	e coreast.Expression,
+) coreast.Bind {
+	return coreast.NewBind(
+		s,
+		coreast.NewFunctionLambda(
+			nil,
+			[]coreast.Argument{a},
+			coreast.NewLet(
+				[]coreast.Bind{
+					coreast.NewBind(
+						"$boxedResult",
+						coreast.NewVariableLambda([]coreast.Argument{a}, e, t),
+					),
+				},
+				coreast.NewFunctionApplication(coreast.NewVariable("$boxedResult"), nil),
+			),
+			coretypes.NewBoxed(t),
+		),
+	)
+}
+
+// compileCodePointConversionTail compiles a thunk which converts tails of
+// lists recursively.
+func (compiler) compileCodePointConversionTail(
+	f, s string,
+	a coreast.Argument,
+	t coretypes.Algebraic,
+) coreast.Bind {
+	return coreast.NewBind(
+		s,
+		coreast.NewVariableLambda(
+			[]coreast.Argument{coreast.NewArgument("$tail", a.Type())},
+			coreast.NewFunctionApplication(
+				coreast.NewVariable(f),
+				[]coreast.Atom{coreast.NewVariable("$tail")},
+			),
+			coretypes.NewBoxed(t),
+		),
+	)
+}
@@ -7,43 +7,109 @@ import (
 	"github.com/raviqqe/lazy-ein/command/compile/tinfer"
 	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
 	corecompile "github.com/raviqqe/lazy-ein/command/core/compile"
+	"github.com/raviqqe/lazy-ein/command/types"
 	"github.com/llvm-mirror/llvm/bindings/go/llvm"
 )
 
 // Compile compiles a module into a module in the core language with imported modules.
 func Compile(m ast.Module, ms []metadata.Module) (llvm.Module, error) {
+	e := ""
+
+	// Printers of results of main functions are generated from their inferred
+	// types.
+	if m.IsMainModule() {
+		mm, err := InferTypes(m, ms)
+
+		if err != nil {
+			return llvm.Module{}, err
+		}
+
+		s, bs, err := generateMainWrapper(mm)
+
+		if err != nil {
+			return llvm.Module{}, err
+		}
+
+		e = s
+		m = m.AddBinds(bs)
+	}
+
 	mm, err := compileToCore(m, ms)
 
 	if err != nil {
 		return llvm.Module{}, err
 	}
 
-	return corecompile.Compile(renameGlobalVariables(mm, m, ms))
+	is, err := metadata.ResolveImports(m, ms)
+
+	if err != nil {
+		return llvm.Module{}, err
+	}
+
+	l, err := corecompile.Compile(renameGlobalVariables(mm, m, is))
+
+	if err != nil {
+		return llvm.Module{}, err
+	} else if e != "" {
+		generateMainFunction(l, e)
+	}
+
+	return l, nil
+}
+
+// InferTypes infers types of binds in a module with imported modules.
+func InferTypes(m ast.Module, ms []metadata.Module) (ast.Module, error) {
+	return tinfer.InferTypes(desugar.WithoutTypes(m), ms)
 }
 
 func compileToCore(m ast.Module, ms []metadata.Module) (coreast.Module, error) {
-	m, err := tinfer.InferTypes(desugar.WithoutTypes(m), ms)
+	m, err := InferTypes(m, ms)
 
 	if err != nil {
 		return coreast.Module{}, err
 	}
 
-	return newCompiler().Compile(desugar.WithTypes(m), ms)
+	return newCompiler().Compile(desugar.WithTypes(removePolymorphicBinds(m)), ms)
 }
 
-func renameGlobalVariables(m coreast.Module, mm ast.Module, ms []metadata.Module) coreast.Module {
-	vs := make(map[string]string, len(m.Binds()))
+// removePolymorphicBinds removes polymorphic binds which are kept after type
+// inference only to be exported.
+func removePolymorphicBinds(m ast.Module) ast.Module {
+	bs := make([]ast.Bind, 0, len(m.Binds()))
 
-	for _, m := range ms {
-		for n := range m.ExportedBinds() {
-			vs[m.Name().Qualify(n)] = m.Name().FullyQualify(n)
+	for _, b := range m.Binds() {
+		if !types.IsPolymorphic(b.Type()) {
+			bs = append(bs, b)
 		}
 	}
 
+	return ast.NewModule(m.Name(), m.Export(), m.Imports(), m.TypeDefinitions(), bs)
+}
+
+func renameGlobalVariables(
+	m coreast.Module,
+	mm ast.Module,
+	is map[string]metadata.ImportedBind,
+) coreast.Module {
+	vs := make(map[string]string, len(m.Binds())+len(is))
+
+	for s, b := range is {
+		vs[s] = b.Name()
+	}
+
 	ds := make([]coreast.Declaration, 0, len(m.Declarations()))
+	ss := make(map[string]struct{}, len(m.Declarations()))
 
 	for _, d := range m.Declarations() {
-		ds = append(ds, coreast.NewDeclaration(vs[d.Name()], d.Lambda()))
+		s := vs[d.Name()]
+
+		// Re-exported names can refer to the same binds in different modules.
+		if _, ok := ss[s]; ok {
+			continue
+		}
+
+		ss[s] = struct{}{}
+		ds = append(ds, coreast.NewDeclaration(s, d.Lambda()))
 	}
 
 	bs := make([]coreast.Bind, 0, len(m.Binds()))
@@ -53,12 +119,13 @@ func renameGlobalVariables(m coreast.Module, mm ast.Module, ms []metadata.Module
 
 		vs[b.Name()] = s
 
-		if b.Name() == ast.MainFunctionName {
-			bs = append(bs, coreast.NewBind("ein_main", b.Lambda()))
+		if b.Name() == mainWrapperName {
+			bs = append(bs, coreast.NewBind(mainGlobalVariableName, b.Lambda()))
 		} else {
 			bs = append(bs, coreast.NewBind(s, b.Lambda()))
 		}
 	}
 
-	return coreast.NewModule(ds, bs).RenameVariables(vs)
+	return coreast.NewModuleWithForeignDeclarations(m.ForeignDeclarations(), ds, bs).
+		RenameVariables(vs)
 }
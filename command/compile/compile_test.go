@@ -22,7 +22,7 @@ var consConstructor = coreast.NewConstructor(listAlgebraic, 0)
 var nilConstructor = coreast.NewConstructor(listAlgebraic, 1)
 
 func TestCompileWithEmptySource(t *testing.T) {
-	_, err := Compile(ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}), nil)
+	_, err := Compile(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}), nil)
 	assert.Nil(t, err)
 }
 
@@ -32,6 +32,7 @@ func TestCompileWithFunctionApplications(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"f",
@@ -60,6 +61,7 @@ func TestCompileWithNestedFunctionApplications(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"f",
@@ -93,6 +95,7 @@ func TestCompileWithDeeplyNestedFunctionApplicationsInLambdaExpressions(t *testi
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"f",
@@ -134,6 +137,7 @@ func TestCompileWithLists(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"x",
@@ -151,12 +155,84 @@ func TestCompileWithLists(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestCompileWithExpandedLists(t *testing.T) {
+	l := types.NewList(types.NewNumber(nil), nil)
+
+	for _, as := range [][]ast.ListArgument{
+		{
+			ast.NewListArgument(ast.NewNumber(42), false),
+			ast.NewListArgument(ast.NewNumber(42), false),
+		},
+		{
+			ast.NewListArgument(ast.NewVariable("x"), true),
+		},
+		{
+			ast.NewListArgument(ast.NewNumber(42), false),
+			ast.NewListArgument(ast.NewVariable("x"), true),
+		},
+		{
+			ast.NewListArgument(ast.NewVariable("x"), true),
+			ast.NewListArgument(ast.NewNumber(42), false),
+			ast.NewListArgument(ast.NewVariable("x"), true),
+			ast.NewListArgument(ast.NewVariable("x"), true),
+		},
+	} {
+		_, err := Compile(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"x",
+						l,
+						ast.NewList(l, []ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)}),
+					),
+					ast.NewBind("y", l, ast.NewList(l, as)),
+				},
+			),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithStrings(t *testing.T) {
+	for _, x := range []string{"", "foo", "ein言語"} {
+		_, err := Compile(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind("x", types.NewString(nil), ast.NewString(x)),
+					ast.NewBind(
+						"y",
+						types.NewList(types.NewString(nil), nil),
+						ast.NewList(
+							types.NewList(types.NewString(nil), nil),
+							[]ast.ListArgument{ast.NewListArgument(ast.NewString(x), false)},
+						),
+					),
+				},
+			),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
 func TestCompileErrorWithUnknownVariables(t *testing.T) {
 	_, err := Compile(
 		ast.NewModule(
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewVariable("y"))},
 		),
 		nil,
@@ -164,22 +240,33 @@ func TestCompileErrorWithUnknownVariables(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestCompilePanicWithUntypedGlobals(t *testing.T) {
-	assert.Panics(t, func() {
-		Compile(
-			ast.NewModule(
-				"",
-				ast.NewExport(),
-				nil,
-				[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), ast.NewNumber(42))},
-			),
+func TestCompileWithUntypedGlobals(t *testing.T) {
+	_, err := Compile(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
 			nil,
-		)
-	})
+			nil,
+			[]ast.Bind{
+				ast.NewBind("x", types.NewUnknown(nil), ast.NewNumber(42)),
+				ast.NewBind(
+					"f",
+					types.NewUnknown(nil),
+					ast.NewLambda(
+						[]string{"y"},
+						ast.NewBinaryOperation(ast.Add, ast.NewVariable("x"), ast.NewVariable("y")),
+					),
+				),
+			},
+		),
+		nil,
+	)
+
+	assert.Nil(t, err)
 }
 
 func TestCompileToCoreWithEmptySource(t *testing.T) {
-	m, err := compileToCore(ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}), nil)
+	m, err := compileToCore(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}), nil)
 	assert.Nil(t, err)
 
 	assert.Equal(t, coreast.NewModule(nil, []coreast.Bind{}), m)
@@ -191,6 +278,7 @@ func TestCompileToCoreWithVariableBinds(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
 		),
 		nil,
@@ -222,6 +310,7 @@ func TestCompileToCoreWithFunctionBinds(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"f",
@@ -279,6 +368,7 @@ func TestCompileToCoreWithLetExpressions(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"x",
@@ -339,6 +429,7 @@ func TestCompileToCoreWithLetExpressionsAndFreeVariables(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"f",
@@ -393,12 +484,67 @@ func TestCompileToCoreWithLetExpressionsAndFreeVariables(t *testing.T) {
 	)
 }
 
+func TestCompileToCoreWithLocalVariablesShadowingGlobals(t *testing.T) {
+	m, err := compileToCore(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+					ast.NewLambda(
+						[]string{"x"},
+						ast.NewLet(
+							[]ast.Bind{ast.NewBind("g", types.NewNumber(nil), ast.NewVariable("x"))},
+							ast.NewLet(
+								[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("g"))},
+								ast.NewVariable("y"),
+							),
+						),
+					),
+				),
+				ast.NewBind(
+					"g",
+					types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+					ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+				),
+			},
+		),
+		nil,
+	)
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		coreast.NewLet(
+			[]coreast.Bind{
+				coreast.NewBind(
+					"y",
+					coreast.NewVariableLambda(
+						[]coreast.Argument{
+							coreast.NewArgument("g", coretypes.NewBoxed(numberAlgebraic)),
+						},
+						coreast.NewFunctionApplication(coreast.NewVariable("g"), nil),
+						coretypes.NewBoxed(numberAlgebraic),
+					),
+				),
+			},
+			coreast.NewFunctionApplication(coreast.NewVariable("y"), nil),
+		),
+		m.Binds()[0].Lambda().Body().(coreast.Let).Expression(),
+	)
+}
+
 func TestCompileToCoreWithNestedLetExpressionsInLambdaExpressions(t *testing.T) {
 	m, err := compileToCore(
 		ast.NewModule(
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"f",
@@ -480,6 +626,7 @@ func TestCompileToCoreWithLists(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"x",
@@ -559,6 +706,7 @@ func TestCompileToCoreWithListCaseExpressionsWithoutDefaultAlternatives(t *testi
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"x",
@@ -604,22 +752,6 @@ func TestCompileToCoreWithListCaseExpressionsWithoutDefaultAlternatives(t *testi
 						numberAlgebraic,
 					),
 				),
-				coreast.NewBind(
-					"$literal-1",
-					coreast.NewVariableLambda(
-						nil,
-						numberConstructorApplication(coreast.NewFloat64(42)),
-						numberAlgebraic,
-					),
-				),
-				coreast.NewBind(
-					"$literal-2",
-					coreast.NewVariableLambda(
-						nil,
-						numberConstructorApplication(coreast.NewFloat64(42)),
-						numberAlgebraic,
-					),
-				),
 				coreast.NewBind(
 					"x",
 					coreast.NewVariableLambda(
@@ -642,7 +774,7 @@ func TestCompileToCoreWithListCaseExpressionsWithoutDefaultAlternatives(t *testi
 												coreast.NewArgument("$nil", coretypes.NewBoxed(listAlgebraic)),
 											},
 											listConstructorApplication(
-												coreast.NewVariable("$literal-2"),
+												coreast.NewVariable("$literal-0"),
 												coreast.NewVariable("$nil"),
 											),
 											listAlgebraic,
@@ -696,7 +828,7 @@ func TestCompileToCoreWithListCaseExpressionsWithoutDefaultAlternatives(t *testi
 								coreast.NewAlgebraicAlternative(
 									nilConstructor,
 									nil,
-									coreast.NewFunctionApplication(coreast.NewVariable("$literal-1"), nil),
+									coreast.NewFunctionApplication(coreast.NewVariable("$literal-0"), nil),
 								),
 							},
 						),
@@ -715,6 +847,7 @@ func TestCompileToCoreWithBinaryOperations(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"x",
@@ -740,14 +873,6 @@ func TestCompileToCoreWithBinaryOperations(t *testing.T) {
 						numberAlgebraic,
 					),
 				),
-				coreast.NewBind(
-					"$literal-1",
-					coreast.NewVariableLambda(
-						nil,
-						numberConstructorApplication(coreast.NewFloat64(1)),
-						numberAlgebraic,
-					),
-				),
 				coreast.NewBind(
 					"x",
 					coreast.NewVariableLambda(
@@ -769,7 +894,7 @@ func TestCompileToCoreWithBinaryOperations(t *testing.T) {
 													[]string{"$lhs"},
 													coreast.NewAlgebraicCaseWithoutDefault(
 														coreast.NewFunctionApplication(
-															coreast.NewVariable("$literal-1"),
+															coreast.NewVariable("$literal-0"),
 															nil,
 														),
 														[]coreast.AlgebraicAlternative{
@@ -818,6 +943,7 @@ func TestCompileWithComplexBinaryOperations(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"x",
@@ -840,45 +966,143 @@ func TestCompileWithComplexBinaryOperations(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-func TestCompileWithCaseExpressions(t *testing.T) {
+func TestCompileWithComparisonOperations(t *testing.T) {
+	for _, o := range []ast.BinaryOperator{
+		ast.Equal,
+		ast.NotEqual,
+		ast.LessThan,
+		ast.LessThanOrEqual,
+		ast.GreaterThan,
+		ast.GreaterThanOrEqual,
+	} {
+		_, err := Compile(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"x",
+						types.NewBoolean(nil),
+						ast.NewBinaryOperation(
+							o,
+							ast.NewNumber(1),
+							ast.NewBinaryOperation(ast.Add, ast.NewNumber(2), ast.NewNumber(3)),
+						),
+					),
+				},
+			),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithBooleanCaseExpressions(t *testing.T) {
 	for _, c := range []ast.Case{
-		ast.NewCase(
-			ast.NewNumber(1),
+		ast.NewCaseWithoutDefault(
+			ast.NewBoolean(true),
 			types.NewUnknown(nil),
 			[]ast.Alternative{
-				ast.NewAlternative(ast.NewNumber(2), ast.NewNumber(3)),
+				ast.NewAlternative(ast.NewBoolean(true), ast.NewNumber(1)),
+				ast.NewAlternative(ast.NewBoolean(false), ast.NewNumber(2)),
 			},
-			ast.NewDefaultAlternative("y", ast.NewVariable("y")),
 		),
-		ast.NewCaseWithoutDefault(
-			ast.NewNumber(1),
+		ast.NewCase(
+			ast.NewBinaryOperation(ast.LessThan, ast.NewNumber(1), ast.NewNumber(2)),
 			types.NewUnknown(nil),
-			[]ast.Alternative{
-				ast.NewAlternative(ast.NewNumber(2), ast.NewNumber(3)),
-			},
+			[]ast.Alternative{ast.NewAlternative(ast.NewBoolean(true), ast.NewNumber(1))},
+			ast.NewDefaultAlternative("y", ast.NewNumber(2)),
 		),
 		ast.NewCase(
-			ast.NewNumber(1),
+			ast.NewBoolean(false),
 			types.NewUnknown(nil),
 			nil,
-			ast.NewDefaultAlternative("y", ast.NewVariable("y")),
+			ast.NewDefaultAlternative("y", ast.NewNumber(42)),
 		),
-		ast.NewCase(
-			ast.NewList(
-				types.NewUnknown(nil),
-				[]ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)},
+	} {
+		_, err := Compile(
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{ast.NewBind("x", types.NewNumber(nil), c)}),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithAlgebraicTypes(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Foo",
+		[]types.Constructor{
+			types.NewConstructor("Bar", []types.Type{types.NewNumber(nil), types.NewReference("Foo", nil)}),
+			types.NewConstructor("Baz", nil),
+		},
+		nil,
+	)
+
+	for _, b := range []ast.Bind{
+		ast.NewBind("x", a, ast.NewVariable("Baz")),
+		ast.NewBind(
+			"x",
+			a,
+			ast.NewApplication(
+				ast.NewVariable("Bar"),
+				[]ast.Expression{ast.NewNumber(42), ast.NewVariable("Baz")},
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(a, a, nil),
+			ast.NewApplication(ast.NewVariable("Bar"), []ast.Expression{ast.NewNumber(42)}),
+		),
+	} {
+		_, err := Compile(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				[]ast.TypeDefinition{ast.NewTypeDefinition("Foo", a)},
+				[]ast.Bind{b},
 			),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithAlgebraicCaseExpressions(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Foo",
+		[]types.Constructor{
+			types.NewConstructor("Bar", []types.Type{types.NewNumber(nil), types.NewReference("Foo", nil)}),
+			types.NewConstructor("Baz", nil),
+		},
+		nil,
+	)
+
+	for _, c := range []ast.Case{
+		ast.NewCaseWithoutDefault(
+			ast.NewVariable("y"),
 			types.NewUnknown(nil),
 			[]ast.Alternative{
 				ast.NewAlternative(
-					ast.NewList(
-						types.NewUnknown(nil),
-						[]ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)},
-					),
-					ast.NewNumber(42),
+					ast.NewConstructorPattern("Bar", []ast.Variable{ast.NewVariable("z"), ast.NewVariable("v")}),
+					ast.NewVariable("z"),
 				),
+				ast.NewAlternative(ast.NewConstructorPattern("Baz", nil), ast.NewNumber(42)),
 			},
-			ast.NewDefaultAlternative("y", ast.NewNumber(42)),
+		),
+		ast.NewCase(
+			ast.NewVariable("y"),
+			types.NewUnknown(nil),
+			[]ast.Alternative{ast.NewAlternative(ast.NewConstructorPattern("Baz", nil), ast.NewNumber(42))},
+			ast.NewDefaultAlternative(
+				"z",
+				ast.NewApplication(ast.NewVariable("x"), []ast.Expression{ast.NewVariable("z")}),
+			),
 		),
 	} {
 		_, err := Compile(
@@ -886,12 +1110,9 @@ func TestCompileWithCaseExpressions(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				[]ast.TypeDefinition{ast.NewTypeDefinition("Foo", a)},
 				[]ast.Bind{
-					ast.NewBind(
-						"x",
-						types.NewNumber(nil),
-						c,
-					),
+					ast.NewBind("x", types.NewFunction(a, types.NewNumber(nil), nil), ast.NewLambda([]string{"y"}, c)),
 				},
 			),
 			nil,
@@ -901,32 +1122,429 @@ func TestCompileWithCaseExpressions(t *testing.T) {
 	}
 }
 
-func TestCompileWithVariablesInImportedModules(t *testing.T) {
-	m, err := desugarModule(
-		ast.NewModule(
-			"foo/bar",
-			ast.NewExport("x"),
-			nil,
-			[]ast.Bind{
-				ast.NewBind(
-					"x",
-					types.NewNumber(nil),
-					ast.NewNumber(42),
-				),
-			},
-		),
+func TestCompileWithRecords(t *testing.T) {
+	r := types.NewRecord(
+		map[string]types.Type{"foo": types.NewNumber(nil), "bar": types.NewString(nil)},
+		nil,
 	)
-	assert.Nil(t, err)
 
-	_, err = Compile(
-		ast.NewModule(
-			"",
-			ast.NewExport(),
-			[]ast.Import{ast.NewImport("foo/bar")},
-			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("bar.x"))},
-		),
-		[]metadata.Module{metadata.NewModule(m)},
-	)
+	for _, b := range []ast.Bind{
+		ast.NewBind(
+			"x",
+			r,
+			ast.NewRecord(
+				types.NewUnknown(nil),
+				[]ast.RecordField{
+					ast.NewRecordField("foo", ast.NewNumber(42)),
+					ast.NewRecordField("bar", ast.NewString("baz")),
+				},
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(r, r, nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewRecordUpdate(
+					types.NewUnknown(nil),
+					ast.NewVariable("y"),
+					[]ast.RecordField{
+						ast.NewRecordField(
+							"foo",
+							ast.NewBinaryOperation(ast.Add, ast.NewVariable("y.foo"), ast.NewNumber(1)),
+						),
+					},
+				),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(r, types.NewString(nil), nil),
+			ast.NewLambda([]string{"y"}, ast.NewVariable("y.bar")),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(
+				types.NewRecord(
+					map[string]types.Type{
+						"foo": types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+					},
+					nil,
+				),
+				types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+				nil,
+			),
+			ast.NewLambda([]string{"y"}, ast.NewVariable("y.foo")),
+		),
+	} {
+		_, err := Compile(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithTuples(t *testing.T) {
+	tt := types.NewTuple([]types.Type{types.NewNumber(nil), types.NewString(nil)}, nil)
+
+	for _, b := range []ast.Bind{
+		ast.NewBind(
+			"x",
+			tt,
+			ast.NewTuple(
+				types.NewUnknown(nil),
+				[]ast.Expression{
+					ast.NewBinaryOperation(ast.Add, ast.NewNumber(42), ast.NewNumber(42)),
+					ast.NewString("foo"),
+				},
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(tt, types.NewNumber(nil), nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewCaseWithoutDefault(
+					ast.NewVariable("y"),
+					types.NewUnknown(nil),
+					[]ast.Alternative{
+						ast.NewAlternative(
+							ast.NewTuple(
+								types.NewUnknown(nil),
+								[]ast.Expression{ast.NewVariable("z"), ast.NewVariable("v")},
+							),
+							ast.NewVariable("z"),
+						),
+					},
+				),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(tt, types.NewString(nil), nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewLet(
+					[]ast.Bind{
+						ast.NewPatternBind(
+							ast.NewTuple(
+								types.NewUnknown(nil),
+								[]ast.Expression{ast.NewVariable("z"), ast.NewVariable("v")},
+							),
+							ast.NewVariable("y"),
+						),
+					},
+					ast.NewVariable("v"),
+				),
+			),
+		),
+	} {
+		_, err := Compile(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithPolymorphicBinds(t *testing.T) {
+	a := types.NewParameter("a", nil)
+
+	for _, bs := range [][]ast.Bind{
+		{
+			ast.NewBind(
+				"id",
+				types.NewFunction(a, a, nil),
+				ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+			),
+			ast.NewBind(
+				"x",
+				types.NewNumber(nil),
+				ast.NewApplication(ast.NewVariable("id"), []ast.Expression{ast.NewNumber(42)}),
+			),
+			ast.NewBind(
+				"y",
+				types.NewString(nil),
+				ast.NewApplication(ast.NewVariable("id"), []ast.Expression{ast.NewString("foo")}),
+			),
+		},
+		{
+			ast.NewBind(
+				"x",
+				types.NewNumber(nil),
+				ast.NewLet(
+					[]ast.Bind{
+						ast.NewBind(
+							"f",
+							types.NewUnknown(nil),
+							ast.NewLambda([]string{"y"}, ast.NewVariable("y")),
+						),
+						ast.NewBind(
+							"s",
+							types.NewUnknown(nil),
+							ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewString("foo")}),
+						),
+					},
+					ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewNumber(42)}),
+				),
+			),
+		},
+	} {
+		_, err := Compile(ast.NewModule("", ast.NewExport(), nil, nil, bs), nil)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithLambdaExpressions(t *testing.T) {
+	f := types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil)
+
+	for _, bs := range [][]ast.Bind{
+		{
+			ast.NewBind(
+				"f",
+				types.NewFunction(types.NewNumber(nil), f, nil),
+				ast.NewLambda(
+					[]string{"x"},
+					ast.NewLambda(
+						[]string{"y"},
+						ast.NewBinaryOperation(ast.Add, ast.NewVariable("x"), ast.NewVariable("y")),
+					),
+				),
+			),
+		},
+		{
+			ast.NewBind(
+				"apply",
+				types.NewFunction(f, f, nil),
+				ast.NewLambda(
+					[]string{"f", "x"},
+					ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+				),
+			),
+			ast.NewBind(
+				"x",
+				types.NewNumber(nil),
+				ast.NewApplication(
+					ast.NewVariable("apply"),
+					[]ast.Expression{
+						ast.NewLambda([]string{"y"}, ast.NewVariable("y")),
+						ast.NewNumber(42),
+					},
+				),
+			),
+		},
+		{
+			ast.NewBind(
+				"f",
+				f,
+				ast.NewIf(
+					ast.NewBoolean(true),
+					ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+					ast.NewLambda(
+						[]string{"x"},
+						ast.NewBinaryOperation(ast.Add, ast.NewVariable("x"), ast.NewNumber(1)),
+					),
+				),
+			),
+		},
+	} {
+		_, err := Compile(ast.NewModule("", ast.NewExport(), nil, nil, bs), nil)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithIfExpressions(t *testing.T) {
+	for _, e := range []ast.Expression{
+		ast.NewIf(ast.NewBoolean(true), ast.NewNumber(1), ast.NewNumber(2)),
+		ast.NewIf(
+			ast.NewBinaryOperation(ast.Equal, ast.NewNumber(1), ast.NewNumber(2)),
+			ast.NewBinaryOperation(ast.Add, ast.NewNumber(1), ast.NewNumber(2)),
+			ast.NewNumber(3),
+		),
+	} {
+		_, err := Compile(
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{ast.NewBind("x", types.NewNumber(nil), e)}),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithCaseExpressions(t *testing.T) {
+	for _, c := range []ast.Case{
+		ast.NewCase(
+			ast.NewNumber(1),
+			types.NewUnknown(nil),
+			[]ast.Alternative{
+				ast.NewAlternative(ast.NewNumber(2), ast.NewNumber(3)),
+			},
+			ast.NewDefaultAlternative("y", ast.NewVariable("y")),
+		),
+		ast.NewCaseWithoutDefault(
+			ast.NewNumber(1),
+			types.NewUnknown(nil),
+			[]ast.Alternative{
+				ast.NewAlternative(ast.NewNumber(2), ast.NewNumber(3)),
+			},
+		),
+		ast.NewCase(
+			ast.NewNumber(1),
+			types.NewUnknown(nil),
+			nil,
+			ast.NewDefaultAlternative("y", ast.NewVariable("y")),
+		),
+		ast.NewCase(
+			ast.NewList(
+				types.NewUnknown(nil),
+				[]ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)},
+			),
+			types.NewUnknown(nil),
+			[]ast.Alternative{
+				ast.NewAlternative(
+					ast.NewList(
+						types.NewUnknown(nil),
+						[]ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)},
+					),
+					ast.NewNumber(42),
+				),
+			},
+			ast.NewDefaultAlternative("y", ast.NewNumber(42)),
+		),
+	} {
+		_, err := Compile(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"x",
+						types.NewNumber(nil),
+						c,
+					),
+				},
+			),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithVariablesInImportedModules(t *testing.T) {
+	m, err := desugarModule(
+		ast.NewModule(
+			"foo/bar",
+			ast.NewExport("x"),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					types.NewNumber(nil),
+					ast.NewNumber(42),
+				),
+			},
+		),
+	)
+	assert.Nil(t, err)
+
+	_, err = Compile(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("foo/bar", nil)},
+			nil,
+			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("bar.x"))},
+		),
+		[]metadata.Module{newMetadataModule(m)},
+	)
+
+	assert.Nil(t, err)
+}
+
+func TestCompileWithAliasedAndSelectiveImports(t *testing.T) {
+	ms := []metadata.Module{}
+
+	for _, n := range []ast.ModuleName{"a/util", "b/util"} {
+		m, err := desugarModule(
+			ast.NewModule(
+				n,
+				ast.NewExport("x"),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+			),
+		)
+		assert.Nil(t, err)
+
+		ms = append(ms, newMetadataModule(m))
+	}
+
+	_, err := Compile(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{
+				ast.NewAliasedImport("a/util", "autil", nil),
+				ast.NewSelectiveImport("b/util", []string{"x"}, nil),
+			},
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"y",
+					types.NewNumber(nil),
+					ast.NewBinaryOperation(ast.Add, ast.NewVariable("autil.x"), ast.NewVariable("x")),
+				),
+			},
+		),
+		ms,
+	)
+
+	assert.Nil(t, err)
+}
+
+func TestCompileWithReexportedNames(t *testing.T) {
+	m, err := desugarModule(
+		ast.NewModule(
+			"a/util",
+			ast.NewExport("x"),
+			nil,
+			nil,
+			[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+		),
+	)
+	assert.Nil(t, err)
+
+	mm, err := metadata.NewModule(
+		ast.NewModule(
+			"a/facade",
+			ast.NewExportWithModules([]ast.ModuleName{"a/util"}),
+			[]ast.Import{ast.NewImport("a/util", nil)},
+			nil,
+			nil,
+		),
+		[]metadata.Module{newMetadataModule(m)},
+	)
+	assert.Nil(t, err)
+
+	_, err = Compile(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("a/util", nil), ast.NewImport("a/facade", nil)},
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"y",
+					types.NewNumber(nil),
+					ast.NewBinaryOperation(ast.Add, ast.NewVariable("util.x"), ast.NewVariable("facade.x")),
+				),
+			},
+		),
+		[]metadata.Module{newMetadataModule(m), mm},
+	)
 
 	assert.Nil(t, err)
 }
@@ -937,6 +1555,7 @@ func TestCompileWithFunctionsInImportedModules(t *testing.T) {
 			"foo/bar",
 			ast.NewExport("f"),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"f",
@@ -952,7 +1571,8 @@ func TestCompileWithFunctionsInImportedModules(t *testing.T) {
 		ast.NewModule(
 			"",
 			ast.NewExport(),
-			[]ast.Import{ast.NewImport("foo/bar")},
+			[]ast.Import{ast.NewImport("foo/bar", nil)},
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"y",
@@ -961,12 +1581,341 @@ func TestCompileWithFunctionsInImportedModules(t *testing.T) {
 				),
 			},
 		),
-		[]metadata.Module{metadata.NewModule(m)},
+		[]metadata.Module{newMetadataModule(m)},
+	)
+
+	assert.Nil(t, err)
+}
+
+func TestCompileWithPolymorphicBindsInImportedModules(t *testing.T) {
+	a := types.NewParameter("a", nil)
+
+	m, err := InferTypes(
+		ast.NewModule(
+			"foo/bar",
+			ast.NewExport("f"),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					types.NewFunction(a, a, nil),
+					ast.NewLambda(
+						[]string{"x"},
+						ast.NewApplication(ast.NewVariable("g"), []ast.Expression{ast.NewVariable("x")}),
+					),
+				),
+				ast.NewBind(
+					"g",
+					types.NewFunction(a, a, nil),
+					ast.NewLambda(
+						[]string{"x"},
+						ast.NewLet(
+							[]ast.Bind{ast.NewBind("y", types.NewUnknown(nil), ast.NewVariable("z"))},
+							ast.NewVariable("x"),
+						),
+					),
+				),
+				ast.NewBind("z", types.NewNumber(nil), ast.NewNumber(42)),
+			},
+		),
+		nil,
+	)
+	assert.Nil(t, err)
+
+	_, err = Compile(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("foo/bar", nil)},
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					types.NewNumber(nil),
+					ast.NewApplication(ast.NewVariable("bar.f"), []ast.Expression{ast.NewNumber(42)}),
+				),
+				ast.NewBind(
+					"y",
+					types.NewString(nil),
+					ast.NewApplication(ast.NewVariable("bar.f"), []ast.Expression{ast.NewString("foo")}),
+				),
+			},
+		),
+		[]metadata.Module{newMetadataModule(m)},
 	)
 
 	assert.Nil(t, err)
 }
 
+func TestCompileWithIntegers(t *testing.T) {
+	i := types.NewInt(nil)
+
+	_, err := Compile(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					i,
+					ast.NewBinaryOperation(
+						ast.Divide,
+						ast.NewApplication(ast.NewVariable("toInt"), []ast.Expression{ast.NewNumber(42)}),
+						ast.NewNumber(5),
+					),
+				),
+				ast.NewBind(
+					"y",
+					types.NewNumber(nil),
+					ast.NewApplication(
+						ast.NewVariable("toNumber"),
+						[]ast.Expression{
+							ast.NewApplication(
+								ast.NewVariable("mod"),
+								[]ast.Expression{ast.NewVariable("x"), ast.NewNumber(3)},
+							),
+						},
+					),
+				),
+				ast.NewBind(
+					"z",
+					types.NewBoolean(nil),
+					ast.NewCase(
+						ast.NewVariable("x"),
+						types.NewUnknown(nil),
+						[]ast.Alternative{
+							ast.NewAlternative(ast.NewNumber(8), ast.NewBoolean(true)),
+						},
+						ast.NewDefaultAlternative("x", ast.NewBinaryOperation(ast.LessThan, ast.NewVariable("x"), ast.NewNumber(0))),
+					),
+				),
+			},
+		),
+		nil,
+	)
+
+	assert.Nil(t, err)
+}
+
+func TestCompileWithForeignFunctions(t *testing.T) {
+	n := types.NewNumber(nil)
+
+	_, err := Compile(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"sin",
+					types.NewFunction(n, n, nil),
+					ast.NewForeignFunction("sin"),
+				),
+				ast.NewBind(
+					"pow",
+					types.NewFunction(n, types.NewFunction(n, n, nil), nil),
+					ast.NewForeignFunction("pow"),
+				),
+				ast.NewBind(
+					"x",
+					n,
+					ast.NewApplication(
+						ast.NewVariable("pow"),
+						[]ast.Expression{
+							ast.NewApplication(ast.NewVariable("sin"), []ast.Expression{ast.NewNumber(42)}),
+							ast.NewNumber(2),
+						},
+					),
+				),
+			},
+		),
+		nil,
+	)
+
+	assert.Nil(t, err)
+}
+
+func TestCompileErrorWithForeignFunctions(t *testing.T) {
+	for _, tt := range []types.Type{
+		types.NewNumber(nil),
+		types.NewFunction(types.NewBoolean(nil), types.NewNumber(nil), nil),
+		types.NewFunction(types.NewNumber(nil), types.NewString(nil), nil),
+	} {
+		_, err := Compile(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("f", tt, ast.NewForeignFunction("f"))},
+			),
+			nil,
+		)
+
+		assert.Error(t, err)
+	}
+}
+
+func TestCompileWithMainFunctions(t *testing.T) {
+	for _, b := range []ast.Bind{
+		ast.NewBind(
+			"main",
+			types.NewFunction(types.NewNumber(nil), types.NewList(types.NewNumber(nil), nil), nil),
+			ast.NewLambda([]string{"x"}, ast.NewList(types.NewUnknown(nil), nil)),
+		),
+		ast.NewBind(
+			"main",
+			types.NewFunction(
+				types.NewList(types.NewString(nil), nil),
+				types.NewFunction(types.NewString(nil), types.NewString(nil), nil),
+				nil,
+			),
+			ast.NewLambda([]string{"args", "s"}, ast.NewVariable("s")),
+		),
+		ast.NewBind(
+			"main",
+			types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+			ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+		),
+		ast.NewBind(
+			"main",
+			types.NewFunction(
+				types.NewList(types.NewString(nil), nil),
+				types.NewFunction(types.NewString(nil), types.NewList(types.NewString(nil), nil), nil),
+				nil,
+			),
+			ast.NewLambda([]string{"args", "s"}, ast.NewVariable("args")),
+		),
+	} {
+		m, err := Compile(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
+		assert.Nil(t, err)
+		assert.False(t, m.NamedFunction("main").IsNil())
+	}
+}
+
+func TestCompileWithPrintedMainFunctionResults(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Tree",
+		[]types.Constructor{
+			types.NewConstructor("Leaf", nil),
+			types.NewConstructor(
+				"Node",
+				[]types.Type{
+					types.NewReference("Tree", nil),
+					types.NewNumber(nil),
+					types.NewReference("Tree", nil),
+				},
+			),
+		},
+		nil,
+	)
+
+	for _, c := range []struct {
+		typ        types.Type
+		expression ast.Expression
+	}{
+		{types.NewBoolean(nil), ast.NewBoolean(true)},
+		{types.NewString(nil), ast.NewString("foo")},
+		{types.NewList(types.NewString(nil), nil), ast.NewList(types.NewUnknown(nil), nil)},
+		{
+			types.NewTuple([]types.Type{types.NewNumber(nil), types.NewBoolean(nil)}, nil),
+			ast.NewTuple(
+				types.NewUnknown(nil),
+				[]ast.Expression{ast.NewVariable("x"), ast.NewBoolean(false)},
+			),
+		},
+		{
+			types.NewRecord(map[string]types.Type{"foo": types.NewNumber(nil)}, nil),
+			ast.NewRecord(
+				types.NewUnknown(nil),
+				[]ast.RecordField{ast.NewRecordField("foo", ast.NewVariable("x"))},
+			),
+		},
+		{a, ast.NewVariable("Leaf")},
+	} {
+		_, err := Compile(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				[]ast.TypeDefinition{ast.NewTypeDefinition("Tree", a)},
+				[]ast.Bind{
+					ast.NewBind(
+						"main",
+						types.NewFunction(types.NewNumber(nil), c.typ, nil),
+						ast.NewLambda([]string{"x"}, c.expression),
+					),
+				},
+			),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestCompileWithPrintedMainFunctionResultsAndShadowedBuiltins(t *testing.T) {
+	bs := []ast.Bind{
+		ast.NewBind(
+			"main",
+			types.NewFunction(types.NewNumber(nil), types.NewList(types.NewNumber(nil), nil), nil),
+			ast.NewLambda(
+				[]string{"x"},
+				ast.NewList(
+					types.NewUnknown(nil),
+					[]ast.ListArgument{
+						ast.NewListArgument(
+							ast.NewApplication(ast.NewVariable("mod"), []ast.Expression{ast.NewVariable("x")}),
+							false,
+						),
+					},
+				),
+			),
+		),
+	}
+
+	for _, s := range []string{"toInt", "toNumber", "div", "mod"} {
+		bs = append(
+			bs,
+			ast.NewBind(
+				s,
+				types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+				ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+			),
+		)
+	}
+
+	_, err := Compile(ast.NewModule("", ast.NewExport(), nil, nil, bs), nil)
+	assert.Nil(t, err)
+}
+
+func TestCompileErrorWithInvalidMainFunctionTypes(t *testing.T) {
+	for _, b := range []ast.Bind{
+		ast.NewBind("main", types.NewNumber(nil), ast.NewNumber(42)),
+		ast.NewBind(
+			"main",
+			types.NewFunction(types.NewString(nil), types.NewString(nil), nil),
+			ast.NewLambda([]string{"s"}, ast.NewVariable("s")),
+		),
+		ast.NewBind(
+			"main",
+			types.NewFunction(
+				types.NewNumber(nil),
+				types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+				nil,
+			),
+			ast.NewLambda([]string{"x", "y"}, ast.NewVariable("x")),
+		),
+	} {
+		_, err := Compile(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
+		assert.Error(t, err)
+	}
+}
+
 func desugarModule(m ast.Module) (ast.Module, error) {
 	m, err := tinfer.InferTypes(desugar.WithoutTypes(m), nil)
 
@@ -984,3 +1933,67 @@ func numberConstructorApplication(a coreast.Atom) coreast.Expression {
 func listConstructorApplication(a, aa coreast.Atom) coreast.Expression {
 	return coreast.NewConstructorApplication(consConstructor, []coreast.Atom{a, aa})
 }
+
+func TestCompileWithStringCaseExpressions(t *testing.T) {
+	for _, c := range []ast.Case{
+		ast.NewCaseWithoutDefault(
+			ast.NewString("foo"),
+			types.NewUnknown(nil),
+			[]ast.Alternative{
+				ast.NewAlternative(ast.NewString("foo"), ast.NewNumber(1)),
+				ast.NewAlternative(ast.NewString("bar"), ast.NewNumber(2)),
+				ast.NewAlternative(ast.NewString("baz"), ast.NewNumber(3)),
+				ast.NewAlternative(ast.NewString(""), ast.NewNumber(4)),
+			},
+		),
+		ast.NewCase(
+			ast.NewString("foo"),
+			types.NewUnknown(nil),
+			[]ast.Alternative{
+				ast.NewAlternative(ast.NewString("foo"), ast.NewNumber(1)),
+				ast.NewAlternative(ast.NewString("fo"), ast.NewNumber(2)),
+			},
+			ast.NewDefaultAlternative("y", ast.NewNumber(3)),
+		),
+		ast.NewCase(
+			ast.NewString("foo"),
+			types.NewUnknown(nil),
+			nil,
+			ast.NewDefaultAlternative("y", ast.NewNumber(42)),
+		),
+		ast.NewCase(
+			ast.NewList(
+				types.NewUnknown(nil),
+				[]ast.ListArgument{ast.NewListArgument(ast.NewString("foo"), false)},
+			),
+			types.NewUnknown(nil),
+			[]ast.Alternative{
+				ast.NewAlternative(
+					ast.NewList(
+						types.NewUnknown(nil),
+						[]ast.ListArgument{ast.NewListArgument(ast.NewString("foo"), false)},
+					),
+					ast.NewNumber(42),
+				),
+			},
+			ast.NewDefaultAlternative("y", ast.NewNumber(42)),
+		),
+	} {
+		_, err := Compile(
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{ast.NewBind("x", types.NewNumber(nil), c)}),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func newMetadataModule(m ast.Module) metadata.Module {
+	mm, err := metadata.NewModule(m, nil)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return mm
+}
@@ -2,6 +2,7 @@ package compile
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/raviqqe/lazy-ein/command/ast"
 	"github.com/raviqqe/lazy-ein/command/compile/metadata"
@@ -19,17 +20,18 @@ func newCompiler() compiler {
 	return compiler{}
 }
 
-func (c *compiler) initialize(m ast.Module, ms []metadata.Module) {
+func (c *compiler) initialize(m ast.Module, is map[string]metadata.ImportedBind) {
 	c.variables = make(map[string]coretypes.Type, len(m.Binds()))
 	gs := make(map[string]struct{}, len(m.Binds()))
 
-	for _, m := range ms {
-		for n, t := range m.ExportedBinds() {
-			s := m.Name().Qualify(n)
+	for n, t := range ast.BuiltinTypes() {
+		c.variables[n] = t.ToCore()
+		gs[n] = struct{}{}
+	}
 
-			c.variables[s] = t.ToCore()
-			gs[s] = struct{}{}
-		}
+	for s, b := range is {
+		c.variables[s] = b.Type().ToCore()
+		gs[s] = struct{}{}
 	}
 
 	for _, b := range m.Binds() {
@@ -41,17 +43,43 @@ func (c *compiler) initialize(m ast.Module, ms []metadata.Module) {
 }
 
 func (c compiler) Compile(m ast.Module, ms []metadata.Module) (coreast.Module, error) {
-	c.initialize(m, ms)
+	is, err := metadata.ResolveImports(m, ms)
+
+	if err != nil {
+		return coreast.Module{}, err
+	}
+
+	// Imported polymorphic binds are specialized in modules on type inference.
+	for s, b := range is {
+		if _, ok := b.Expression(); ok {
+			delete(is, s)
+		}
+	}
+
+	c.initialize(m, is)
 
-	ds := []coreast.Declaration(nil)
+	var ds []coreast.Declaration
 
-	for _, m := range ms {
-		ds = append(ds, m.Declarations()...)
+	for _, s := range sortedImportedNames(is) {
+		ds = append(ds, coreast.NewDeclaration(s, is[s].Declaration()))
 	}
 
+	var fs []coreast.ForeignDeclaration
 	bs := make([]coreast.Bind, 0, len(m.Binds()))
 
 	for _, b := range m.Binds() {
+		if f, ok := b.Expression().(ast.ForeignFunction); ok {
+			d, b, err := c.compileForeignFunction(b, f)
+
+			if err != nil {
+				return coreast.Module{}, err
+			}
+
+			fs = append(fs, d)
+			bs = append(bs, b)
+			continue
+		}
+
 		b, err := c.compileBind(b)
 
 		if err != nil {
@@ -61,12 +89,15 @@ func (c compiler) Compile(m ast.Module, ms []metadata.Module) (coreast.Module, e
 		bs = append(bs, coreast.NewBind(b.Name(), b.Lambda().ClearFreeVariables()))
 	}
 
-	return coreast.NewModule(ds, bs), nil
+	for _, s := range c.findBuiltins(m) {
+		bs = append(bs, c.compileBuiltin(s))
+	}
+
+	return coreast.NewModuleWithForeignDeclarations(fs, ds, bs), nil
 }
 
 func (c compiler) compileBind(b ast.Bind) (coreast.Bind, error) {
 	t := b.Type().ToCore()
-	c = c.addVariable(b.Name(), t)
 	l, ok := b.Expression().(ast.Lambda)
 
 	if !ok {
@@ -125,11 +156,23 @@ func (c compiler) compileExpression(e ast.Expression) (coreast.Expression, error
 		return c.compileBinaryOperation(e)
 	case ast.Case:
 		return c.compileCase(e)
+	case ast.ConstructorApplication:
+		return c.compileConstructorApplication(e)
+	case ast.FieldAccess:
+		return c.compileFieldAccess(e, nil), nil
+	case ast.If:
+		return c.compileIf(e)
 	case ast.Let:
 		return c.compileLet(e)
 	case ast.List:
 		return c.compileList(e)
-	case ast.Number:
+	case ast.Record:
+		return c.compileRecord(e)
+	case ast.RecordUpdate:
+		return c.compileRecordUpdate(e)
+	case ast.Tuple:
+		return c.compileTuple(e)
+	case ast.Boolean, ast.Number, ast.String:
 		break
 	case ast.Unboxed:
 		return c.compileUnboxed(e)
@@ -147,6 +190,10 @@ func (c compiler) compileApplication(a ast.Application) (coreast.Expression, err
 		as = append(as, coreast.NewVariable(a.(ast.Variable).Name()))
 	}
 
+	if f, ok := a.Function().(ast.FieldAccess); ok {
+		return c.compileFieldAccess(f, as), nil
+	}
+
 	return coreast.NewFunctionApplication(
 		coreast.NewVariable(a.Function().(ast.Variable).Name()),
 		as,
@@ -175,6 +222,13 @@ func (c compiler) compileBinaryOperation(o ast.BinaryOperation) (coreast.Express
 		return nil, err
 	}
 
+	n := c.numberType(x)
+	t := types.Type(n)
+
+	if o.Operator().IsComparison() {
+		t = types.NewBoolean(nil)
+	}
+
 	return coreast.NewLet(
 		[]coreast.Bind{
 			coreast.NewBind(
@@ -182,32 +236,27 @@ func (c compiler) compileBinaryOperation(o ast.BinaryOperation) (coreast.Express
 				coreast.NewVariableLambda(
 					vs,
 					c.bindNumberPrimitive(
+						n,
 						coreast.NewFunctionApplication(coreast.NewVariable(x), nil),
 						"$lhs",
 						c.bindNumberPrimitive(
+							n,
 							coreast.NewFunctionApplication(coreast.NewVariable(y), nil),
 							"$rhs",
-							coreast.NewPrimitiveCase(
+							c.boxBinaryOperationResult(
+								o.Operator(),
+								n,
 								coreast.NewPrimitiveOperation(
-									binaryOperatorToPrimitive(o.Operator()),
+									binaryOperatorToPrimitive(o.Operator(), n),
 									[]coreast.Atom{
 										coreast.NewVariable("$lhs"),
 										coreast.NewVariable("$rhs"),
 									},
 								),
-								coretypes.NewFloat64(),
-								nil,
-								coreast.NewDefaultAlternative(
-									"$result",
-									coreast.NewConstructorApplication(
-										types.NewNumber(nil).CoreConstructor(),
-										[]coreast.Atom{coreast.NewVariable("$result")},
-									),
-								),
 							),
 						),
 					),
-					coretypes.Unbox(types.NewNumber(nil).ToCore()).(coretypes.Algebraic),
+					coretypes.Unbox(t.ToCore()).(coretypes.Algebraic),
 				),
 			),
 		},
@@ -215,18 +264,215 @@ func (c compiler) compileBinaryOperation(o ast.BinaryOperation) (coreast.Express
 	), nil
 }
 
+func (c compiler) boxBinaryOperationResult(
+	o ast.BinaryOperator,
+	t numberType,
+	p coreast.PrimitiveOperation,
+) coreast.Expression {
+	if o.IsComparison() {
+		return coreast.NewPrimitiveCase(
+			p,
+			primitiveType(t),
+			[]coreast.PrimitiveAlternative{
+				coreast.NewPrimitiveAlternative(
+					c.compileUnboxedLiteral(ast.NewNumberWithType(0, t)),
+					coreast.NewConstructorApplication(types.NewBoolean(nil).CoreConstructor(false), nil),
+				),
+			},
+			coreast.NewDefaultAlternative(
+				"",
+				coreast.NewConstructorApplication(types.NewBoolean(nil).CoreConstructor(true), nil),
+			),
+		)
+	}
+
+	return coreast.NewPrimitiveCase(
+		p,
+		primitiveType(t),
+		nil,
+		coreast.NewDefaultAlternative(
+			"$result",
+			coreast.NewConstructorApplication(
+				t.CoreConstructor(),
+				[]coreast.Atom{coreast.NewVariable("$result")},
+			),
+		),
+	)
+}
+
 func (c compiler) compileCase(cc ast.Case) (coreast.Expression, error) {
 	switch cc.Type().(type) {
-	case types.Number:
+	case types.Algebraic, types.Boolean, types.Tuple:
+		return newAlgebraicCaseCompiler(c).Compile(cc)
+	case types.Number, types.Int:
 		return c.compilePrimitiveCase(cc)
 	case types.List:
 		return newListCaseCompiler(c).Compile(cc)
+	case types.String:
+		return newStringCaseCompiler(c).Compile(cc)
 	}
 
 	panic("unreachable")
 }
 
+func (c compiler) compileConstructorApplication(
+	a ast.ConstructorApplication,
+) (coreast.Expression, error) {
+	as := make([]coreast.Atom, 0, len(a.Arguments()))
+
+	for _, a := range a.Arguments() {
+		as = append(as, coreast.NewVariable(a.(ast.Variable).Name()))
+	}
+
+	return c.compileThunk(
+		"$constructor",
+		a,
+		coreast.NewConstructorApplication(a.Type().CoreConstructor(a.Index()), as),
+		coretypes.Unbox(a.Type().ToCore()).(coretypes.Bindable),
+	)
+}
+
+func (c compiler) compileRecord(r ast.Record) (coreast.Expression, error) {
+	t := r.Type().(types.Record)
+	es := make(map[string]ast.Expression, len(r.Fields()))
+
+	for _, f := range r.Fields() {
+		es[f.Name()] = f.Expression()
+	}
+
+	as := make([]coreast.Atom, 0, len(r.Fields()))
+
+	for _, s := range t.FieldNames() {
+		as = append(as, coreast.NewVariable(es[s].(ast.Variable).Name()))
+	}
+
+	return c.compileThunk(
+		"$record",
+		r,
+		coreast.NewConstructorApplication(t.CoreConstructor(), as),
+		coretypes.Unbox(t.ToCore()).(coretypes.Bindable),
+	)
+}
+
+func (c compiler) compileTuple(t ast.Tuple) (coreast.Expression, error) {
+	as := make([]coreast.Atom, 0, len(t.Elements()))
+
+	for _, e := range t.Elements() {
+		as = append(as, coreast.NewVariable(e.(ast.Variable).Name()))
+	}
+
+	return c.compileThunk(
+		"$tuple",
+		t,
+		coreast.NewConstructorApplication(t.Type().(types.Tuple).CoreConstructor(), as),
+		coretypes.Unbox(t.Type().ToCore()).(coretypes.Bindable),
+	)
+}
+
+func (c compiler) compileRecordUpdate(u ast.RecordUpdate) (coreast.Expression, error) {
+	t := u.Type().(types.Record)
+	es := make(map[string]ast.Expression, len(u.Fields()))
+
+	for _, f := range u.Fields() {
+		es[f.Name()] = f.Expression()
+	}
+
+	ss := make([]string, 0, len(t.Fields()))
+	as := make([]coreast.Atom, 0, len(t.Fields()))
+
+	for _, s := range t.FieldNames() {
+		ss = append(ss, "$record-update."+s)
+
+		if e, ok := es[s]; ok {
+			as = append(as, coreast.NewVariable(e.(ast.Variable).Name()))
+		} else {
+			as = append(as, coreast.NewVariable(ss[len(ss)-1]))
+		}
+	}
+
+	return c.compileThunk(
+		"$record",
+		u,
+		coreast.NewAlgebraicCaseWithoutDefault(
+			coreast.NewFunctionApplication(coreast.NewVariable(u.Record().(ast.Variable).Name()), nil),
+			[]coreast.AlgebraicAlternative{
+				coreast.NewAlgebraicAlternative(
+					t.CoreConstructor(),
+					ss,
+					coreast.NewConstructorApplication(t.CoreConstructor(), as),
+				),
+			},
+		),
+		coretypes.Unbox(t.ToCore()).(coretypes.Bindable),
+	)
+}
+
+// compileFieldAccess compiles a field access applied to given arguments.
+func (c compiler) compileFieldAccess(a ast.FieldAccess, as []coreast.Atom) coreast.Expression {
+	t := a.Type().(types.Record)
+	i, _ := t.FieldIndex(a.Field())
+	ss := make([]string, 0, len(t.Fields()))
+
+	for _, s := range t.FieldNames() {
+		ss = append(ss, "$field-access."+s)
+	}
+
+	return coreast.NewAlgebraicCaseWithoutDefault(
+		coreast.NewFunctionApplication(coreast.NewVariable(a.Record().(ast.Variable).Name()), nil),
+		[]coreast.AlgebraicAlternative{
+			coreast.NewAlgebraicAlternative(
+				t.CoreConstructor(),
+				ss,
+				coreast.NewFunctionApplication(coreast.NewVariable(ss[i]), as),
+			),
+		},
+	)
+}
+
+// compileThunk compiles an expression into a thunk of a given core expression
+// which captures free variables of the original expression.
+func (c compiler) compileThunk(
+	s string,
+	e ast.Expression,
+	ce coreast.Expression,
+	t coretypes.Bindable,
+) (coreast.Expression, error) {
+	vs, err := c.compileFreeVariables(e)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return coreast.NewLet(
+		[]coreast.Bind{coreast.NewBind(s, coreast.NewVariableLambda(vs, ce, t))},
+		coreast.NewFunctionApplication(coreast.NewVariable(s), nil),
+	), nil
+}
+
+func (c compiler) compileIf(i ast.If) (coreast.Expression, error) {
+	es := make([]coreast.Expression, 0, 3)
+
+	for _, e := range []ast.Expression{i.Condition(), i.Then(), i.Else()} {
+		e, err := c.compileExpression(e)
+
+		if err != nil {
+			return nil, err
+		}
+
+		es = append(es, e)
+	}
+
+	return coreast.NewAlgebraicCaseWithoutDefault(
+		es[0],
+		[]coreast.AlgebraicAlternative{
+			coreast.NewAlgebraicAlternative(types.NewBoolean(nil).CoreConstructor(true), nil, es[1]),
+			coreast.NewAlgebraicAlternative(types.NewBoolean(nil).CoreConstructor(false), nil, es[2]),
+		},
+	), nil
+}
+
 func (c compiler) compilePrimitiveCase(cc ast.Case) (coreast.Expression, error) {
+	t := cc.Type().(numberType)
 	arg, err := c.compileExpression(cc.Argument())
 
 	if err != nil {
@@ -252,8 +498,8 @@ func (c compiler) compilePrimitiveCase(cc ast.Case) (coreast.Expression, error)
 
 	if !ok {
 		return coreast.NewPrimitiveCaseWithoutDefault(
-			c.extractNumberPrimitive(arg),
-			coretypes.NewFloat64(),
+			c.extractNumberPrimitive(t, arg),
+			primitiveType(t),
 			as,
 		), nil
 	}
@@ -274,9 +520,10 @@ func (c compiler) compilePrimitiveCase(cc ast.Case) (coreast.Expression, error)
 	if v, ok := cc.Argument().(ast.Variable); ok && d.Variable() == v.Name() {
 		return coreast.NewPrimitiveCase(
 			c.extractNumberPrimitive(
+				t,
 				coreast.NewFunctionApplication(coreast.NewVariable(d.Variable()), nil),
 			),
-			coretypes.NewFloat64(),
+			primitiveType(t),
 			as,
 			coreast.NewDefaultAlternative("", de),
 		), nil
@@ -291,9 +538,10 @@ func (c compiler) compilePrimitiveCase(cc ast.Case) (coreast.Expression, error)
 		},
 		coreast.NewPrimitiveCase(
 			c.extractNumberPrimitive(
+				t,
 				coreast.NewFunctionApplication(coreast.NewVariable(d.Variable()), nil),
 			),
-			coretypes.NewFloat64(),
+			primitiveType(t),
 			as,
 			coreast.NewDefaultAlternative("", de),
 		),
@@ -328,34 +576,44 @@ func (c compiler) compileLet(l ast.Let) (coreast.Expression, error) {
 
 func (c compiler) compileList(l ast.List) (coreast.Expression, error) {
 	t := coretypes.Unbox(l.Type().ToCore()).(coretypes.Algebraic)
+	as := l.Arguments()
+	bs := make([]coreast.Bind, 0, len(as)+1)
 	s := "$nil"
 
-	bs := make([]coreast.Bind, 0, len(l.Arguments())+1)
-	bs = append(
-		bs,
-		coreast.NewBind(
-			s,
-			coreast.NewVariableLambda(
-				nil,
-				coreast.NewConstructorApplication(coreast.NewConstructor(t, 1), nil),
-				t,
+	if len(as) != 0 && as[len(as)-1].Expanded() {
+		s = as[len(as)-1].Expression().(ast.Variable).Name()
+		as = as[:len(as)-1]
+	} else {
+		bs = append(
+			bs,
+			coreast.NewBind(
+				s,
+				coreast.NewVariableLambda(
+					nil,
+					coreast.NewConstructorApplication(coreast.NewConstructor(t, 1), nil),
+					t,
+				),
 			),
-		),
-	)
+		)
+	}
 
-	for i := range l.Arguments() {
-		a := l.Arguments()[len(l.Arguments())-1-i]
+	for i := range as {
+		a := as[len(as)-1-i]
 
 		if a.Expanded() {
-			panic("not implemented")
+			panic("unreachable")
 		}
 
 		e := a.Expression().(ast.Variable)
 		ss := fmt.Sprintf("$list-%v", i)
-		vs := []coreast.Argument{coreast.NewArgument(s, coretypes.NewBoxed(t))}
+		vs := []coreast.Argument{}
+
+		if len(c.freeVariableFinder.Find(ast.NewVariable(s))) > 0 {
+			vs = append(vs, coreast.NewArgument(s, coretypes.NewBoxed(t)))
+		}
 
 		if len(c.freeVariableFinder.Find(e)) > 0 {
-			vs = append(vs, coreast.NewArgument(e.Name(), t.Constructors()[0].Elements()[i]))
+			vs = append(vs, coreast.NewArgument(e.Name(), t.Constructors()[0].Elements()[0]))
 		}
 
 		bs = append(
@@ -379,33 +637,101 @@ func (c compiler) compileList(l ast.List) (coreast.Expression, error) {
 		s = ss
 	}
 
+	if len(bs) == 0 {
+		return coreast.NewFunctionApplication(coreast.NewVariable(s), nil), nil
+	}
+
 	return coreast.NewLet(bs, coreast.NewFunctionApplication(coreast.NewVariable(s), nil)), nil
 }
 
 func (c compiler) compileUnboxed(u ast.Unboxed) (coreast.Expression, error) {
 	// TODO: Handle other literals.
 	switch l := u.Content().(type) {
+	case ast.Boolean:
+		return coreast.NewConstructorApplication(
+			types.NewBoolean(nil).CoreConstructor(l.Value()),
+			nil,
+		), nil
 	case ast.Number:
 		return coreast.NewConstructorApplication(
-			types.NewNumber(nil).CoreConstructor(),
-			[]coreast.Atom{coreast.NewFloat64(l.Value())},
+			l.Type().(numberType).CoreConstructor(),
+			[]coreast.Atom{c.compileUnboxedLiteral(l)},
 		), nil
+	case ast.String:
+		return c.compileString(l), nil
 	}
 
 	panic("unreachable")
 }
 
+func (compiler) compileString(s ast.String) coreast.Expression {
+	t := coretypes.Unbox(types.NewString(nil).ToCore()).(coretypes.Algebraic)
+	rs := []rune(s.Value())
+
+	if len(rs) == 0 {
+		return coreast.NewConstructorApplication(coreast.NewConstructor(t, 1), nil)
+	}
+
+	ss := "$nil"
+	bs := make([]coreast.Bind, 0, len(rs))
+	bs = append(
+		bs,
+		coreast.NewBind(
+			ss,
+			coreast.NewVariableLambda(
+				nil,
+				coreast.NewConstructorApplication(coreast.NewConstructor(t, 1), nil),
+				t,
+			),
+		),
+	)
+
+	for i := len(rs) - 1; i > 0; i-- {
+		s := fmt.Sprintf("$string-%v", i)
+
+		bs = append(
+			bs,
+			coreast.NewBind(
+				s,
+				coreast.NewVariableLambda(
+					[]coreast.Argument{coreast.NewArgument(ss, coretypes.NewBoxed(t))},
+					coreast.NewConstructorApplication(
+						coreast.NewConstructor(t, 0),
+						[]coreast.Atom{coreast.NewFloat64(float64(rs[i])), coreast.NewVariable(ss)},
+					),
+					t,
+				),
+			),
+		)
+
+		ss = s
+	}
+
+	return coreast.NewLet(
+		bs,
+		coreast.NewConstructorApplication(
+			coreast.NewConstructor(t, 0),
+			[]coreast.Atom{coreast.NewFloat64(float64(rs[0])), coreast.NewVariable(ss)},
+		),
+	)
+}
+
 func (compiler) compileUnboxedLiteral(l ast.Literal) coreast.Literal {
 	switch l := l.(type) {
 	case ast.Number:
+		if _, ok := l.Type().(types.Int); ok {
+			return coreast.NewInt64(int64(l.Value()))
+		}
+
 		return coreast.NewFloat64(l.Value())
 	}
 
 	panic("unreachable")
 }
 
-func (c compiler) extractNumberPrimitive(e coreast.Expression) coreast.Expression {
+func (c compiler) extractNumberPrimitive(t numberType, e coreast.Expression) coreast.Expression {
 	return c.bindNumberPrimitive(
+		t,
 		e,
 		"$primitive",
 		coreast.NewFunctionApplication(coreast.NewVariable("$primitive"), nil),
@@ -413,6 +739,7 @@ func (c compiler) extractNumberPrimitive(e coreast.Expression) coreast.Expressio
 }
 
 func (compiler) bindNumberPrimitive(
+	t numberType,
 	e coreast.Expression,
 	s string,
 	ee coreast.Expression,
@@ -421,7 +748,7 @@ func (compiler) bindNumberPrimitive(
 		e,
 		[]coreast.AlgebraicAlternative{
 			coreast.NewAlgebraicAlternative(
-				types.NewNumber(nil).CoreConstructor(),
+				t.CoreConstructor(),
 				[]string{s},
 				ee,
 			),
@@ -429,6 +756,15 @@ func (compiler) bindNumberPrimitive(
 	)
 }
 
+// numberType returns a type of a variable of numbers or integers.
+func (c compiler) numberType(s string) numberType {
+	if coretypes.Equal(c.variables[s], types.NewInt(nil).ToCore()) {
+		return types.NewInt(nil)
+	}
+
+	return types.NewNumber(nil)
+}
+
 func (c compiler) compileFreeVariables(e ast.Expression) ([]coreast.Argument, error) {
 	ss := c.freeVariableFinder.Find(e)
 
@@ -460,5 +796,18 @@ func (c compiler) addVariable(s string, t coretypes.Type) compiler {
 
 	m[s] = t
 
-	return compiler{m, c.freeVariableFinder}
+	// Local variables can shadow global ones.
+	return compiler{m, c.freeVariableFinder.RemoveVariable(s)}
+}
+
+func sortedImportedNames(is map[string]metadata.ImportedBind) []string {
+	ss := make([]string, 0, len(is))
+
+	for s := range is {
+		ss = append(ss, s)
+	}
+
+	sort.Strings(ss)
+
+	return ss
 }
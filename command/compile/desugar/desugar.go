@@ -5,10 +5,15 @@ import "github.com/raviqqe/lazy-ein/command/ast"
 // WithoutTypes desugars an AST without type information.
 func WithoutTypes(m ast.Module) ast.Module {
 	for _, f := range []func(ast.Module) ast.Module{
-		desugarLiterals,
+		desugarFieldAccesses,
+		desugarPatternBinds,
+		desugarLambdas,
+		desugarConstructors,
 		desugarApplications,
 		desugarBinaryOperations,
 		desugarLists,
+		desugarRecords,
+		desugarTuples,
 		desugarListCases,
 	} {
 		m = f(m)
@@ -19,5 +24,23 @@ func WithoutTypes(m ast.Module) ast.Module {
 
 // WithTypes desugars an AST with type information.
 func WithTypes(m ast.Module) ast.Module {
-	return desugarPartialApplications(m)
+	for _, f := range []func(ast.Module) ast.Module{
+		desugarLiterals,
+		desugarPartialApplications,
+	} {
+		m = f(m)
+	}
+
+	return m
+}
+
+// isAtomic checks if an expression is a variable or a literal. Literals are
+// converted into global variables after type inference.
+func isAtomic(e ast.Expression) bool {
+	switch e.(type) {
+	case ast.Literal, ast.Variable:
+		return true
+	}
+
+	return false
 }
@@ -27,14 +27,13 @@ func desugarApplications(m ast.Module) ast.Module {
 		as := make([]ast.Expression, 0, len(app.Arguments()))
 
 		for _, a := range app.Arguments() {
-			v, ok := a.(ast.Variable)
-
-			if !ok {
-				v = ast.NewVariable(g.Generate("argument"))
+			if !isAtomic(a) {
+				v := ast.NewVariable(g.Generate("argument"))
 				bs = append(bs, ast.NewBind(v.Name(), types.NewUnknown(nil), a))
+				a = v
 			}
 
-			as = append(as, v)
+			as = append(as, a)
 		}
 
 		if len(bs) == 0 {
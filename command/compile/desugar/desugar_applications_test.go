@@ -12,8 +12,8 @@ func TestDesugarApplications(t *testing.T) {
 	for _, ms := range [][2]ast.Module{
 		// Empty modules
 		{
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
 		},
 		// Arguments
 		{
@@ -21,6 +21,7 @@ func TestDesugarApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -46,6 +47,7 @@ func TestDesugarApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -85,6 +87,7 @@ func TestDesugarApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -109,6 +112,7 @@ func TestDesugarApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -20,14 +20,13 @@ func desugarBinaryOperations(m ast.Module) ast.Module {
 		es := make([]ast.Expression, 0, 2)
 
 		for _, e := range []ast.Expression{o.LHS(), o.RHS()} {
-			v, ok := e.(ast.Variable)
-
-			if !ok {
-				v = ast.NewVariable(g.Generate("argument"))
+			if !isAtomic(e) {
+				v := ast.NewVariable(g.Generate("argument"))
 				bs = append(bs, ast.NewBind(v.Name(), types.NewUnknown(nil), e))
+				e = v
 			}
 
-			es = append(es, v)
+			es = append(es, e)
 		}
 
 		if len(bs) == 0 {
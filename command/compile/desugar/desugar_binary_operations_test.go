@@ -12,8 +12,8 @@ func TestDesugarBinaryOperations(t *testing.T) {
 	for _, ms := range [][2]ast.Module{
 		// Empty modules
 		{
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
 		},
 		// Arguments
 		{
@@ -21,6 +21,7 @@ func TestDesugarBinaryOperations(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"a",
@@ -45,6 +46,7 @@ func TestDesugarBinaryOperations(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"a",
@@ -0,0 +1,54 @@
+package desugar
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/compile/desugar/names"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// desugarConstructors defines constructors of algebraic types as functions.
+func desugarConstructors(m ast.Module) ast.Module {
+	g := names.NewNameGenerator("constructor")
+	bs := []ast.Bind{}
+
+	for _, d := range m.TypeDefinitions() {
+		a, ok := d.Type().(types.Algebraic)
+
+		if !ok {
+			continue
+		}
+
+		for i, c := range a.Constructors() {
+			ts := a.ConstructorElements(i)
+			ss := make([]string, 0, len(ts))
+			es := make([]ast.Expression, 0, len(ts))
+
+			for range ts {
+				s := g.Generate("argument")
+				ss = append(ss, s)
+				es = append(es, ast.NewVariable(s))
+			}
+
+			t := types.Type(a)
+			e := ast.Expression(ast.NewConstructorApplication(a, i, es))
+
+			for i := range ts {
+				t = types.NewFunction(ts[len(ts)-1-i], t, nil)
+			}
+
+			if len(ss) != 0 {
+				e = ast.NewLambda(ss, e)
+			}
+
+			bs = append(bs, ast.NewBind(c.Name(), t, e))
+		}
+	}
+
+	return ast.NewModule(
+		m.Name(),
+		m.Export(),
+		m.Imports(),
+		m.TypeDefinitions(),
+		append(bs, m.Binds()...),
+	)
+}
@@ -0,0 +1,65 @@
+package desugar
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesugarConstructors(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Foo",
+		[]types.Constructor{
+			types.NewConstructor("Bar", nil),
+			types.NewConstructor("Baz", []types.Type{types.NewNumber(nil), types.NewReference("Foo", nil)}),
+		},
+		nil,
+	)
+
+	for _, ms := range [][2]ast.Module{
+		// Empty modules
+		{
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
+		},
+		// Algebraic types
+		{
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				[]ast.TypeDefinition{ast.NewTypeDefinition("Foo", a)},
+				[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+			),
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				[]ast.TypeDefinition{ast.NewTypeDefinition("Foo", a)},
+				[]ast.Bind{
+					ast.NewBind("Bar", a, ast.NewConstructorApplication(a, 0, []ast.Expression{})),
+					ast.NewBind(
+						"Baz",
+						types.NewFunction(types.NewNumber(nil), types.NewFunction(a, a, nil), nil),
+						ast.NewLambda(
+							[]string{"$constructor.argument-0", "$constructor.argument-1"},
+							ast.NewConstructorApplication(
+								a,
+								1,
+								[]ast.Expression{
+									ast.NewVariable("$constructor.argument-0"),
+									ast.NewVariable("$constructor.argument-1"),
+								},
+							),
+						),
+					),
+					ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42)),
+				},
+			),
+		},
+	} {
+		assert.Equal(t, ms[1], desugarConstructors(ms[0]))
+	}
+}
@@ -0,0 +1,30 @@
+package desugar
+
+import (
+	"strings"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// desugarFieldAccesses converts variables qualified with names of local
+// variables rather than imported modules into record field accesses.
+func desugarFieldAccesses(m ast.Module) ast.Module {
+	return m.ConvertExpressions(func(e ast.Expression) ast.Expression {
+		v, ok := e.(ast.Variable)
+
+		if !ok || !strings.Contains(v.Name(), ".") {
+			return e
+		}
+
+		for _, i := range m.Imports() {
+			if i.IsQualified() && strings.HasPrefix(v.Name(), i.Qualifier()+".") {
+				return e
+			}
+		}
+
+		ss := strings.SplitN(v.Name(), ".", 2)
+
+		return ast.NewFieldAccess(types.NewUnknown(nil), ast.NewVariable(ss[0]), ss[1])
+	})
+}
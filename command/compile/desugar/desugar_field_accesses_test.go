@@ -0,0 +1,40 @@
+package desugar
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesugarFieldAccesses(t *testing.T) {
+	for _, es := range [][2]ast.Expression{
+		{ast.NewVariable("x"), ast.NewVariable("x")},
+		{ast.NewVariable("foo.x"), ast.NewVariable("foo.x")},
+		{
+			ast.NewVariable("x.y"),
+			ast.NewFieldAccess(types.NewUnknown(nil), ast.NewVariable("x"), "y"),
+		},
+	} {
+		assert.Equal(
+			t,
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				[]ast.Import{ast.NewImport("bar/foo", nil)},
+				nil,
+				[]ast.Bind{ast.NewBind("z", types.NewNumber(nil), es[1])},
+			),
+			desugarFieldAccesses(
+				ast.NewModule(
+					"",
+					ast.NewExport(),
+					[]ast.Import{ast.NewImport("bar/foo", nil)},
+					nil,
+					[]ast.Bind{ast.NewBind("z", types.NewNumber(nil), es[0])},
+				),
+			),
+		)
+	}
+}
@@ -0,0 +1,65 @@
+package desugar
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/compile/desugar/names"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// desugarLambdas binds anonymous lambdas to let-bound variables so that only
+// binds hold lambdas.
+func desugarLambdas(m ast.Module) ast.Module {
+	g := names.NewNameGenerator("lambda")
+	ss := map[string]struct{}{}
+
+	f := func(e ast.Expression) ast.Expression {
+		switch e := e.(type) {
+		case ast.Lambda:
+			s := g.Generate("lambda")
+			ss[s] = struct{}{}
+
+			return ast.NewLet(
+				[]ast.Bind{ast.NewBind(s, types.NewUnknown(nil), e)},
+				ast.NewVariable(s),
+			)
+		case ast.Let:
+			bs := make([]ast.Bind, 0, len(e.Binds()))
+
+			for _, b := range e.Binds() {
+				bs = append(bs, unbindLambda(b, ss))
+			}
+
+			return ast.NewLet(bs, e.Expression())
+		}
+
+		return e
+	}
+
+	bs := make([]ast.Bind, 0, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		bs = append(bs, unbindLambda(b.ConvertExpressions(f), ss))
+	}
+
+	return ast.NewModule(m.Name(), m.Export(), m.Imports(), m.TypeDefinitions(), bs)
+}
+
+// unbindLambda restores a lambda of a bind which has been bound to a variable
+// by desugarLambdas.
+func unbindLambda(b ast.Bind, ss map[string]struct{}) ast.Bind {
+	l, ok := b.Expression().(ast.Let)
+
+	if !ok || len(l.Binds()) != 1 {
+		return b
+	}
+
+	v, ok := l.Expression().(ast.Variable)
+
+	if !ok || v.Name() != l.Binds()[0].Name() {
+		return b
+	} else if _, ok := ss[v.Name()]; !ok {
+		return b
+	}
+
+	return ast.NewBindWithComment(b.Name(), b.Type(), l.Binds()[0].Expression(), b.Comment())
+}
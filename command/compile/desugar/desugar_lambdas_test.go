@@ -0,0 +1,119 @@
+package desugar
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesugarLambdas(t *testing.T) {
+	for _, es := range [][2]ast.Expression{
+		// Lambdas of binds
+		{
+			ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+			ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+		},
+		// Lambdas in expressions
+		{
+			ast.NewApplication(
+				ast.NewVariable("f"),
+				[]ast.Expression{ast.NewLambda([]string{"x"}, ast.NewVariable("x"))},
+			),
+			ast.NewApplication(
+				ast.NewVariable("f"),
+				[]ast.Expression{
+					ast.NewLet(
+						[]ast.Bind{
+							ast.NewBind(
+								"$lambda.lambda-0",
+								types.NewUnknown(nil),
+								ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+							),
+						},
+						ast.NewVariable("$lambda.lambda-0"),
+					),
+				},
+			),
+		},
+		// Lambdas returning lambdas
+		{
+			ast.NewLambda(
+				[]string{"x"},
+				ast.NewLambda([]string{"y"}, ast.NewVariable("x")),
+			),
+			ast.NewLambda(
+				[]string{"x"},
+				ast.NewLet(
+					[]ast.Bind{
+						ast.NewBind(
+							"$lambda.lambda-0",
+							types.NewUnknown(nil),
+							ast.NewLambda([]string{"y"}, ast.NewVariable("x")),
+						),
+					},
+					ast.NewVariable("$lambda.lambda-0"),
+				),
+			),
+		},
+		// Lambdas of let binds
+		{
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewUnknown(nil),
+						ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+					),
+				},
+				ast.NewVariable("f"),
+			),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewUnknown(nil),
+						ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+					),
+				},
+				ast.NewVariable("f"),
+			),
+		},
+	} {
+		assert.Equal(
+			t,
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), es[1])},
+			),
+			desugarLambdas(
+				ast.NewModule(
+					"",
+					ast.NewExport(),
+					nil,
+					nil,
+					[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), es[0])},
+				),
+			),
+		)
+	}
+}
+
+func TestDesugarLambdasWithComments(t *testing.T) {
+	b := ast.NewBindWithComment(
+		"f",
+		types.NewUnknown(nil),
+		ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+		"foo",
+	)
+
+	assert.Equal(
+		t,
+		b,
+		desugarLambdas(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b})).Binds()[0],
+	)
+}
@@ -959,6 +959,7 @@ func newModuleWithExpression(e ast.Expression) ast.Module {
 		"",
 		ast.NewExport(),
 		nil,
+		nil,
 		[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), e)},
 	)
 }
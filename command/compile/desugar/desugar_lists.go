@@ -6,10 +6,15 @@ import (
 	"github.com/raviqqe/lazy-ein/command/types"
 )
 
+// desugarLists binds elements of lists to variables and converts expanded
+// arguments into lazy appends so that only the last arguments of lists are
+// expanded.
 func desugarLists(m ast.Module) ast.Module {
 	g := names.NewNameGenerator("list")
+	f := g.Generate("append")
+	appended := false
 
-	return m.ConvertExpressions(func(e ast.Expression) ast.Expression {
+	m = m.ConvertExpressions(func(e ast.Expression) ast.Expression {
 		l, ok := e.(ast.List)
 
 		if !ok {
@@ -20,22 +25,112 @@ func desugarLists(m ast.Module) ast.Module {
 		as := make([]ast.ListArgument, 0, len(l.Arguments()))
 
 		for _, a := range l.Arguments() {
-			if a.Expanded() {
-				panic("not implemented")
-			} else if _, ok := a.Expression().(ast.Variable); ok {
-				as = append(as, a)
+			if _, ok := a.Expression().(ast.Variable); !ok && (a.Expanded() || !isAtomic(a.Expression())) {
+				s := g.Generate("element")
+				bs = append(bs, ast.NewBind(s, types.NewUnknown(nil), a.Expression()))
+				a = ast.NewListArgument(ast.NewVariable(s), a.Expanded())
+			}
+
+			as = append(as, a)
+		}
+
+		aas := []ast.ListArgument{}
+
+		for i := range as {
+			a := as[len(as)-1-i]
+
+			if !a.Expanded() || len(aas) == 0 {
+				aas = append([]ast.ListArgument{a}, aas...)
 				continue
 			}
 
-			s := g.Generate("element")
-			bs = append(bs, ast.NewBind(s, types.NewUnknown(nil), a.Expression()))
-			as = append(as, ast.NewListArgument(ast.NewVariable(s), false))
+			s := g.Generate("rest")
+			ss := g.Generate("appended")
+			bs = append(
+				bs,
+				ast.NewBind(
+					s,
+					types.NewUnknown(nil),
+					ast.NewList(types.NewUnknown(l.Type().DebugInformation()), aas),
+				),
+				ast.NewBind(
+					ss,
+					types.NewUnknown(nil),
+					ast.NewApplication(
+						ast.NewVariable(f),
+						[]ast.Expression{a.Expression(), ast.NewVariable(s)},
+					),
+				),
+			)
+			aas = []ast.ListArgument{ast.NewListArgument(ast.NewVariable(ss), true)}
+			appended = true
 		}
 
 		if len(bs) == 0 {
 			return l
 		}
 
-		return ast.NewLet(bs, ast.NewList(l.Type(), as))
+		return ast.NewLet(bs, ast.NewList(l.Type(), aas))
 	})
+
+	if !appended {
+		return m
+	}
+
+	return ast.NewModule(
+		m.Name(),
+		m.Export(),
+		m.Imports(),
+		m.TypeDefinitions(),
+		append(m.Binds(), createAppendBind(f)),
+	)
+}
+
+// createAppendBind creates a bind of a function which appends two lists
+// lazily.
+func createAppendBind(f string) ast.Bind {
+	t := types.NewList(types.NewParameter("a", nil), nil)
+
+	return ast.NewBind(
+		f,
+		types.NewFunction(t, types.NewFunction(t, t, nil), nil),
+		ast.NewLambda(
+			[]string{"$list.xs", "$list.ys"},
+			ast.NewCaseWithoutDefault(
+				ast.NewVariable("$list.xs"),
+				types.NewUnknown(nil),
+				[]ast.Alternative{
+					ast.NewAlternative(ast.NewList(types.NewUnknown(nil), nil), ast.NewVariable("$list.ys")),
+					ast.NewAlternative(
+						ast.NewList(
+							types.NewUnknown(nil),
+							[]ast.ListArgument{
+								ast.NewListArgument(ast.NewVariable("$list.x"), false),
+								ast.NewListArgument(ast.NewVariable("$list.tail"), true),
+							},
+						),
+						ast.NewLet(
+							[]ast.Bind{
+								ast.NewBind(
+									"$list.zs",
+									types.NewUnknown(nil),
+									ast.NewApplication(
+										ast.NewVariable(f),
+										[]ast.Expression{ast.NewVariable("$list.tail"), ast.NewVariable("$list.ys")},
+									),
+								),
+							},
+							ast.NewList(
+								types.NewUnknown(nil),
+								[]ast.ListArgument{
+									ast.NewListArgument(ast.NewVariable("$list.x"), false),
+									ast.NewListArgument(ast.NewVariable("$list.zs"), true),
+								},
+							),
+						),
+					),
+				},
+			),
+		),
+	)
 }
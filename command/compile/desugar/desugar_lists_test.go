@@ -12,8 +12,8 @@ func TestDesugarLists(t *testing.T) {
 	for _, ms := range [][2]ast.Module{
 		// Empty modules
 		{
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
 		},
 		// Simple lists
 		{
@@ -21,6 +21,7 @@ func TestDesugarLists(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"x",
@@ -36,6 +37,7 @@ func TestDesugarLists(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"x",
@@ -54,6 +56,7 @@ func TestDesugarLists(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"x",
@@ -77,6 +80,7 @@ func TestDesugarLists(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"x",
@@ -103,6 +107,108 @@ func TestDesugarLists(t *testing.T) {
 				},
 			),
 		},
+		// Lists with expanded last arguments
+		{
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"x",
+						types.NewList(types.NewNumber(nil), nil),
+						ast.NewList(
+							types.NewList(types.NewNumber(nil), nil),
+							[]ast.ListArgument{
+								ast.NewListArgument(ast.NewVariable("y"), false),
+								ast.NewListArgument(ast.NewVariable("ys"), true),
+							},
+						),
+					),
+				},
+			),
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"x",
+						types.NewList(types.NewNumber(nil), nil),
+						ast.NewList(
+							types.NewList(types.NewNumber(nil), nil),
+							[]ast.ListArgument{
+								ast.NewListArgument(ast.NewVariable("y"), false),
+								ast.NewListArgument(ast.NewVariable("ys"), true),
+							},
+						),
+					),
+				},
+			),
+		},
+		// Lists with expanded arguments in the middle
+		{
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"x",
+						types.NewList(types.NewNumber(nil), nil),
+						ast.NewList(
+							types.NewList(types.NewNumber(nil), nil),
+							[]ast.ListArgument{
+								ast.NewListArgument(ast.NewVariable("ys"), true),
+								ast.NewListArgument(ast.NewVariable("y"), false),
+							},
+						),
+					),
+				},
+			),
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"x",
+						types.NewList(types.NewNumber(nil), nil),
+						ast.NewLet(
+							[]ast.Bind{
+								ast.NewBind(
+									"$list.rest-0",
+									types.NewUnknown(nil),
+									ast.NewList(
+										types.NewUnknown(nil),
+										[]ast.ListArgument{ast.NewListArgument(ast.NewVariable("y"), false)},
+									),
+								),
+								ast.NewBind(
+									"$list.appended-0",
+									types.NewUnknown(nil),
+									ast.NewApplication(
+										ast.NewVariable("$list.append-0"),
+										[]ast.Expression{ast.NewVariable("ys"), ast.NewVariable("$list.rest-0")},
+									),
+								),
+							},
+							ast.NewList(
+								types.NewList(types.NewNumber(nil), nil),
+								[]ast.ListArgument{
+									ast.NewListArgument(ast.NewVariable("$list.appended-0"), true),
+								},
+							),
+						),
+					),
+					createAppendBind("$list.append-0"),
+				},
+			),
+		},
 	} {
 		assert.Equal(t, ms[1], desugarLists(ms[0]))
 	}
@@ -1,6 +1,8 @@
 package desugar
 
 import (
+	"math"
+
 	"github.com/raviqqe/lazy-ein/command/ast"
 	"github.com/raviqqe/lazy-ein/command/compile/desugar/names"
 	"github.com/raviqqe/lazy-ein/command/types"
@@ -9,6 +11,7 @@ import (
 func desugarLiterals(m ast.Module) ast.Module {
 	g := names.NewNameGenerator("")
 	bs := []ast.Bind{}
+	ss := map[interface{}]string{}
 
 	for _, b := range m.Binds() {
 		if l, ok := b.Expression().(ast.Literal); ok {
@@ -31,14 +34,33 @@ func desugarLiterals(m ast.Module) ast.Module {
 				return e
 			}
 
+			k := getLiteralKey(l)
+
+			if s, ok := ss[k]; ok {
+				return ast.NewVariable(s)
+			}
+
 			s := g.Generate("literal")
+			ss[k] = s
 
 			// TODO: Handle other literals.
 			switch l := l.(type) {
+			case ast.Boolean:
+				bs = append(
+					bs,
+					ast.NewBind(s, types.NewUnboxed(types.NewBoolean(nil), nil), ast.NewUnboxed(l)),
+				)
+				return ast.NewVariable(s)
 			case ast.Number:
 				bs = append(
 					bs,
-					ast.NewBind(s, types.NewUnboxed(types.NewNumber(nil), nil), ast.NewUnboxed(l)),
+					ast.NewBind(s, types.NewUnboxed(l.Type(), nil), ast.NewUnboxed(l)),
+				)
+				return ast.NewVariable(s)
+			case ast.String:
+				bs = append(
+					bs,
+					ast.NewBind(s, types.NewUnboxed(types.NewString(nil), nil), ast.NewUnboxed(l)),
 				)
 				return ast.NewVariable(s)
 			}
@@ -47,5 +69,21 @@ func desugarLiterals(m ast.Module) ast.Module {
 		}))
 	}
 
-	return ast.NewModule(m.Name(), m.Export(), m.Imports(), bs)
+	return ast.NewModule(m.Name(), m.Export(), m.Imports(), m.TypeDefinitions(), bs)
+}
+
+type numberKey struct {
+	bits    uint64
+	integer bool
+}
+
+// getLiteralKey returns a key to share binds of literals with the same values
+// and types.
+func getLiteralKey(l ast.Literal) interface{} {
+	if n, ok := l.(ast.Number); ok {
+		_, ok := n.Type().(types.Int)
+		return numberKey{math.Float64bits(n.Value()), ok}
+	}
+
+	return l
 }
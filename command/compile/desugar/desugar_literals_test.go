@@ -12,8 +12,8 @@ func TestDesugarLiterals(t *testing.T) {
 	for _, ms := range [][2]ast.Module{
 		// Don't convert empty modules
 		{
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
 		},
 		// Convert variable binds
 		// TODO: Don't convert variable binds in a special way and optimize codes in core language.
@@ -22,12 +22,14 @@ func TestDesugarLiterals(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
 			),
 			ast.NewModule(
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"x",
@@ -43,22 +45,24 @@ func TestDesugarLiterals(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
 						types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
-						ast.NewLambda([]string{"x"}, ast.NewNumber(42))),
+						ast.NewLambda([]string{"x"}, ast.NewNumberWithType(42, types.NewNumber(nil)))),
 				},
 			),
 			ast.NewModule(
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"$literal-0",
 						types.NewUnboxed(types.NewNumber(nil), nil),
-						ast.NewUnboxed(ast.NewNumber(42)),
+						ast.NewUnboxed(ast.NewNumberWithType(42, types.NewNumber(nil))),
 					),
 					ast.NewBind(
 						"f",
@@ -67,12 +71,142 @@ func TestDesugarLiterals(t *testing.T) {
 				},
 			),
 		},
+		// Convert integer literals
+		{
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewFunction(types.NewInt(nil), types.NewInt(nil), nil),
+						ast.NewLambda([]string{"x"}, ast.NewNumberWithType(42, types.NewInt(nil)))),
+				},
+			),
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"$literal-0",
+						types.NewUnboxed(types.NewInt(nil), nil),
+						ast.NewUnboxed(ast.NewNumberWithType(42, types.NewInt(nil))),
+					),
+					ast.NewBind(
+						"f",
+						types.NewFunction(types.NewInt(nil), types.NewInt(nil), nil),
+						ast.NewLambda([]string{"x"}, ast.NewVariable("$literal-0"))),
+				},
+			),
+		},
+		// Share binds of the same literals
+		{
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+						ast.NewLambda(
+							[]string{"x"},
+							ast.NewBinaryOperation(
+								ast.Add,
+								ast.NewNumberWithType(42, types.NewNumber(nil)),
+								ast.NewNumberWithType(42, types.NewNumber(nil)),
+							),
+						),
+					),
+				},
+			),
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"$literal-0",
+						types.NewUnboxed(types.NewNumber(nil), nil),
+						ast.NewUnboxed(ast.NewNumberWithType(42, types.NewNumber(nil))),
+					),
+					ast.NewBind(
+						"f",
+						types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+						ast.NewLambda(
+							[]string{"x"},
+							ast.NewBinaryOperation(
+								ast.Add,
+								ast.NewVariable("$literal-0"),
+								ast.NewVariable("$literal-0"),
+							),
+						),
+					),
+				},
+			),
+		},
+		// Don't share binds of literals with different types
+		{
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+						ast.NewLambda([]string{"x"}, ast.NewNumberWithType(42, types.NewNumber(nil))),
+					),
+					ast.NewBind(
+						"g",
+						types.NewFunction(types.NewInt(nil), types.NewInt(nil), nil),
+						ast.NewLambda([]string{"x"}, ast.NewNumberWithType(42, types.NewInt(nil))),
+					),
+				},
+			),
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"$literal-0",
+						types.NewUnboxed(types.NewNumber(nil), nil),
+						ast.NewUnboxed(ast.NewNumberWithType(42, types.NewNumber(nil))),
+					),
+					ast.NewBind(
+						"f",
+						types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+						ast.NewLambda([]string{"x"}, ast.NewVariable("$literal-0")),
+					),
+					ast.NewBind(
+						"$literal-1",
+						types.NewUnboxed(types.NewInt(nil), nil),
+						ast.NewUnboxed(ast.NewNumberWithType(42, types.NewInt(nil))),
+					),
+					ast.NewBind(
+						"g",
+						types.NewFunction(types.NewInt(nil), types.NewInt(nil), nil),
+						ast.NewLambda([]string{"x"}, ast.NewVariable("$literal-1")),
+					),
+				},
+			),
+		},
 		// Don't convert non-literal expressions
 		{
 			ast.NewModule(
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -85,6 +219,7 @@ func TestDesugarLiterals(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
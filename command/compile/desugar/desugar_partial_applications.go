@@ -14,7 +14,7 @@ func desugarPartialApplications(m ast.Module) ast.Module {
 		bs = append(bs, desugarPartialApplicationsInBind(b))
 	}
 
-	return ast.NewModule(m.Name(), m.Export(), m.Imports(), bs)
+	return ast.NewModule(m.Name(), m.Export(), m.Imports(), m.TypeDefinitions(), bs)
 }
 
 func desugarPartialApplicationsInBind(b ast.Bind) ast.Bind {
@@ -36,6 +36,10 @@ func desugarPartialApplicationsInBind(b ast.Bind) ast.Bind {
 		},
 	).(ast.Expression)
 
+	if _, ok := e.(ast.ForeignFunction); ok {
+		return ast.NewBind(b.Name(), b.Type(), e)
+	}
+
 	t, ok := b.Type().(types.Function)
 
 	if !ok {
@@ -58,8 +62,14 @@ func desugarPartialApplication(e ast.Expression, as []ast.Expression) ast.Expres
 	switch e := e.(type) {
 	case ast.Application:
 		return ast.NewApplication(e.Function(), append(e.Arguments(), as...))
-	case ast.Variable:
+	case ast.FieldAccess, ast.Variable:
 		return ast.NewApplication(e, as)
+	case ast.If:
+		return ast.NewIf(
+			e.Condition(),
+			desugarPartialApplication(e.Then(), as),
+			desugarPartialApplication(e.Else(), as),
+		)
 	case ast.Let:
 		return ast.NewLet(e.Binds(), desugarPartialApplication(e.Expression(), as))
 	}
@@ -12,8 +12,37 @@ func TestDesugarPartialApplications(t *testing.T) {
 	for _, ms := range [][2]ast.Module{
 		// Empty modules
 		{
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
-			ast.NewModule("", ast.NewExport(), nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{}),
+		},
+		// Foreign functions
+		{
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+						ast.NewForeignFunction("sin"),
+					),
+				},
+			),
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+						ast.NewForeignFunction("sin"),
+					),
+				},
+			),
 		},
 		// Variables with single arguments
 		{
@@ -21,6 +50,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -38,6 +68,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -64,6 +95,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -89,6 +121,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -126,6 +159,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -147,6 +181,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -177,6 +212,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -197,6 +233,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -226,6 +263,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -252,6 +290,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -287,6 +326,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -308,6 +348,7 @@ func TestDesugarPartialApplications(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{
 					ast.NewBind(
 						"f",
@@ -0,0 +1,54 @@
+package desugar
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/compile/desugar/names"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// desugarPatternBinds converts binds with patterns in let expressions into
+// binds of whole values and variables destructuring them lazily.
+func desugarPatternBinds(m ast.Module) ast.Module {
+	g := names.NewNameGenerator("pattern")
+
+	return m.ConvertExpressions(func(e ast.Expression) ast.Expression {
+		l, ok := e.(ast.Let)
+
+		if !ok {
+			return e
+		}
+
+		bs := make([]ast.Bind, 0, len(l.Binds()))
+
+		for _, b := range l.Binds() {
+			p := b.Pattern()
+
+			if p == nil {
+				bs = append(bs, b)
+				continue
+			}
+
+			s := g.Generate("value")
+			bs = append(bs, ast.NewBind(s, types.NewUnknown(nil), b.Expression()))
+
+			for _, e := range p.(ast.Tuple).Elements() {
+				v := e.(ast.Variable)
+
+				bs = append(
+					bs,
+					ast.NewBind(
+						v.Name(),
+						types.NewUnknown(nil),
+						ast.NewCaseWithoutDefault(
+							ast.NewVariable(s),
+							types.NewUnknown(nil),
+							[]ast.Alternative{ast.NewAlternative(p, v)},
+						),
+					),
+				)
+			}
+		}
+
+		return ast.NewLet(bs, l.Expression())
+	})
+}
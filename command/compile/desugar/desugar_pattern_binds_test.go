@@ -0,0 +1,81 @@
+package desugar
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesugarPatternBinds(t *testing.T) {
+	p := ast.NewTuple(
+		types.NewUnknown(nil),
+		[]ast.Expression{ast.NewVariable("x"), ast.NewVariable("y")},
+	)
+
+	for _, es := range [][2]ast.Expression{
+		// Let expressions without pattern binds
+		{
+			ast.NewLet(
+				[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), ast.NewNumber(42))},
+				ast.NewVariable("x"),
+			),
+			ast.NewLet(
+				[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), ast.NewNumber(42))},
+				ast.NewVariable("x"),
+			),
+		},
+		// Tuple patterns
+		{
+			ast.NewLet(
+				[]ast.Bind{ast.NewPatternBind(p, ast.NewVariable("z"))},
+				ast.NewVariable("x"),
+			),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind("$pattern.value-0", types.NewUnknown(nil), ast.NewVariable("z")),
+					ast.NewBind(
+						"x",
+						types.NewUnknown(nil),
+						ast.NewCaseWithoutDefault(
+							ast.NewVariable("$pattern.value-0"),
+							types.NewUnknown(nil),
+							[]ast.Alternative{ast.NewAlternative(p, ast.NewVariable("x"))},
+						),
+					),
+					ast.NewBind(
+						"y",
+						types.NewUnknown(nil),
+						ast.NewCaseWithoutDefault(
+							ast.NewVariable("$pattern.value-0"),
+							types.NewUnknown(nil),
+							[]ast.Alternative{ast.NewAlternative(p, ast.NewVariable("y"))},
+						),
+					),
+				},
+				ast.NewVariable("x"),
+			),
+		},
+	} {
+		assert.Equal(
+			t,
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), es[1])},
+			),
+			desugarPatternBinds(
+				ast.NewModule(
+					"",
+					ast.NewExport(),
+					nil,
+					nil,
+					[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), es[0])},
+				),
+			),
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package desugar
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/compile/desugar/names"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+func desugarRecords(m ast.Module) ast.Module {
+	g := names.NewNameGenerator("record")
+
+	return m.ConvertExpressions(func(e ast.Expression) ast.Expression {
+		bs := []ast.Bind{}
+
+		switch r := e.(type) {
+		case ast.Record:
+			fs, bbs := bindRecordFields(g, r.Fields())
+			bs = append(bs, bbs...)
+			e = ast.NewRecord(r.Type(), fs)
+		case ast.RecordUpdate:
+			rr, bbs := bindRecordExpression(g, r.Record(), "record")
+			bs = append(bs, bbs...)
+			fs, bbs := bindRecordFields(g, r.Fields())
+			bs = append(bs, bbs...)
+			e = ast.NewRecordUpdate(r.Type(), rr, fs)
+		case ast.FieldAccess:
+			rr, bbs := bindRecordExpression(g, r.Record(), "record")
+			bs = append(bs, bbs...)
+			e = ast.NewFieldAccess(r.Type(), rr, r.Field())
+		default:
+			return e
+		}
+
+		if len(bs) == 0 {
+			return e
+		}
+
+		return ast.NewLet(bs, e)
+	})
+}
+
+func bindRecordFields(g names.NameGenerator, fs []ast.RecordField) ([]ast.RecordField, []ast.Bind) {
+	ffs := make([]ast.RecordField, 0, len(fs))
+	bs := []ast.Bind{}
+
+	for _, f := range fs {
+		e, bbs := bindRecordExpression(g, f.Expression(), "field")
+		ffs = append(ffs, ast.NewRecordField(f.Name(), e))
+		bs = append(bs, bbs...)
+	}
+
+	return ffs, bs
+}
+
+func bindRecordExpression(g names.NameGenerator, e ast.Expression, s string) (ast.Expression, []ast.Bind) {
+	if isAtomic(e) {
+		return e, nil
+	}
+
+	s = g.Generate(s)
+
+	return ast.NewVariable(s), []ast.Bind{ast.NewBind(s, types.NewUnknown(nil), e)}
+}
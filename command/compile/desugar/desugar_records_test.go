@@ -0,0 +1,110 @@
+package desugar
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesugarRecords(t *testing.T) {
+	for _, es := range [][2]ast.Expression{
+		// Records with variables
+		{
+			ast.NewRecord(
+				types.NewUnknown(nil),
+				[]ast.RecordField{ast.NewRecordField("foo", ast.NewVariable("x"))},
+			),
+			ast.NewRecord(
+				types.NewUnknown(nil),
+				[]ast.RecordField{ast.NewRecordField("foo", ast.NewVariable("x"))},
+			),
+		},
+		// Records with complex fields
+		{
+			ast.NewRecord(
+				types.NewUnknown(nil),
+				[]ast.RecordField{
+					ast.NewRecordField(
+						"foo",
+						ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+					),
+				},
+			),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"$record.field-0",
+						types.NewUnknown(nil),
+						ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+					),
+				},
+				ast.NewRecord(
+					types.NewUnknown(nil),
+					[]ast.RecordField{ast.NewRecordField("foo", ast.NewVariable("$record.field-0"))},
+				),
+			),
+		},
+		// Record updates
+		{
+			ast.NewRecordUpdate(
+				types.NewUnknown(nil),
+				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+				[]ast.RecordField{ast.NewRecordField("foo", ast.NewVariable("y"))},
+			),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"$record.record-0",
+						types.NewUnknown(nil),
+						ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+					),
+				},
+				ast.NewRecordUpdate(
+					types.NewUnknown(nil),
+					ast.NewVariable("$record.record-0"),
+					[]ast.RecordField{ast.NewRecordField("foo", ast.NewVariable("y"))},
+				),
+			),
+		},
+		// Field accesses
+		{
+			ast.NewFieldAccess(
+				types.NewUnknown(nil),
+				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+				"foo",
+			),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"$record.record-0",
+						types.NewUnknown(nil),
+						ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+					),
+				},
+				ast.NewFieldAccess(types.NewUnknown(nil), ast.NewVariable("$record.record-0"), "foo"),
+			),
+		},
+	} {
+		assert.Equal(
+			t,
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), es[1])},
+			),
+			desugarRecords(
+				ast.NewModule(
+					"",
+					ast.NewExport(),
+					nil,
+					nil,
+					[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), es[0])},
+				),
+			),
+		)
+	}
+}
@@ -0,0 +1,38 @@
+package desugar
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/compile/desugar/names"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+func desugarTuples(m ast.Module) ast.Module {
+	g := names.NewNameGenerator("tuple")
+
+	return m.ConvertExpressions(func(e ast.Expression) ast.Expression {
+		t, ok := e.(ast.Tuple)
+
+		if !ok {
+			return e
+		}
+
+		bs := []ast.Bind{}
+		es := make([]ast.Expression, 0, len(t.Elements()))
+
+		for _, e := range t.Elements() {
+			if !isAtomic(e) {
+				s := g.Generate("element")
+				bs = append(bs, ast.NewBind(s, types.NewUnknown(nil), e))
+				e = ast.NewVariable(s)
+			}
+
+			es = append(es, e)
+		}
+
+		if len(bs) == 0 {
+			return t
+		}
+
+		return ast.NewLet(bs, ast.NewTuple(t.Type(), es))
+	})
+}
@@ -0,0 +1,68 @@
+package desugar
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesugarTuples(t *testing.T) {
+	for _, es := range [][2]ast.Expression{
+		// Tuples with variables
+		{
+			ast.NewTuple(
+				types.NewUnknown(nil),
+				[]ast.Expression{ast.NewVariable("x"), ast.NewNumber(42)},
+			),
+			ast.NewTuple(
+				types.NewUnknown(nil),
+				[]ast.Expression{ast.NewVariable("x"), ast.NewNumber(42)},
+			),
+		},
+		// Tuples with complex elements
+		{
+			ast.NewTuple(
+				types.NewUnknown(nil),
+				[]ast.Expression{
+					ast.NewVariable("x"),
+					ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+				},
+			),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"$tuple.element-0",
+						types.NewUnknown(nil),
+						ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+					),
+				},
+				ast.NewTuple(
+					types.NewUnknown(nil),
+					[]ast.Expression{ast.NewVariable("x"), ast.NewVariable("$tuple.element-0")},
+				),
+			),
+		},
+	} {
+		assert.Equal(
+			t,
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), es[1])},
+			),
+			desugarTuples(
+				ast.NewModule(
+					"",
+					ast.NewExport(),
+					nil,
+					nil,
+					[]ast.Bind{ast.NewBind("x", types.NewUnknown(nil), es[0])},
+				),
+			),
+		)
+	}
+}
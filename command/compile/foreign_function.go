@@ -0,0 +1,79 @@
+package compile
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// compileForeignFunction compiles a bind of a foreign function into its
+// declaration and a wrapper function which unboxes arguments and boxes a
+// result so that it can be called as an ordinary function.
+func (c compiler) compileForeignFunction(
+	b ast.Bind,
+	f ast.ForeignFunction,
+) (coreast.ForeignDeclaration, coreast.Bind, error) {
+	ts := []numberType{}
+	t := b.Type()
+
+	for {
+		f, ok := t.(types.Function)
+
+		if !ok {
+			break
+		}
+
+		tt, ok := toForeignNumberType(f.Argument())
+
+		if !ok {
+			return coreast.ForeignDeclaration{}, coreast.Bind{}, newForeignFunctionTypeError(b)
+		}
+
+		ts = append(ts, tt)
+		t = f.Result()
+	}
+
+	r, ok := toForeignNumberType(t)
+
+	if !ok || len(ts) == 0 {
+		return coreast.ForeignDeclaration{}, coreast.Bind{}, newForeignFunctionTypeError(b)
+	}
+
+	ps := make([]coretypes.Type, 0, len(ts))
+
+	for _, t := range ts {
+		ps = append(ps, primitiveType(t))
+	}
+
+	s := "$foreign-" + b.Name()
+
+	return coreast.NewForeignDeclaration(s, f.Name(), coretypes.NewFunction(ps, primitiveType(r))),
+		c.compileNumberFunction(
+			b.Name(),
+			ts,
+			r,
+			func(as []coreast.Atom) coreast.Expression {
+				return coreast.NewFunctionApplication(coreast.NewVariable(s), as)
+			},
+		),
+		nil
+}
+
+func toForeignNumberType(t types.Type) (numberType, bool) {
+	switch t := t.(type) {
+	case types.Int:
+		return t, true
+	case types.Number:
+		return t, true
+	}
+
+	return nil, false
+}
+
+func newForeignFunctionTypeError(b ast.Bind) error {
+	return types.NewTypeError(
+		"foreign functions must take and return numbers",
+		b.Type().DebugInformation(),
+	)
+}
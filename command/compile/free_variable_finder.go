@@ -19,6 +19,14 @@ func (f freeVariableFinder) Find(e ast.Expression) []string {
 			ss = append(ss, f.Find(a)...)
 		}
 
+		return ss
+	case ast.ConstructorApplication:
+		ss := []string{}
+
+		for _, a := range e.Arguments() {
+			ss = append(ss, f.Find(a)...)
+		}
+
 		return ss
 	case ast.BinaryOperation:
 		return append(f.Find(e.LHS()), f.Find(e.RHS())...)
@@ -34,6 +42,10 @@ func (f freeVariableFinder) Find(e ast.Expression) []string {
 		}
 
 		return ss
+	case ast.FieldAccess:
+		return f.Find(e.Record())
+	case ast.If:
+		return append(append(f.Find(e.Condition()), f.Find(e.Then())...), f.Find(e.Else())...)
 	case ast.Lambda:
 		ss := make([]string, 0, len(e.Arguments()))
 
@@ -66,9 +78,21 @@ func (f freeVariableFinder) Find(e ast.Expression) []string {
 		}
 
 		return ss
-	case ast.Number:
+	case ast.Record:
+		return f.findInRecordFields(e.Fields())
+	case ast.RecordUpdate:
+		return append(f.Find(e.Record()), f.findInRecordFields(e.Fields())...)
+	case ast.Tuple:
+		ss := []string{}
+
+		for _, e := range e.Elements() {
+			ss = append(ss, f.Find(e)...)
+		}
+
+		return ss
+	case ast.Boolean, ast.Number, ast.String:
 		break
-	case ast.Unboxed:
+	case ast.ForeignFunction, ast.Unboxed:
 		return nil
 	case ast.Variable:
 		if _, ok := f.variables[e.Name()]; ok {
@@ -81,7 +105,52 @@ func (f freeVariableFinder) Find(e ast.Expression) []string {
 	panic("unreachable")
 }
 
+func (f freeVariableFinder) findInRecordFields(fs []ast.RecordField) []string {
+	ss := []string{}
+
+	for _, ff := range fs {
+		ss = append(ss, f.Find(ff.Expression())...)
+	}
+
+	return ss
+}
+
+// RemoveVariable removes a variable from ones not regarded as free.
+func (f freeVariableFinder) RemoveVariable(s string) freeVariableFinder {
+	if _, ok := f.variables[s]; !ok {
+		return f
+	}
+
+	m := make(map[string]struct{}, len(f.variables))
+
+	for k := range f.variables {
+		if k != s {
+			m[k] = struct{}{}
+		}
+	}
+
+	return freeVariableFinder{m}
+}
+
 func (f freeVariableFinder) addVariablesFromPattern(e ast.Expression) freeVariableFinder {
+	if p, ok := e.(ast.ConstructorPattern); ok {
+		ss := make([]string, 0, len(p.Arguments()))
+
+		for _, v := range p.Arguments() {
+			ss = append(ss, v.Name())
+		}
+
+		return f.addVariables(ss...)
+	} else if t, ok := e.(ast.Tuple); ok {
+		ss := make([]string, 0, len(t.Elements()))
+
+		for _, e := range t.Elements() {
+			ss = append(ss, e.(ast.Variable).Name())
+		}
+
+		return f.addVariables(ss...)
+	}
+
 	l, ok := e.(ast.List)
 
 	if !ok {
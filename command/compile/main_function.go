@@ -0,0 +1,131 @@
+package compile
+
+import (
+	"fmt"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/core/compile/llir"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/llvm-mirror/llvm/bindings/go/llvm"
+)
+
+const (
+	mainFunctionName       = "main"
+	mainGlobalVariableName = "ein_main"
+	mainWrapperName        = "$main"
+)
+
+type mainFunction struct {
+	arguments    []types.Type
+	runtimeEntry string
+}
+
+// Main functions are called by different runtime entries depending on types
+// of their arguments.
+var mainFunctions = []mainFunction{
+	{[]types.Type{types.NewNumber(nil)}, "io_main_number"},
+	{
+		[]types.Type{types.NewList(types.NewString(nil), nil), types.NewString(nil)},
+		"io_main_string",
+	},
+}
+
+const invalidMainFunctionTypeMessage = "main function must be of type " +
+	"Number -> a or [String] -> String -> a"
+
+// generateMainWrapper generates a wrapper of a main function in a typed module
+// which converts its results into strings. Results of strings are passed
+// through as they are and the others are printed as JSON with newlines.
+func generateMainWrapper(m ast.Module) (string, []ast.Bind, error) {
+	for _, b := range m.Binds() {
+		if b.Name() != ast.MainFunctionName {
+			continue
+		}
+
+		for _, f := range mainFunctions {
+			r, ok := f.result(b.Type())
+
+			if !ok {
+				continue
+			}
+
+			bs, err := f.generateWrapper(r)
+
+			if err != nil {
+				return "", nil, err
+			}
+
+			return f.runtimeEntry, bs, nil
+		}
+
+		return "", nil, types.NewTypeError(invalidMainFunctionTypeMessage, b.Type().DebugInformation())
+	}
+
+	return "", nil, nil
+}
+
+func (f mainFunction) result(t types.Type) (types.Type, bool) {
+	for _, a := range f.arguments {
+		ft, ok := t.(types.Function)
+
+		if !ok {
+			return nil, false
+		} else if es, err := a.Unify(ft.Argument()); err != nil || len(es) != 0 {
+			return nil, false
+		}
+
+		t = ft.Result()
+	}
+
+	return t, true
+}
+
+func (f mainFunction) generateWrapper(r types.Type) ([]ast.Bind, error) {
+	ss := make([]string, 0, len(f.arguments))
+	es := make([]ast.Expression, 0, len(f.arguments))
+	t := types.Type(types.NewString(nil))
+
+	for i := len(f.arguments) - 1; i >= 0; i-- {
+		t = types.NewFunction(f.arguments[i], t, nil)
+	}
+
+	for i := range f.arguments {
+		s := fmt.Sprintf("x%v", i)
+		ss = append(ss, s)
+		es = append(es, ast.NewVariable(s))
+	}
+
+	e := apply(ast.MainFunctionName, es...)
+	bs := []ast.Bind{}
+
+	if _, ok := r.(types.String); !ok {
+		s, bbs, err := newPrinterGenerator().Generate(r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e = apply(
+			fromCodePointsName,
+			newCodePoints(expandedCodePoints(apply(s, e)), codePoint('\n')),
+		)
+		bs = bbs
+	}
+
+	return append(bs, ast.NewBind(mainWrapperName, t, ast.NewLambda(ss, e))), nil
+}
+
+func generateMainFunction(m llvm.Module, e string) {
+	p := llir.PointerType(llvm.Int8Type())
+	f := llvm.AddFunction(m, mainFunctionName, llir.FunctionType(llvm.Int32Type(), nil))
+
+	b := llvm.NewBuilder()
+	b.SetInsertPointAtEnd(llvm.AddBasicBlock(f, ""))
+
+	b.CreateCall(
+		llvm.AddFunction(m, e, llir.FunctionType(llvm.VoidType(), []llvm.Type{p})),
+		[]llvm.Value{b.CreateBitCast(m.NamedGlobal(mainGlobalVariableName), p, "")},
+		"",
+	)
+	b.CreateRet(llvm.ConstInt(llvm.Int32Type(), 0, false))
+}
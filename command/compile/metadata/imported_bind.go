@@ -0,0 +1,70 @@
+package metadata
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// ImportedBind is a bind imported from another module.
+// Polymorphic binds have their expressions and binds they depend on so that
+// they are specialized in importing modules.
+type ImportedBind struct {
+	name         string
+	typ          types.Type
+	expression   ast.Expression
+	dependencies []ImportedBind
+}
+
+func newImportedBind(n string, t types.Type) ImportedBind {
+	return ImportedBind{n, t, nil, nil}
+}
+
+func newPolymorphicImportedBind(n string, t types.Type, e ast.Expression, bs []ImportedBind) ImportedBind {
+	return ImportedBind{n, t, e, bs}
+}
+
+// Name returns a fully qualified name.
+func (b ImportedBind) Name() string {
+	return b.name
+}
+
+// GlobalName returns a global name.
+func (b ImportedBind) GlobalName() string {
+	return GlobalName(b.name)
+}
+
+// Type returns a type.
+func (b ImportedBind) Type() types.Type {
+	return b.typ
+}
+
+// Expression returns an expression of a polymorphic bind. Its free variables
+// are global names.
+func (b ImportedBind) Expression() (ast.Expression, bool) {
+	return b.expression, b.expression != nil
+}
+
+// Dependencies returns binds which an expression of a polymorphic bind
+// depends on directly or indirectly.
+func (b ImportedBind) Dependencies() []ImportedBind {
+	return b.dependencies
+}
+
+// Declaration returns a declaration in the core language.
+func (b ImportedBind) Declaration() coreast.LambdaDeclaration {
+	if t, ok := b.typ.(types.Function); ok {
+		f := t.ToCore().(coretypes.Function)
+		return coreast.NewLambdaDeclaration(nil, f.Arguments(), f.Result())
+	}
+
+	return coreast.NewLambdaDeclaration(nil, nil, coretypes.Unbox(b.typ.ToCore()))
+}
+
+// GlobalName converts a fully qualified name into a global name which is
+// distinct from any name in modules. Expressions of polymorphic binds refer
+// to other binds by their global names.
+func GlobalName(s string) string {
+	return "$" + s
+}
@@ -1,55 +1,94 @@
 package metadata
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/raviqqe/lazy-ein/command/ast"
-	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
-	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
 	"github.com/raviqqe/lazy-ein/command/types"
 )
 
 // Module is a module metadata.
 type Module struct {
 	name          ast.ModuleName
-	exportedBinds map[string]types.Type
-	declarations  []coreast.Declaration
+	exportedBinds map[string]ImportedBind
 }
 
-// NewModule returns a module metadata.
-func NewModule(m ast.Module) Module {
-	ts := make(map[string]types.Type, len(m.Export().Names()))
+// NewModule returns a module metadata. Names re-exported from imported modules
+// refer to their original binds. Expressions of exported polymorphic binds
+// must refer to other binds by their global names.
+func NewModule(m ast.Module, ms []Module) (Module, error) {
+	is, err := ResolveImports(m, ms)
 
-	for _, b := range m.ExportedBinds() {
-		ts[b.Name()] = types.Box(b.Type())
+	if err != nil {
+		return Module{}, err
 	}
 
-	ds := make([]coreast.Declaration, 0, len(ts))
+	bs := make(map[string]ImportedBind, len(m.Export().Names()))
+
+	for _, b := range m.ExportedBinds() {
+		if err := validateExportedType(b, m.Export().DebugInformation()); err != nil {
+			return Module{}, err
+		}
+
+		bs[b.Name()] = newExportedBind(m, b, is)
+	}
 
-	for n, t := range ts {
-		n := m.Name().Qualify(n)
+	mm := Module{m.Name(), bs}
 
-		switch t := t.(type) {
-		case types.Function:
-			f := t.ToCore().(coretypes.Function)
+	for _, s := range m.Export().Names() {
+		if _, ok := bs[s]; ok {
+			continue
+		} else if b, ok := is[s]; ok {
+			ss := strings.Split(s, ".")
 
-			ds = append(
-				ds,
-				coreast.NewDeclaration(
-					n,
-					coreast.NewLambdaDeclaration(nil, f.Arguments(), f.Result()),
-				),
+			if err := mm.addReexportedBind(ss[len(ss)-1], b, m.Export().DebugInformation()); err != nil {
+				return Module{}, err
+			}
+		} else if strings.Contains(s, ".") {
+			return Module{}, newExportError(
+				fmt.Sprintf("re-exported name '%v' not found", s),
+				m.Export().DebugInformation(),
 			)
-		default:
-			ds = append(
-				ds,
-				coreast.NewDeclaration(
-					n,
-					coreast.NewLambdaDeclaration(nil, nil, coretypes.Unbox(t.ToCore())),
-				),
+		}
+	}
+
+	mms := make(map[ast.ModuleName]Module, len(ms))
+
+	for _, m := range ms {
+		mms[m.Name()] = m
+	}
+
+	for _, n := range m.Export().Modules() {
+		ok := false
+
+		for _, i := range m.Imports() {
+			if i.Name() != n {
+				continue
+			}
+
+			ok = true
+
+			for s := range mms[n].ExportedBinds() {
+				if ss, ok := i.ImportedName(s); ok {
+					if err := mm.addReexportedBind(s, is[ss], m.Export().DebugInformation()); err != nil {
+						return Module{}, err
+					}
+				}
+			}
+		}
+
+		if !ok {
+			return Module{}, newExportError(
+				fmt.Sprintf("re-exported module '%v' not imported", n),
+				m.Export().DebugInformation(),
 			)
 		}
 	}
 
-	return Module{m.Name(), ts, ds}
+	return mm, nil
 }
 
 // Name returns a name.
@@ -58,11 +97,110 @@ func (m Module) Name() ast.ModuleName {
 }
 
 // ExportedBinds returns exported binds.
-func (m Module) ExportedBinds() map[string]types.Type {
+func (m Module) ExportedBinds() map[string]ImportedBind {
 	return m.exportedBinds
 }
 
-// Declarations reutrns declarations.
-func (m Module) Declarations() []coreast.Declaration {
-	return m.declarations
+func (m Module) addReexportedBind(s string, b ImportedBind, i *debug.Information) error {
+	if bb, ok := m.exportedBinds[s]; ok && bb.Name() != b.Name() {
+		return newExportError(fmt.Sprintf("name '%v' is exported more than once", s), i)
+	}
+
+	m.exportedBinds[s] = b
+
+	return nil
+}
+
+// newExportedBind creates an exported bind. Polymorphic binds are exported
+// with their expressions and binds they depend on.
+func newExportedBind(m ast.Module, b ast.Bind, is map[string]ImportedBind) ImportedBind {
+	s := m.Name().FullyQualify(b.Name())
+
+	if !types.IsPolymorphic(b.Type()) {
+		return newImportedBind(s, types.Box(b.Type()))
+	}
+
+	gs := make(map[string]ImportedBind, len(m.Binds())+len(is))
+
+	for _, b := range is {
+		gs[b.GlobalName()] = b
+	}
+
+	for _, b := range m.Binds() {
+		s := m.Name().FullyQualify(b.Name())
+
+		if types.IsPolymorphic(b.Type()) {
+			gs[GlobalName(s)] = newPolymorphicImportedBind(s, types.Box(b.Type()), b.Expression(), nil)
+		} else {
+			gs[GlobalName(s)] = newImportedBind(s, types.Box(b.Type()))
+		}
+	}
+
+	ds := map[string]ImportedBind{GlobalName(s): {}}
+
+	for es := []ast.Expression{b.Expression()}; len(es) != 0; es = es[1:] {
+		es[0].ConvertExpressions(func(e ast.Expression) ast.Expression {
+			v, ok := e.(ast.Variable)
+
+			if !ok {
+				return e
+			} else if _, ok := ds[v.Name()]; ok {
+				return e
+			}
+
+			d, ok := gs[v.Name()]
+
+			if !ok {
+				return e
+			} else if ee, ok := d.Expression(); ok {
+				d = newPolymorphicImportedBind(d.Name(), d.Type(), ee, nil)
+				es = append(es, ee)
+			}
+
+			ds[v.Name()] = d
+
+			return e
+		})
+	}
+
+	delete(ds, GlobalName(s))
+
+	ss := make([]string, 0, len(ds))
+
+	for s := range ds {
+		ss = append(ss, s)
+	}
+
+	sort.Strings(ss)
+
+	dds := make([]ImportedBind, 0, len(ss))
+
+	for _, s := range ss {
+		dds = append(dds, ds[s])
+	}
+
+	return newPolymorphicImportedBind(s, types.Box(b.Type()), b.Expression(), dds)
+}
+
+// validateExportedType rejects binds of types containing algebraic types
+// because other modules cannot refer to algebraic types defined in a module.
+func validateExportedType(b ast.Bind, i *debug.Information) error {
+	return b.Type().VisitTypes(func(t types.Type) error {
+		if a, ok := t.(types.Algebraic); ok {
+			return newExportError(
+				fmt.Sprintf(
+					"bind '%v' cannot be exported because its type contains module-local type '%v'",
+					b.Name(),
+					a.Name(),
+				),
+				i,
+			)
+		}
+
+		return nil
+	})
+}
+
+func newExportError(s string, i *debug.Information) error {
+	return debug.NewError("ExportError", s, i)
 }
@@ -0,0 +1,176 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/debug"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewModule(t *testing.T) {
+	m, err := NewModule(
+		ast.NewModule(
+			"foo",
+			ast.NewExport("x"),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42)),
+				ast.NewBind("y", types.NewNumber(nil), ast.NewNumber(42)),
+			},
+		),
+		nil,
+	)
+	assert.Nil(t, err)
+
+	assert.Equal(t, ast.ModuleName("foo"), m.Name())
+	assert.Equal(t, 1, len(m.ExportedBinds()))
+	assert.Equal(t, "foo.x", m.ExportedBinds()["x"].Name())
+}
+
+func TestNewModuleWithPolymorphicBinds(t *testing.T) {
+	m, err := NewModule(newPolymorphicTestModule(), nil)
+	assert.Nil(t, err)
+
+	b := m.ExportedBinds()["f"]
+	e, ok := b.Expression()
+
+	assert.True(t, ok)
+	assert.Equal(t, "foo.f", b.Name())
+	assert.Equal(t, "$foo.f", b.GlobalName())
+	assert.Equal(t, ast.NewApplication(ast.NewVariable("$foo.g"), []ast.Expression{ast.NewVariable("$foo.x")}), e)
+
+	ns := []string{}
+
+	for _, b := range b.Dependencies() {
+		ns = append(ns, b.Name())
+	}
+
+	assert.Equal(t, []string{"foo.g", "foo.x"}, ns)
+
+	_, ok = b.Dependencies()[0].Expression()
+	assert.True(t, ok)
+
+	_, ok = b.Dependencies()[1].Expression()
+	assert.False(t, ok)
+}
+
+func TestNewModuleWithReexports(t *testing.T) {
+	ms := []Module{newTestModule("a/util", "x", "y")}
+
+	for _, c := range []struct {
+		export  ast.Export
+		imports []ast.Import
+		names   map[string]string
+	}{
+		{
+			ast.NewExport("util.x"),
+			[]ast.Import{ast.NewImport("a/util", nil)},
+			map[string]string{"x": "a/util.x"},
+		},
+		{
+			ast.NewExport("au.x"),
+			[]ast.Import{ast.NewAliasedImport("a/util", "au", nil)},
+			map[string]string{"x": "a/util.x"},
+		},
+		{
+			ast.NewExport("y"),
+			[]ast.Import{ast.NewSelectiveImport("a/util", []string{"y"}, nil)},
+			map[string]string{"y": "a/util.y"},
+		},
+		{
+			ast.NewExportWithModules([]ast.ModuleName{"a/util"}),
+			[]ast.Import{ast.NewImport("a/util", nil)},
+			map[string]string{"x": "a/util.x", "y": "a/util.y"},
+		},
+		{
+			ast.NewExportWithModules([]ast.ModuleName{"a/util"}),
+			[]ast.Import{ast.NewSelectiveImport("a/util", []string{"x"}, nil)},
+			map[string]string{"x": "a/util.x"},
+		},
+		{
+			ast.NewExportWithModules([]ast.ModuleName{"a/util"}, "util.x"),
+			[]ast.Import{ast.NewImport("a/util", nil)},
+			map[string]string{"x": "a/util.x", "y": "a/util.y"},
+		},
+	} {
+		m, err := NewModule(ast.NewModule("foo", c.export, c.imports, nil, nil), ms)
+		assert.Nil(t, err)
+
+		ns := make(map[string]string, len(m.ExportedBinds()))
+
+		for s, b := range m.ExportedBinds() {
+			ns[s] = b.Name()
+		}
+
+		assert.Equal(t, c.names, ns)
+	}
+}
+
+func TestNewModuleError(t *testing.T) {
+	ms := []Module{newTestModule("a/util", "x", "y")}
+
+	for _, c := range []struct {
+		export  ast.Export
+		imports []ast.Import
+		binds   []ast.Bind
+	}{
+		{ast.NewExport("util.z"), []ast.Import{ast.NewImport("a/util", nil)}, nil},
+		{ast.NewExport("util.x"), nil, nil},
+		{ast.NewExportWithModules([]ast.ModuleName{"a/util"}), nil, nil},
+		{
+			ast.NewExport("x", "util.x"),
+			[]ast.Import{ast.NewImport("a/util", nil)},
+			[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+		},
+		{
+			ast.NewExport("x"),
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					types.NewFunction(
+						types.NewNumber(nil),
+						types.NewList(
+							types.NewAlgebraic("Foo", []types.Constructor{types.NewConstructor("Bar", nil)}, nil),
+							nil,
+						),
+						nil,
+					),
+					ast.NewLambda([]string{"y"}, ast.NewList(nil, nil)),
+				),
+			},
+		},
+	} {
+		i := debug.NewInformation("foo", 1, 1, "export { x }")
+		_, err := NewModule(
+			ast.NewModule("foo", c.export.WithDebugInformation(i), c.imports, nil, c.binds),
+			ms,
+		)
+
+		assert.Error(t, err)
+		assert.Equal(t, i, err.(debug.Error).DebugInformation())
+	}
+}
+
+func newPolymorphicTestModule() ast.Module {
+	a := types.NewParameter("a", nil)
+
+	return ast.NewModule(
+		"foo",
+		ast.NewExport("f"),
+		nil,
+		nil,
+		[]ast.Bind{
+			ast.NewBind(
+				"f",
+				a,
+				ast.NewApplication(ast.NewVariable("$foo.g"), []ast.Expression{ast.NewVariable("$foo.x")}),
+			),
+			ast.NewBind("g", types.NewFunction(types.NewNumber(nil), a, nil), ast.NewVariable("$foo.g")),
+			ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42)),
+		},
+	)
+}
@@ -0,0 +1,128 @@
+package metadata
+
+import (
+	"fmt"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// ResolveImports resolves binds imported by a module from metadata of
+// imported modules. Imported binds are indexed by their names in the module.
+// Imported polymorphic binds and binds they depend on are also indexed by
+// their global names.
+func ResolveImports(m ast.Module, ms []Module) (map[string]ImportedBind, error) {
+	mms := make(map[ast.ModuleName]Module, len(ms))
+
+	for _, m := range ms {
+		mms[m.Name()] = m
+	}
+
+	ls := make(map[string]struct{}, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		ls[b.Name()] = struct{}{}
+	}
+
+	qs := map[string]struct{}{}
+	bs := map[string]ImportedBind{}
+
+	for _, i := range sortImports(m.Imports()) {
+		mm, ok := mms[i.Name()]
+
+		if !ok {
+			return nil, newImportError(fmt.Sprintf("module '%v' not found", i.Name()), i)
+		}
+
+		if i.IsQualified() {
+			if _, ok := qs[i.Qualifier()]; ok {
+				return nil, newImportError(
+					fmt.Sprintf("import qualifier '%v' is duplicate", i.Qualifier()),
+					i,
+				)
+			}
+
+			qs[i.Qualifier()] = struct{}{}
+		}
+
+		for _, s := range i.Names() {
+			if _, ok := mm.ExportedBinds()[s]; !ok {
+				return nil, newImportError(
+					fmt.Sprintf("name '%v' is not exported from module '%v'", s, i.Name()),
+					i,
+				)
+			}
+		}
+
+		for n, b := range mm.ExportedBinds() {
+			s, ok := i.ImportedName(n)
+
+			if !ok {
+				continue
+			} else if _, ok := bs[s]; ok && i.IsPrelude() {
+				continue
+			} else if _, ok := ls[s]; ok && i.IsPrelude() {
+				continue
+			} else if _, ok := bs[s]; ok {
+				return nil, newImportError(fmt.Sprintf("name '%v' is imported more than once", s), i)
+			} else if _, ok := ls[s]; ok {
+				return nil, newImportError(
+					fmt.Sprintf("imported name '%v' conflicts with a bind in the module", s),
+					i,
+				)
+			}
+
+			bs[s] = b
+		}
+	}
+
+	return addDependencies(bs), nil
+}
+
+// addDependencies adds imported polymorphic binds and binds they depend on by
+// their global names.
+func addDependencies(bs map[string]ImportedBind) map[string]ImportedBind {
+	ds := map[string]ImportedBind{}
+
+	for _, b := range bs {
+		for _, d := range b.Dependencies() {
+			ds[d.GlobalName()] = d
+		}
+	}
+
+	for _, b := range bs {
+		if _, ok := b.Expression(); ok {
+			ds[b.GlobalName()] = b
+		}
+	}
+
+	for s, b := range ds {
+		bs[s] = b
+	}
+
+	return bs
+}
+
+// sortImports moves implicit imports of the prelude module to the end so that
+// their names are shadowed by binds and the other imports.
+func sortImports(is []ast.Import) []ast.Import {
+	iis := make([]ast.Import, 0, len(is))
+
+	for _, i := range is {
+		if !i.IsPrelude() {
+			iis = append(iis, i)
+		}
+	}
+
+	for _, i := range is {
+		if i.IsPrelude() {
+			iis = append(iis, i)
+		}
+	}
+
+	return iis
+}
+
+func newImportError(s string, i ast.Import) error {
+	return debug.NewError("ImportError", s, i.DebugInformation())
+}
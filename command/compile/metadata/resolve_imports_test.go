@@ -0,0 +1,159 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveImports(t *testing.T) {
+	ms := []Module{
+		newTestModule("a/util", "x", "y"),
+		newTestModule("b/util", "x", "y"),
+	}
+
+	for _, c := range []struct {
+		imports []ast.Import
+		names   map[string]string
+	}{
+		{
+			[]ast.Import{ast.NewImport("a/util", nil)},
+			map[string]string{"util.x": "a/util.x", "util.y": "a/util.y"},
+		},
+		{
+			[]ast.Import{
+				ast.NewImport("a/util", nil),
+				ast.NewAliasedImport("b/util", "butil", nil),
+			},
+			map[string]string{
+				"util.x":  "a/util.x",
+				"util.y":  "a/util.y",
+				"butil.x": "b/util.x",
+				"butil.y": "b/util.y",
+			},
+		},
+		{
+			[]ast.Import{
+				ast.NewSelectiveImport("a/util", []string{"x"}, nil),
+				ast.NewSelectiveImport("b/util", []string{"y"}, nil),
+			},
+			map[string]string{"x": "a/util.x", "y": "b/util.y"},
+		},
+	} {
+		bs, err := ResolveImports(ast.NewModule("", ast.NewExport(), c.imports, nil, nil), ms)
+		assert.Nil(t, err)
+
+		ns := make(map[string]string, len(bs))
+
+		for s, b := range bs {
+			ns[s] = b.Name()
+		}
+
+		assert.Equal(t, c.names, ns)
+	}
+}
+
+func TestResolveImportsWithPrelude(t *testing.T) {
+	ms := []Module{
+		newTestModule("a/prelude", "x", "y", "z"),
+		newTestModule("a/util", "x"),
+	}
+
+	bs, err := ResolveImports(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{
+				ast.NewPreludeImport("a/prelude"),
+				ast.NewSelectiveImport("a/util", []string{"x"}, nil),
+			},
+			nil,
+			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewNumber(42))},
+		),
+		ms,
+	)
+	assert.Nil(t, err)
+
+	ns := make(map[string]string, len(bs))
+
+	for s, b := range bs {
+		ns[s] = b.Name()
+	}
+
+	assert.Equal(t, map[string]string{"x": "a/util.x", "z": "a/prelude.z"}, ns)
+}
+
+func TestResolveImportsWithPolymorphicBinds(t *testing.T) {
+	m, err := NewModule(newPolymorphicTestModule(), nil)
+	assert.Nil(t, err)
+
+	bs, err := ResolveImports(
+		ast.NewModule("", ast.NewExport(), []ast.Import{ast.NewImport("foo", nil)}, nil, nil),
+		[]Module{m},
+	)
+	assert.Nil(t, err)
+
+	ns := make(map[string]string, len(bs))
+
+	for s, b := range bs {
+		ns[s] = b.Name()
+	}
+
+	assert.Equal(
+		t,
+		map[string]string{"foo.f": "foo.f", "$foo.f": "foo.f", "$foo.g": "foo.g", "$foo.x": "foo.x"},
+		ns,
+	)
+}
+
+func TestResolveImportsError(t *testing.T) {
+	ms := []Module{
+		newTestModule("a/util", "x", "y"),
+		newTestModule("b/util", "x", "y"),
+	}
+
+	for _, c := range []struct {
+		imports []ast.Import
+		binds   []ast.Bind
+	}{
+		{[]ast.Import{ast.NewImport("c/util", nil)}, nil},
+		{[]ast.Import{ast.NewImport("a/util", nil), ast.NewImport("b/util", nil)}, nil},
+		{
+			[]ast.Import{ast.NewImport("a/util", nil), ast.NewAliasedImport("b/util", "util", nil)},
+			nil,
+		},
+		{[]ast.Import{ast.NewSelectiveImport("a/util", []string{"z"}, nil)}, nil},
+		{
+			[]ast.Import{
+				ast.NewSelectiveImport("a/util", []string{"x"}, nil),
+				ast.NewSelectiveImport("b/util", []string{"x"}, nil),
+			},
+			nil,
+		},
+		{
+			[]ast.Import{ast.NewSelectiveImport("a/util", []string{"x"}, nil)},
+			[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+		},
+	} {
+		_, err := ResolveImports(ast.NewModule("", ast.NewExport(), c.imports, nil, c.binds), ms)
+		assert.Error(t, err)
+	}
+}
+
+func newTestModule(n ast.ModuleName, ss ...string) Module {
+	bs := make([]ast.Bind, 0, len(ss))
+
+	for _, s := range ss {
+		bs = append(bs, ast.NewBind(s, types.NewNumber(nil), ast.NewNumber(42)))
+	}
+
+	m, err := NewModule(ast.NewModule(n, ast.NewExport(ss...), nil, nil, bs), nil)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
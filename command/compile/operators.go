@@ -3,9 +3,26 @@ package compile
 import (
 	"github.com/raviqqe/lazy-ein/command/ast"
 	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
 )
 
-func binaryOperatorToPrimitive(o ast.BinaryOperator) coreast.PrimitiveOperator {
+// numberType is a type of numbers or integers.
+type numberType interface {
+	types.Type
+	CoreConstructor() coreast.Constructor
+}
+
+// primitiveType returns a primitive type in a number type.
+func primitiveType(t numberType) coretypes.Primitive {
+	return t.CoreConstructor().ConstructorType().Elements()[0].(coretypes.Primitive)
+}
+
+func binaryOperatorToPrimitive(o ast.BinaryOperator, t numberType) coreast.PrimitiveOperator {
+	if _, ok := t.(types.Int); ok {
+		return binaryOperatorToIntegerPrimitive(o)
+	}
+
 	switch o {
 	case ast.Add:
 		return coreast.AddFloat64
@@ -15,6 +32,53 @@ func binaryOperatorToPrimitive(o ast.BinaryOperator) coreast.PrimitiveOperator {
 		return coreast.MultiplyFloat64
 	case ast.Divide:
 		return coreast.DivideFloat64
+	case ast.Modulo:
+		return coreast.ModuloFloat64
+	case ast.Power:
+		return coreast.PowerFloat64
+	case ast.Equal:
+		return coreast.EqualFloat64
+	case ast.NotEqual:
+		return coreast.NotEqualFloat64
+	case ast.LessThan:
+		return coreast.LessThanFloat64
+	case ast.LessThanOrEqual:
+		return coreast.LessThanOrEqualFloat64
+	case ast.GreaterThan:
+		return coreast.GreaterThanFloat64
+	case ast.GreaterThanOrEqual:
+		return coreast.GreaterThanOrEqualFloat64
+	}
+
+	panic("unreachable")
+}
+
+func binaryOperatorToIntegerPrimitive(o ast.BinaryOperator) coreast.PrimitiveOperator {
+	switch o {
+	case ast.Add:
+		return coreast.AddInt64
+	case ast.Subtract:
+		return coreast.SubtractInt64
+	case ast.Multiply:
+		return coreast.MultiplyInt64
+	case ast.Divide:
+		return coreast.DivideInt64
+	case ast.Modulo:
+		return coreast.RemainderInt64
+	case ast.Power:
+		return coreast.PowerInt64
+	case ast.Equal:
+		return coreast.EqualInt64
+	case ast.NotEqual:
+		return coreast.NotEqualInt64
+	case ast.LessThan:
+		return coreast.LessThanInt64
+	case ast.LessThanOrEqual:
+		return coreast.LessThanOrEqualInt64
+	case ast.GreaterThan:
+		return coreast.GreaterThanInt64
+	case ast.GreaterThanOrEqual:
+		return coreast.GreaterThanOrEqualInt64
 	}
 
 	panic("unreachable")
@@ -0,0 +1,780 @@
+package compile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+const (
+	printIntName               = "$print-int"
+	printDigitsName            = "$print-digits"
+	printPaddedDigitsName      = "$print-paddedDigits"
+	printNumberName            = "$print-number"
+	printNonNegativeNumberName = "$print-nonNegativeNumber"
+	printDecimalName           = "$print-decimal"
+	countDigitsName            = "$print-countDigits"
+	decimalSignificandName     = "$print-decimalSignificand"
+	decimalExponentName        = "$print-decimalExponent"
+	printStringName            = "$print-string"
+	printEscapedStringName     = "$print-escapedString"
+)
+
+const (
+	decimalSignificandForeignName = "core_decimal_significand"
+	decimalExponentForeignName    = "core_decimal_exponent"
+)
+
+var codePointsType = types.NewList(types.NewNumber(nil), nil)
+
+// printerGenerator generates functions which serialize values of given types
+// into JSON as lists of code points.
+type printerGenerator struct {
+	printers map[string]string
+	binds    []ast.Bind
+	index    int
+}
+
+func newPrinterGenerator() *printerGenerator {
+	return &printerGenerator{map[string]string{}, nil, 0}
+}
+
+// Generate generates binds of printer functions and returns a name of a
+// printer function of a given type.
+func (g *printerGenerator) Generate(t types.Type) (string, []ast.Bind, error) {
+	s, err := g.generate(types.Box(t))
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return s, g.binds, nil
+}
+
+func (g *printerGenerator) generate(t types.Type) (string, error) {
+	k, err := g.typeKey(t)
+
+	if err != nil {
+		return "", err
+	} else if s, ok := g.printers[k]; ok {
+		return s, nil
+	}
+
+	switch t := t.(type) {
+	case types.Boolean:
+		return g.addPrinter(
+			k,
+			t,
+			"x",
+			ast.NewIf(
+				ast.NewVariable("x"),
+				newCodePoints(stringCodePoints("true")...),
+				newCodePoints(stringCodePoints("false")...),
+			),
+		), nil
+	case types.Int:
+		g.generateIntPrinter()
+		return printIntName, nil
+	case types.Number:
+		g.generateNumberPrinter()
+		return printNumberName, nil
+	case types.String:
+		g.generateStringPrinter()
+		return printStringName, nil
+	case types.List:
+		return g.generateListPrinter(k, t)
+	case types.Tuple:
+		return g.generateTuplePrinter(k, t)
+	case types.Record:
+		return g.generateRecordPrinter(k, t)
+	case types.Algebraic:
+		return g.generateAlgebraicPrinter(k, t)
+	}
+
+	panic("unreachable")
+}
+
+func (g *printerGenerator) generateListPrinter(k string, t types.List) (string, error) {
+	s := g.generateName("list")
+	ss := g.generateName("listElements")
+
+	g.printers[k] = s
+
+	p, err := g.generate(types.Box(t.Element()))
+
+	if err != nil {
+		return "", err
+	}
+
+	g.addBind(
+		s,
+		t,
+		"xs",
+		newListCase(
+			ast.NewVariable("xs"),
+			newCodePoints(stringCodePoints("[]")...),
+			"y",
+			"ys",
+			newCodePoints(
+				codePoint('['),
+				expandedCodePoints(apply(p, ast.NewVariable("y"))),
+				expandedCodePoints(apply(ss, ast.NewVariable("ys"))),
+			),
+		),
+	)
+	g.addBind(
+		ss,
+		t,
+		"xs",
+		newListCase(
+			ast.NewVariable("xs"),
+			newCodePoints(codePoint(']')),
+			"y",
+			"ys",
+			newCodePoints(
+				codePoint(','),
+				expandedCodePoints(apply(p, ast.NewVariable("y"))),
+				expandedCodePoints(apply(ss, ast.NewVariable("ys"))),
+			),
+		),
+	)
+
+	return s, nil
+}
+
+func (g *printerGenerator) generateTuplePrinter(k string, t types.Tuple) (string, error) {
+	s := g.generateName("tuple")
+	g.printers[k] = s
+
+	es := make([]ast.Expression, 0, len(t.Elements()))
+	as := []ast.ListArgument{codePoint('[')}
+
+	for i, tt := range t.Elements() {
+		p, err := g.generate(types.Box(tt))
+
+		if err != nil {
+			return "", err
+		}
+
+		v := ast.NewVariable(fmt.Sprintf("x%v", i))
+		es = append(es, v)
+
+		if i != 0 {
+			as = append(as, codePoint(','))
+		}
+
+		as = append(as, expandedCodePoints(apply(p, v)))
+	}
+
+	g.addBind(
+		s,
+		t,
+		"x",
+		ast.NewCaseWithoutDefault(
+			ast.NewVariable("x"),
+			types.NewUnknown(nil),
+			[]ast.Alternative{
+				ast.NewAlternative(
+					ast.NewTuple(types.NewUnknown(nil), es),
+					newCodePoints(append(as, codePoint(']'))...),
+				),
+			},
+		),
+	)
+
+	return s, nil
+}
+
+func (g *printerGenerator) generateRecordPrinter(k string, t types.Record) (string, error) {
+	s := g.generateName("record")
+	g.printers[k] = s
+
+	as := []ast.ListArgument{codePoint('{')}
+
+	for i, f := range t.FieldNames() {
+		p, err := g.generate(types.Box(t.Fields()[f]))
+
+		if err != nil {
+			return "", err
+		}
+
+		if i != 0 {
+			as = append(as, codePoint(','))
+		}
+
+		as = append(as, stringCodePoints(quoteString(f)+":")...)
+		as = append(
+			as,
+			expandedCodePoints(apply(p, ast.NewFieldAccess(t, ast.NewVariable("x"), f))),
+		)
+	}
+
+	g.addBind(s, t, "x", newCodePoints(append(as, codePoint('}'))...))
+
+	return s, nil
+}
+
+// generateAlgebraicPrinter generates a printer of an algebraic type.
+// Constructors without elements are printed as strings and the others are
+// printed as objects with their names as keys and their elements as values.
+func (g *printerGenerator) generateAlgebraicPrinter(
+	k string,
+	t types.Algebraic,
+) (string, error) {
+	s := g.generateName("algebraic")
+	g.printers[k] = s
+
+	as := make([]ast.Alternative, 0, len(t.Constructors()))
+
+	for i, c := range t.Constructors() {
+		n := c.Name()
+
+		if len(c.Elements()) == 0 {
+			as = append(
+				as,
+				ast.NewAlternative(
+					ast.NewConstructorPattern(n, nil),
+					newCodePoints(stringCodePoints(quoteString(n))...),
+				),
+			)
+
+			continue
+		}
+
+		vs := make([]ast.Variable, 0, len(c.Elements()))
+		aas := append(stringCodePoints("{"+quoteString(n)+":"), codePoint('['))
+
+		for j, t := range t.ConstructorElements(i) {
+			p, err := g.generate(types.Box(t))
+
+			if err != nil {
+				return "", err
+			}
+
+			v := ast.NewVariable(fmt.Sprintf("x%v", j))
+			vs = append(vs, v)
+
+			if j != 0 {
+				aas = append(aas, codePoint(','))
+			}
+
+			aas = append(aas, expandedCodePoints(apply(p, v)))
+		}
+
+		as = append(
+			as,
+			ast.NewAlternative(
+				ast.NewConstructorPattern(n, vs),
+				newCodePoints(append(aas, stringCodePoints("]}")...)...),
+			),
+		)
+	}
+
+	g.addBind(
+		s,
+		t,
+		"x",
+		ast.NewCaseWithoutDefault(ast.NewVariable("x"), types.NewUnknown(nil), as),
+	)
+
+	return s, nil
+}
+
+func (g *printerGenerator) generateIntPrinter() {
+	if _, ok := g.printers[printIntName]; ok {
+		return
+	}
+
+	g.printers[printIntName] = printIntName
+	i := types.NewInt(nil)
+
+	g.addBind(
+		printIntName,
+		i,
+		"i",
+		ast.NewIf(
+			ast.NewBinaryOperation(ast.LessThan, ast.NewVariable("i"), integer(0)),
+			newCodePoints(
+				codePoint('-'),
+				expandedCodePoints(
+					apply(
+						printDigitsName,
+						ast.NewBinaryOperation(ast.Subtract, integer(0), ast.NewVariable("i")),
+						newCodePoints(),
+					),
+				),
+			),
+			apply(printDigitsName, ast.NewVariable("i"), newCodePoints()),
+		),
+	)
+
+	// Digits of integers are prepended to lists of code points until their
+	// quotients become zero.
+	g.binds = append(
+		g.binds,
+		ast.NewBind(
+			printDigitsName,
+			types.NewFunction(i, types.NewFunction(codePointsType, codePointsType, nil), nil),
+			ast.NewLambda(
+				[]string{"i", "cs"},
+				ast.NewLet(
+					[]ast.Bind{
+						ast.NewBind("ds", codePointsType, prependDigit(ast.NewVariable("i"), "cs")),
+						ast.NewBind("j", i, apply(divName, ast.NewVariable("i"), integer(10))),
+					},
+					ast.NewIf(
+						ast.NewBinaryOperation(ast.Equal, ast.NewVariable("j"), integer(0)),
+						ast.NewVariable("ds"),
+						apply(printDigitsName, ast.NewVariable("j"), ast.NewVariable("ds")),
+					),
+				),
+			),
+		),
+		ast.NewBind(
+			printPaddedDigitsName,
+			types.NewFunction(
+				i,
+				types.NewFunction(i, types.NewFunction(codePointsType, codePointsType, nil), nil),
+				nil,
+			),
+			ast.NewLambda(
+				[]string{"i", "n", "cs"},
+				ast.NewIf(
+					ast.NewBinaryOperation(ast.Equal, ast.NewVariable("n"), integer(0)),
+					ast.NewVariable("cs"),
+					apply(
+						printPaddedDigitsName,
+						apply(divName, ast.NewVariable("i"), integer(10)),
+						ast.NewBinaryOperation(ast.Subtract, ast.NewVariable("n"), integer(1)),
+						prependDigit(ast.NewVariable("i"), "cs"),
+					),
+				),
+			),
+		),
+	)
+}
+
+// generateNumberPrinter generates a number printer. Infinities and NaNs are
+// printed as null because JSON does not support them.
+func (g *printerGenerator) generateNumberPrinter() {
+	if _, ok := g.printers[printNumberName]; ok {
+		return
+	}
+
+	g.printers[printNumberName] = printNumberName
+	g.generateIntPrinter()
+
+	i, n := types.NewInt(nil), types.NewNumber(nil)
+	x := ast.NewVariable("x")
+
+	g.addBind(
+		printNumberName,
+		n,
+		"x",
+		ast.NewIf(
+			ast.NewBinaryOperation(
+				ast.Equal,
+				ast.NewBinaryOperation(ast.Subtract, x, x),
+				number(0),
+			),
+			ast.NewIf(
+				ast.NewBinaryOperation(ast.LessThan, x, number(0)),
+				newCodePoints(
+					codePoint('-'),
+					expandedCodePoints(
+						apply(
+							printNonNegativeNumberName,
+							ast.NewBinaryOperation(ast.Subtract, number(0), x),
+						),
+					),
+				),
+				apply(printNonNegativeNumberName, x),
+			),
+			newCodePoints(stringCodePoints("null")...),
+		),
+	)
+
+	// Numbers are printed in their shortest decimal representations which are
+	// parsed back into the same numbers. Their significands and exponents are
+	// computed by the runtime.
+	g.binds = append(
+		g.binds,
+		ast.NewBind(
+			decimalSignificandName,
+			types.NewFunction(n, i, nil),
+			ast.NewForeignFunction(decimalSignificandForeignName),
+		),
+		ast.NewBind(
+			decimalExponentName,
+			types.NewFunction(n, i, nil),
+			ast.NewForeignFunction(decimalExponentForeignName),
+		),
+	)
+	g.addBind(
+		printNonNegativeNumberName,
+		n,
+		"x",
+		apply(printDecimalName, apply(decimalSignificandName, x), apply(decimalExponentName, x)),
+	)
+
+	m, e := ast.NewVariable("m"), ast.NewVariable("e")
+	d, k := ast.NewVariable("d"), ast.NewVariable("k")
+	ee := newCodePoints(
+		codePoint('e'),
+		ast.NewListArgument(
+			ast.NewIf(
+				ast.NewBinaryOperation(ast.LessThan, k, integer(1)),
+				number('-'),
+				number('+'),
+			),
+			false,
+		),
+		expandedCodePoints(
+			apply(
+				printDigitsName,
+				ast.NewIf(
+					ast.NewBinaryOperation(ast.LessThan, k, integer(1)),
+					ast.NewBinaryOperation(ast.Subtract, integer(1), k),
+					ast.NewBinaryOperation(ast.Subtract, k, integer(1)),
+				),
+				newCodePoints(),
+			),
+		),
+	)
+
+	// Decimals of significands m and exponents e are printed in the same way
+	// as JavaScript does where d is a number of digits in significands and k is
+	// an exponent of their first digits.
+	g.binds = append(
+		g.binds,
+		ast.NewBind(
+			printDecimalName,
+			types.NewFunction(i, types.NewFunction(i, codePointsType, nil), nil),
+			ast.NewLambda(
+				[]string{"m", "e"},
+				ast.NewLet(
+					[]ast.Bind{
+						ast.NewBind("d", i, apply(countDigitsName, m)),
+						ast.NewBind("k", i, ast.NewBinaryOperation(ast.Add, d, e)),
+					},
+					ast.NewIf(
+						ast.NewBinaryOperation(ast.GreaterThan, k, integer(21)),
+						printScientificDecimal(m, d, ee),
+						ast.NewIf(
+							ast.NewBinaryOperation(ast.LessThanOrEqual, k, integer(-6)),
+							printScientificDecimal(m, d, ee),
+							ast.NewIf(
+								ast.NewBinaryOperation(ast.GreaterThanOrEqual, e, integer(0)),
+								apply(
+									printDigitsName,
+									m,
+									apply(printPaddedDigitsName, integer(0), e, newCodePoints()),
+								),
+								ast.NewIf(
+									ast.NewBinaryOperation(ast.GreaterThan, k, integer(0)),
+									printFractionalDecimal(
+										m,
+										ast.NewBinaryOperation(ast.Subtract, integer(0), e),
+										newCodePoints(),
+									),
+									newCodePoints(
+										codePoint('0'),
+										codePoint('.'),
+										expandedCodePoints(
+											apply(
+												printPaddedDigitsName,
+												m,
+												ast.NewBinaryOperation(ast.Subtract, integer(0), e),
+												newCodePoints(),
+											),
+										),
+									),
+								),
+							),
+						),
+					),
+				),
+			),
+		),
+		ast.NewBind(
+			countDigitsName,
+			types.NewFunction(i, i, nil),
+			ast.NewLambda(
+				[]string{"m"},
+				ast.NewIf(
+					ast.NewBinaryOperation(ast.LessThan, m, integer(10)),
+					integer(1),
+					ast.NewBinaryOperation(
+						ast.Add,
+						integer(1),
+						apply(countDigitsName, apply(divName, m, integer(10))),
+					),
+				),
+			),
+		),
+	)
+}
+
+// printScientificDecimal prints a significand of d digits with a single digit
+// in its integer part followed by an exponent.
+func printScientificDecimal(m, d, e ast.Expression) ast.Expression {
+	return ast.NewIf(
+		ast.NewBinaryOperation(ast.Equal, d, integer(1)),
+		apply(printDigitsName, m, e),
+		printFractionalDecimal(m, ast.NewBinaryOperation(ast.Subtract, d, integer(1)), e),
+	)
+}
+
+// printFractionalDecimal prints a significand with f digits in its fractional
+// part followed by code points.
+func printFractionalDecimal(m, f, cs ast.Expression) ast.Expression {
+	p := ast.NewBinaryOperation(ast.Power, integer(10), f)
+
+	return apply(
+		printDigitsName,
+		apply(divName, m, p),
+		newCodePoints(
+			codePoint('.'),
+			expandedCodePoints(apply(printPaddedDigitsName, apply(modName, m, p), f, cs)),
+		),
+	)
+}
+
+// generateStringPrinter generates a string printer which escapes double
+// quotes, backslashes and control characters.
+func (g *printerGenerator) generateStringPrinter() {
+	if _, ok := g.printers[printStringName]; ok {
+		return
+	}
+
+	g.printers[printStringName] = printStringName
+	c := ast.NewVariable("c")
+	es := expandedCodePoints(ast.NewVariable("es"))
+
+	g.addBind(
+		printStringName,
+		types.NewString(nil),
+		"s",
+		newCodePoints(
+			codePoint('"'),
+			expandedCodePoints(
+				apply(printEscapedStringName, apply(toCodePointsName, ast.NewVariable("s"))),
+			),
+		),
+	)
+	g.addBind(
+		printEscapedStringName,
+		codePointsType,
+		"cs",
+		newListCase(
+			ast.NewVariable("cs"),
+			newCodePoints(codePoint('"')),
+			"c",
+			"ds",
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"es",
+						codePointsType,
+						apply(printEscapedStringName, ast.NewVariable("ds")),
+					),
+				},
+				ast.NewIf(
+					ast.NewBinaryOperation(ast.Equal, c, number('"')),
+					newCodePoints(codePoint('\\'), codePoint('"'), es),
+					ast.NewIf(
+						ast.NewBinaryOperation(ast.Equal, c, number('\\')),
+						newCodePoints(codePoint('\\'), codePoint('\\'), es),
+						ast.NewIf(
+							ast.NewBinaryOperation(ast.LessThan, c, number(' ')),
+							newCodePoints(
+								append(
+									stringCodePoints(`\u00`),
+									ast.NewListArgument(
+										ast.NewIf(
+											ast.NewBinaryOperation(ast.LessThan, c, number(16)),
+											number('0'),
+											number('1'),
+										),
+										false,
+									),
+									ast.NewListArgument(
+										hexadecimalDigit(ast.NewBinaryOperation(ast.Modulo, c, number(16))),
+										false,
+									),
+									es,
+								)...,
+							),
+							newCodePoints(ast.NewListArgument(c, false), es),
+						),
+					),
+				),
+			),
+		),
+	)
+}
+
+// generateName generates a unique name of a printer. Names are not qualified
+// with dots because they are global.
+func (g *printerGenerator) generateName(s string) string {
+	g.index++
+	return fmt.Sprintf("$print-%v-%v", s, g.index)
+}
+
+func (g *printerGenerator) addPrinter(k string, t types.Type, s string, e ast.Expression) string {
+	n := g.generateName("value")
+	g.printers[k] = n
+	g.addBind(n, t, s, e)
+	return n
+}
+
+func (g *printerGenerator) addBind(n string, t types.Type, s string, e ast.Expression) {
+	g.binds = append(
+		g.binds,
+		ast.NewBind(
+			n,
+			types.NewFunction(t, codePointsType, nil),
+			ast.NewLambda([]string{s}, e),
+		),
+	)
+}
+
+// typeKey returns a key which identifies a type structurally. Algebraic
+// types are identified by their names.
+func (g *printerGenerator) typeKey(t types.Type) (string, error) {
+	switch t := t.(type) {
+	case types.Boolean:
+		return "Bool", nil
+	case types.Int:
+		return printIntName, nil
+	case types.Number:
+		return printNumberName, nil
+	case types.String:
+		return printStringName, nil
+	case types.List:
+		k, err := g.typeKey(types.Box(t.Element()))
+
+		if err != nil {
+			return "", err
+		}
+
+		return "[" + k + "]", nil
+	case types.Tuple:
+		ks := make([]string, 0, len(t.Elements()))
+
+		for _, t := range t.Elements() {
+			k, err := g.typeKey(types.Box(t))
+
+			if err != nil {
+				return "", err
+			}
+
+			ks = append(ks, k)
+		}
+
+		return "(" + strings.Join(ks, ",") + ")", nil
+	case types.Record:
+		ks := make([]string, 0, len(t.Fields()))
+
+		for _, s := range t.FieldNames() {
+			k, err := g.typeKey(types.Box(t.Fields()[s]))
+
+			if err != nil {
+				return "", err
+			}
+
+			ks = append(ks, s+":"+k)
+		}
+
+		return "{" + strings.Join(ks, ",") + "}", nil
+	case types.Algebraic:
+		return "type " + t.Name(), nil
+	}
+
+	return "", types.NewTypeError("values of this type cannot be printed", t.DebugInformation())
+}
+
+func newListCase(e, empty ast.Expression, x, xs string, cons ast.Expression) ast.Expression {
+	return ast.NewCaseWithoutDefault(
+		e,
+		types.NewUnknown(nil),
+		[]ast.Alternative{
+			ast.NewAlternative(ast.NewList(types.NewUnknown(nil), nil), empty),
+			ast.NewAlternative(
+				ast.NewList(
+					types.NewUnknown(nil),
+					[]ast.ListArgument{
+						ast.NewListArgument(ast.NewVariable(x), false),
+						ast.NewListArgument(ast.NewVariable(xs), true),
+					},
+				),
+				cons,
+			),
+		},
+	)
+}
+
+func newCodePoints(as ...ast.ListArgument) ast.Expression {
+	return ast.NewList(codePointsType, as)
+}
+
+func codePoint(r rune) ast.ListArgument {
+	return ast.NewListArgument(number(float64(r)), false)
+}
+
+func stringCodePoints(s string) []ast.ListArgument {
+	as := make([]ast.ListArgument, 0, len(s))
+
+	for _, r := range s {
+		as = append(as, codePoint(r))
+	}
+
+	return as
+}
+
+func expandedCodePoints(e ast.Expression) ast.ListArgument {
+	return ast.NewListArgument(e, true)
+}
+
+func prependDigit(i ast.Expression, s string) ast.Expression {
+	return newCodePoints(
+		ast.NewListArgument(
+			ast.NewBinaryOperation(
+				ast.Add,
+				number('0'),
+				apply(toNumberName, apply(modName, i, integer(10))),
+			),
+			false,
+		),
+		expandedCodePoints(ast.NewVariable(s)),
+	)
+}
+
+func hexadecimalDigit(e ast.Expression) ast.Expression {
+	return ast.NewLet(
+		[]ast.Bind{ast.NewBind("d", types.NewNumber(nil), e)},
+		ast.NewIf(
+			ast.NewBinaryOperation(ast.LessThan, ast.NewVariable("d"), number(10)),
+			ast.NewBinaryOperation(ast.Add, number('0'), ast.NewVariable("d")),
+			ast.NewBinaryOperation(ast.Add, number('a'-10), ast.NewVariable("d")),
+		),
+	)
+}
+
+func quoteString(s string) string {
+	return `"` + s + `"`
+}
+
+func apply(s string, es ...ast.Expression) ast.Expression {
+	return ast.NewApplication(ast.NewVariable(s), es)
+}
+
+func number(n float64) ast.Expression {
+	return ast.NewNumberWithType(n, types.NewNumber(nil))
+}
+
+func integer(n float64) ast.Expression {
+	return ast.NewNumberWithType(n, types.NewInt(nil))
+}
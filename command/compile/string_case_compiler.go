@@ -0,0 +1,167 @@
+package compile
+
+import (
+	"github.com/raviqqe/lazy-ein/command/ast"
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+type stringAlternative struct {
+	codePoints []rune
+	expression coreast.Expression
+}
+
+type stringCaseCompiler struct {
+	compiler
+	typ coretypes.Algebraic
+}
+
+func newStringCaseCompiler(c compiler) stringCaseCompiler {
+	return stringCaseCompiler{c, coretypes.Unbox(types.NewString(nil).ToCore()).(coretypes.Algebraic)}
+}
+
+func (c stringCaseCompiler) Compile(cc ast.Case) (coreast.Expression, error) {
+	arg, err := c.compileExpression(cc.Argument())
+
+	if err != nil {
+		return nil, err
+	}
+
+	as := make([]stringAlternative, 0, len(cc.Alternatives()))
+
+	for _, a := range cc.Alternatives() {
+		e, err := c.compileExpression(a.Expression())
+
+		if err != nil {
+			return nil, err
+		}
+
+		as = append(as, stringAlternative{[]rune(a.Pattern().(ast.String).Value()), e})
+	}
+
+	d, ok := cc.DefaultAlternative()
+
+	if !ok {
+		return c.compileAlternatives(arg, as, nil), nil
+	}
+
+	t := coretypes.NewBoxed(c.typ)
+	e, err := c.addVariable(d.Variable(), t).compileExpression(d.Expression())
+
+	if err != nil {
+		return nil, err
+	} else if d.Variable() == "" {
+		return c.compileAlternatives(arg, as, e), nil
+	}
+
+	vs, err := c.compileFreeVariables(cc.Argument())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: Prove that name generation is not necessary here.
+	s := "$argument." + d.Variable()
+
+	return coreast.NewLet(
+		[]coreast.Bind{coreast.NewBind(s, coreast.NewVariableLambda(vs, arg, t))},
+		c.compileAlternatives(
+			coreast.NewFunctionApplication(coreast.NewVariable(s), nil),
+			as,
+			coreast.NewLet(
+				[]coreast.Bind{
+					coreast.NewBind(
+						d.Variable(),
+						coreast.NewVariableLambda(
+							[]coreast.Argument{coreast.NewArgument(s, t)},
+							coreast.NewFunctionApplication(coreast.NewVariable(s), nil),
+							t,
+						),
+					),
+				},
+				e,
+			),
+		),
+	), nil
+}
+
+// compileAlternatives compiles alternatives into nested cases matching a
+// string code point by code point. A default expression is nil if it does
+// not exist.
+func (c stringCaseCompiler) compileAlternatives(
+	arg coreast.Expression,
+	as []stringAlternative,
+	d coreast.Expression,
+) coreast.Expression {
+	aas := []coreast.AlgebraicAlternative{}
+	rs := []rune{}
+	ass := map[rune][]stringAlternative{}
+
+	for _, a := range as {
+		if len(a.codePoints) == 0 {
+			if len(aas) == 0 {
+				aas = append(
+					aas,
+					coreast.NewAlgebraicAlternative(coreast.NewConstructor(c.typ, 1), nil, a.expression),
+				)
+			}
+
+			continue
+		}
+
+		r := a.codePoints[0]
+
+		if _, ok := ass[r]; !ok {
+			rs = append(rs, r)
+		}
+
+		ass[r] = append(ass[r], stringAlternative{a.codePoints[1:], a.expression})
+	}
+
+	if len(rs) != 0 {
+		pas := make([]coreast.PrimitiveAlternative, 0, len(rs))
+
+		for _, r := range rs {
+			pas = append(
+				pas,
+				coreast.NewPrimitiveAlternative(
+					coreast.NewFloat64(float64(r)),
+					c.compileAlternatives(
+						coreast.NewFunctionApplication(coreast.NewVariable("$string-case.tail"), nil),
+						ass[r],
+						d,
+					),
+				),
+			)
+		}
+
+		e := coreast.NewFunctionApplication(coreast.NewVariable("$string-case.head"), nil)
+		pc := coreast.Expression(coreast.NewPrimitiveCaseWithoutDefault(e, coretypes.NewFloat64(), pas))
+
+		if d != nil {
+			pc = coreast.NewPrimitiveCase(e, coretypes.NewFloat64(), pas, coreast.NewDefaultAlternative("", d))
+		}
+
+		aas = append(
+			[]coreast.AlgebraicAlternative{
+				coreast.NewAlgebraicAlternative(
+					coreast.NewConstructor(c.typ, 0),
+					[]string{"$string-case.head", "$string-case.tail"},
+					pc,
+				),
+			},
+			aas...,
+		)
+	}
+
+	if d == nil {
+		return coreast.NewAlgebraicCaseWithoutDefault(arg, aas)
+	}
+
+	return coreast.NewAlgebraicCase(arg, aas, coreast.NewDefaultAlternative("", d))
+}
+
+func (c stringCaseCompiler) addVariable(s string, t coretypes.Type) stringCaseCompiler {
+	return stringCaseCompiler{c.compiler.addVariable(s, t), c.typ}
+}
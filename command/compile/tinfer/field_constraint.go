@@ -0,0 +1,15 @@
+package tinfer
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// fieldConstraint is a constraint that a record type has a field of a type.
+// It is resolved after the record type is inferred.
+type fieldConstraint struct {
+	record types.Type
+	name   string
+	field  types.Type
+}
+
+func newFieldConstraint(r types.Type, s string, f types.Type) fieldConstraint {
+	return fieldConstraint{r, s, f}
+}
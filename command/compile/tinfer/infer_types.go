@@ -1,31 +1,118 @@
 package tinfer
 
 import (
+	"fmt"
+
 	"github.com/raviqqe/lazy-ein/command/ast"
 	"github.com/raviqqe/lazy-ein/command/compile/metadata"
 	"github.com/raviqqe/lazy-ein/command/debug"
 	"github.com/raviqqe/lazy-ein/command/types"
 )
 
+const maxSpecializationDepth = 64
+
 // InferTypes infers types in a module with imported modules.
+// Types of top-level binds without type signatures are inferred first.
+// Polymorphic binds are specialized into monomorphic ones for each of their
+// uses. Imported polymorphic binds are specialized in the module too.
+// Exported polymorphic binds are kept with their expressions not inferred so
+// that other modules can specialize them.
 func InferTypes(m ast.Module, ms []metadata.Module) (ast.Module, error) {
-	m, err := newInferrer(m, ms).Infer(m)
+	is, err := metadata.ResolveImports(m, ms)
 
 	if err != nil {
 		return ast.Module{}, err
-	} else if err := validateFullyTypedModule(m); err != nil {
+	}
+
+	m, iis := importPolymorphicBinds(m, is)
+	m, err = newInferrer(m, iis).InferTopLevelTypes(m)
+
+	if err != nil {
+		return ast.Module{}, err
+	}
+
+	bs := exportPolymorphicBinds(m, is)
+
+	for i := 0; ; i++ {
+		mm, err := newInferrer(m, iis).Infer(m)
+
+		if err != nil {
+			return ast.Module{}, err
+		}
+
+		mm, ok := specialize(mm)
+
+		if !ok {
+			m = mm.AddBinds(bs)
+			break
+		} else if i == maxSpecializationDepth {
+			return ast.Module{}, newSpecializationDepthError(m)
+		}
+
+		m = mm
+	}
+
+	if err := validateFullyTypedModule(m); err != nil {
 		return ast.Module{}, err
 	}
 
-	return m, nil
+	return removeVariableTypes(m), nil
+}
+
+func newSpecializationDepthError(m ast.Module) error {
+	var i *debug.Information
+
+	for _, b := range m.Binds() {
+		if types.IsPolymorphic(b.Type()) {
+			i = b.Type().DebugInformation()
+			break
+		}
+	}
+
+	return debug.NewError("TypeInferenceError", "too deep specialization of polymorphic binds", i)
 }
 
 func validateFullyTypedModule(m ast.Module) error {
 	return m.VisitTypes(func(t types.Type) error {
-		if v, ok := t.(types.Variable); ok {
-			return debug.NewError("TypeInferenceError", "failed to infer a type", v.DebugInformation())
+		switch t := t.(type) {
+		case types.Variable:
+			return debug.NewError("TypeInferenceError", "failed to infer a type", t.DebugInformation())
+		case types.Reference:
+			return types.NewTypeError(fmt.Sprintf("type '%v' not resolved", t.Name()), t.DebugInformation())
 		}
 
 		return nil
 	})
 }
+
+// removeVariableTypes removes types of variables used only on type inference.
+func removeVariableTypes(m ast.Module) ast.Module {
+	f := func(e ast.Expression) ast.Expression {
+		if v, ok := e.(ast.Variable); ok {
+			return ast.NewVariable(v.Name())
+		}
+
+		return e
+	}
+
+	return m.ConvertExpressions(func(e ast.Expression) ast.Expression {
+		if c, ok := e.(ast.Case); ok {
+			as := make([]ast.Alternative, 0, len(c.Alternatives()))
+
+			for _, a := range c.Alternatives() {
+				as = append(
+					as,
+					ast.NewAlternative(a.Pattern().ConvertExpressions(f).(ast.Expression), a.Expression()),
+				)
+			}
+
+			if d, ok := c.DefaultAlternative(); ok {
+				return ast.NewCase(c.Argument(), c.Type(), as, d)
+			}
+
+			return ast.NewCaseWithoutDefault(c.Argument(), c.Type(), as)
+		}
+
+		return f(e)
+	})
+}
@@ -6,11 +6,14 @@ import (
 	"github.com/raviqqe/lazy-ein/command/ast"
 	"github.com/raviqqe/lazy-ein/command/compile/metadata"
 	"github.com/raviqqe/lazy-ein/command/compile/tinfer"
+	"github.com/raviqqe/lazy-ein/command/debug"
 	"github.com/raviqqe/lazy-ein/command/types"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestInferTypesWithLetExpressions(t *testing.T) {
+	n := ast.NewNumberWithType(42, types.NewNumber(nil))
+
 	for _, ls := range [][2]ast.Let{
 		// Constant expressions
 		{
@@ -19,8 +22,8 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 				ast.NewNumber(42),
 			),
 			ast.NewLet(
-				[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
-				ast.NewNumber(42),
+				[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), n)},
+				n,
 			),
 		},
 		// Nested let expressions
@@ -44,12 +47,12 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 						"x",
 						types.NewNumber(nil),
 						ast.NewLet(
-							[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
-							ast.NewNumber(42),
+							[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), n)},
+							n,
 						),
 					),
 				},
-				ast.NewNumber(42),
+				n,
 			),
 		},
 		// Local variables
@@ -74,11 +77,11 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 						types.NewNumber(nil),
 						ast.NewLet(
 							[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("x"))},
-							ast.NewNumber(42),
+							n,
 						),
 					),
 				},
-				ast.NewNumber(42),
+				n,
 			),
 		},
 		// Mutually recursive binds
@@ -93,9 +96,9 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 			ast.NewLet(
 				[]ast.Bind{
 					ast.NewBind("x", types.NewNumber(nil), ast.NewVariable("y")),
-					ast.NewBind("y", types.NewNumber(nil), ast.NewNumber(42)),
+					ast.NewBind("y", types.NewNumber(nil), n),
 				},
-				ast.NewNumber(42),
+				n,
 			),
 		},
 		// Functions with single arguments
@@ -122,7 +125,7 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 						ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
 					),
 				},
-				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewNumber(42)}),
+				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{n}),
 			),
 		},
 		// Functions with multiple arguments
@@ -158,7 +161,7 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 				},
 				ast.NewApplication(
 					ast.NewVariable("f"),
-					[]ast.Expression{ast.NewNumber(42), ast.NewNumber(42)},
+					[]ast.Expression{n, n},
 				),
 			),
 		},
@@ -179,10 +182,10 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 					ast.NewBind(
 						"x",
 						types.NewNumber(nil),
-						ast.NewBinaryOperation(ast.Add, ast.NewNumber(42), ast.NewNumber(42)),
+						ast.NewBinaryOperation(ast.Add, n, n),
 					),
 				},
-				ast.NewNumber(42),
+				n,
 			),
 		},
 		// Number case expressions
@@ -210,16 +213,16 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 						"x",
 						types.NewNumber(nil),
 						ast.NewCase(
-							ast.NewNumber(42),
+							n,
 							types.NewNumber(nil),
 							[]ast.Alternative{
-								ast.NewAlternative(ast.NewNumber(42), ast.NewNumber(42)),
+								ast.NewAlternative(n, n),
 							},
 							ast.NewDefaultAlternative("y", ast.NewVariable("y")),
 						),
 					),
 				},
-				ast.NewNumber(42),
+				n,
 			),
 		},
 		// List literals
@@ -244,11 +247,11 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 						types.NewList(types.NewNumber(nil), nil),
 						ast.NewList(
 							types.NewList(types.NewNumber(nil), nil),
-							[]ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)},
+							[]ast.ListArgument{ast.NewListArgument(n, false)},
 						),
 					),
 				},
-				ast.NewNumber(42),
+				n,
 			),
 		},
 		// List case expressions
@@ -286,22 +289,22 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 						ast.NewCaseWithoutDefault(
 							ast.NewList(
 								types.NewList(types.NewNumber(nil), nil),
-								[]ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)},
+								[]ast.ListArgument{ast.NewListArgument(n, false)},
 							),
 							types.NewList(types.NewNumber(nil), nil),
 							[]ast.Alternative{
 								ast.NewAlternative(
 									ast.NewList(
 										types.NewList(types.NewNumber(nil), nil),
-										[]ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)},
+										[]ast.ListArgument{ast.NewListArgument(n, false)},
 									),
-									ast.NewNumber(42),
+									n,
 								),
 							},
 						),
 					),
 				},
-				ast.NewNumber(42),
+				n,
 			),
 		},
 		// List case expressions with variable elements
@@ -342,7 +345,7 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 						ast.NewCaseWithoutDefault(
 							ast.NewList(
 								types.NewList(types.NewNumber(nil), nil),
-								[]ast.ListArgument{ast.NewListArgument(ast.NewNumber(42), false)},
+								[]ast.ListArgument{ast.NewListArgument(n, false)},
 							),
 							types.NewList(types.NewNumber(nil), nil),
 							[]ast.Alternative{
@@ -360,7 +363,7 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 						),
 					),
 				},
-				ast.NewNumber(42),
+				n,
 			),
 		},
 	} {
@@ -369,6 +372,7 @@ func TestInferTypesWithLetExpressions(t *testing.T) {
 				"",
 				ast.NewExport(),
 				nil,
+				nil,
 				[]ast.Bind{ast.NewBind("bar", types.NewNumber(nil), ls[0])},
 			),
 			nil,
@@ -384,6 +388,7 @@ func TestInferTypesWithArguments(t *testing.T) {
 		"",
 		ast.NewExport(),
 		nil,
+		nil,
 		[]ast.Bind{
 			ast.NewBind(
 				"f",
@@ -403,6 +408,7 @@ func TestInferTypesWithFunctionApplications(t *testing.T) {
 		"",
 		ast.NewExport(),
 		nil,
+		nil,
 		[]ast.Bind{
 			ast.NewBind(
 				"a",
@@ -446,6 +452,7 @@ func TestInferTypesWithLambda(t *testing.T) {
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"f",
@@ -468,102 +475,1060 @@ func TestInferTypesWithLambda(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-func TestInferTypesErrorWithUnknownVarabiles(t *testing.T) {
+func TestInferTypesWithIfExpressions(t *testing.T) {
 	_, err := tinfer.InferTypes(
 		ast.NewModule(
 			"",
 			ast.NewExport(),
 			nil,
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"x",
 					types.NewNumber(nil),
-					ast.NewLet(
-						[]ast.Bind{
-							ast.NewBind("y", types.NewUnknown(nil), ast.NewVariable("z")),
-						},
-						ast.NewNumber(42),
-					),
+					ast.NewIf(ast.NewBoolean(true), ast.NewNumber(1), ast.NewNumber(2)),
 				),
 			},
 		),
 		nil,
 	)
 
-	assert.Error(t, err)
+	assert.Nil(t, err)
 }
 
-func TestInferTypesWithImportedModules(t *testing.T) {
+func TestInferTypesErrorWithIfExpressions(t *testing.T) {
+	for _, e := range []ast.Expression{
+		ast.NewIf(ast.NewNumber(42), ast.NewNumber(1), ast.NewNumber(2)),
+		ast.NewIf(ast.NewBoolean(true), ast.NewNumber(1), ast.NewBoolean(false)),
+		ast.NewIf(ast.NewBoolean(true), ast.NewBoolean(true), ast.NewBoolean(false)),
+	} {
+		_, err := tinfer.InferTypes(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), e)},
+			),
+			nil,
+		)
+
+		assert.Error(t, err)
+	}
+}
+
+func TestInferTypesWithIntegers(t *testing.T) {
+	i := types.NewInt(nil)
+
+	for _, b := range []ast.Bind{
+		ast.NewBind("x", i, ast.NewNumber(42)),
+		ast.NewBind("x", i, ast.NewBinaryOperation(ast.Add, ast.NewNumber(42), ast.NewNumber(42))),
+		ast.NewBind(
+			"x",
+			i,
+			ast.NewApplication(ast.NewVariable("toInt"), []ast.Expression{ast.NewNumber(42)}),
+		),
+		ast.NewBind(
+			"x",
+			types.NewNumber(nil),
+			ast.NewApplication(
+				ast.NewVariable("toNumber"),
+				[]ast.Expression{ast.NewNumberWithType(42, i)},
+			),
+		),
+		ast.NewBind(
+			"x",
+			i,
+			ast.NewApplication(
+				ast.NewVariable("mod"),
+				[]ast.Expression{ast.NewNumber(42), ast.NewNumber(2)},
+			),
+		),
+		ast.NewBind(
+			"x",
+			i,
+			ast.NewCase(
+				ast.NewNumber(42),
+				types.NewUnknown(nil),
+				[]ast.Alternative{ast.NewAlternative(ast.NewNumber(42), ast.NewNumber(0))},
+				ast.NewDefaultAlternative("y", ast.NewVariable("y")),
+			),
+		),
+	} {
+		_, err := tinfer.InferTypes(
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}),
+			nil,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestInferTypesErrorWithIntegers(t *testing.T) {
+	i := types.NewInt(nil)
+
+	for _, b := range []ast.Bind{
+		ast.NewBind("x", i, ast.NewNumberWithType(4.2, types.NewNumber(nil))),
+		ast.NewBind(
+			"x",
+			i,
+			ast.NewBinaryOperation(
+				ast.Add,
+				ast.NewNumber(42),
+				ast.NewNumberWithType(4.2, types.NewNumber(nil)),
+			),
+		),
+		ast.NewBind(
+			"x",
+			i,
+			ast.NewApplication(ast.NewVariable("toNumber"), []ast.Expression{ast.NewNumber(42)}),
+		),
+		ast.NewBind("x", i, ast.NewBinaryOperation(ast.Add, ast.NewString("foo"), ast.NewString("foo"))),
+	} {
+		_, err := tinfer.InferTypes(
+			ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}),
+			nil,
+		)
+
+		assert.Error(t, err)
+	}
+}
+
+func TestInferTypesWithAlgebraicTypes(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Foo",
+		[]types.Constructor{
+			types.NewConstructor("Bar", []types.Type{types.NewNumber(nil)}),
+			types.NewConstructor("Baz", nil),
+		},
+		nil,
+	)
+
 	_, err := tinfer.InferTypes(
 		ast.NewModule(
 			"",
 			ast.NewExport(),
-			[]ast.Import{ast.NewImport("foo/bar")},
-			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("bar.x"))},
-		),
-		[]metadata.Module{
-			metadata.NewModule(
-				ast.NewModule(
-					"foo/bar",
-					ast.NewExport("x"),
-					nil,
-					[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"Bar",
+					types.NewFunction(types.NewNumber(nil), a, nil),
+					ast.NewLambda(
+						[]string{"x"},
+						ast.NewConstructorApplication(a, 0, []ast.Expression{ast.NewVariable("x")}),
+					),
 				),
-			),
-		},
+				ast.NewBind(
+					"x",
+					types.NewNumber(nil),
+					ast.NewCase(
+						ast.NewApplication(ast.NewVariable("Bar"), []ast.Expression{ast.NewNumber(42)}),
+						types.NewUnknown(nil),
+						[]ast.Alternative{
+							ast.NewAlternative(
+								ast.NewConstructorPattern("Bar", []ast.Variable{ast.NewVariable("y")}),
+								ast.NewVariable("y"),
+							),
+						},
+						ast.NewDefaultAlternative("", ast.NewNumber(0)),
+					),
+				),
+			},
+		),
+		nil,
 	)
 
 	assert.Nil(t, err)
 }
 
-func TestInferTypesWithUnboxedTypesImportedModules(t *testing.T) {
-	_, err := tinfer.InferTypes(
-		ast.NewModule(
-			"",
-			ast.NewExport(),
-			[]ast.Import{ast.NewImport("foo/bar")},
-			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("bar.x"))},
+func TestInferTypesErrorWithConstructorPatterns(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Foo",
+		[]types.Constructor{types.NewConstructor("Bar", []types.Type{types.NewNumber(nil)})},
+		nil,
+	)
+
+	for _, p := range []ast.ConstructorPattern{
+		ast.NewConstructorPattern("Baz", nil),
+		ast.NewConstructorPattern("Bar", nil),
+		ast.NewConstructorPattern("Bar", []ast.Variable{ast.NewVariable("y"), ast.NewVariable("z")}),
+		ast.NewConstructorPattern("x", nil),
+	} {
+		_, err := tinfer.InferTypes(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				nil,
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"Bar",
+						types.NewFunction(types.NewNumber(nil), a, nil),
+						ast.NewLambda(
+							[]string{"x"},
+							ast.NewConstructorApplication(a, 0, []ast.Expression{ast.NewVariable("x")}),
+						),
+					),
+					ast.NewBind(
+						"x",
+						types.NewNumber(nil),
+						ast.NewCaseWithoutDefault(
+							ast.NewApplication(ast.NewVariable("Bar"), []ast.Expression{ast.NewNumber(42)}),
+							types.NewUnknown(nil),
+							[]ast.Alternative{ast.NewAlternative(p, ast.NewNumber(0))},
+						),
+					),
+				},
+			),
+			nil,
+		)
+
+		assert.Error(t, err)
+	}
+}
+
+func TestInferTypesWithRecords(t *testing.T) {
+	r := types.NewRecord(
+		map[string]types.Type{"foo": types.NewNumber(nil), "bar": types.NewString(nil)},
+		nil,
+	)
+
+	for _, b := range []ast.Bind{
+		ast.NewBind(
+			"x",
+			r,
+			ast.NewRecord(
+				types.NewUnknown(nil),
+				[]ast.RecordField{
+					ast.NewRecordField("foo", ast.NewNumber(42)),
+					ast.NewRecordField("bar", ast.NewString("baz")),
+				},
+			),
 		),
-		[]metadata.Module{
-			metadata.NewModule(
-				ast.NewModule(
-					"foo/bar",
-					ast.NewExport("x"),
-					nil,
-					[]ast.Bind{
-						ast.NewBind(
-							"x",
-							types.NewUnboxed(types.NewNumber(nil), nil),
-							ast.NewUnboxed(ast.NewNumber(42)),
+		ast.NewBind(
+			"x",
+			types.NewFunction(r, r, nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewRecordUpdate(
+					types.NewUnknown(nil),
+					ast.NewVariable("y"),
+					[]ast.RecordField{ast.NewRecordField("foo", ast.NewNumber(42))},
+				),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(r, types.NewNumber(nil), nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewBinaryOperation(
+					ast.Add,
+					ast.NewFieldAccess(types.NewUnknown(nil), ast.NewVariable("y"), "foo"),
+					ast.NewNumber(42),
+				),
+			),
+		),
+	} {
+		_, err := tinfer.InferTypes(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestInferTypesErrorWithRecords(t *testing.T) {
+	r := types.NewRecord(map[string]types.Type{"foo": types.NewNumber(nil)}, nil)
+
+	for _, b := range []ast.Bind{
+		ast.NewBind(
+			"x",
+			r,
+			ast.NewRecord(
+				types.NewUnknown(nil),
+				[]ast.RecordField{ast.NewRecordField("foo", ast.NewString("bar"))},
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(r, r, nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewRecordUpdate(
+					types.NewUnknown(nil),
+					ast.NewVariable("y"),
+					[]ast.RecordField{ast.NewRecordField("bar", ast.NewNumber(42))},
+				),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(r, types.NewNumber(nil), nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewFieldAccess(types.NewUnknown(nil), ast.NewVariable("y"), "bar"),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewFieldAccess(types.NewUnknown(nil), ast.NewVariable("y"), "foo"),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewNumber(nil),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewUnknown(nil),
+						ast.NewLambda(
+							[]string{"y"},
+							ast.NewFieldAccess(types.NewUnknown(nil), ast.NewVariable("y"), "foo"),
+						),
+					),
+				},
+				ast.NewNumber(42),
+			),
+		),
+	} {
+		_, err := tinfer.InferTypes(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
+
+		assert.Error(t, err)
+	}
+}
+
+func TestInferTypesWithTuples(t *testing.T) {
+	tt := types.NewTuple([]types.Type{types.NewNumber(nil), types.NewString(nil)}, nil)
+
+	for _, b := range []ast.Bind{
+		ast.NewBind(
+			"x",
+			tt,
+			ast.NewTuple(types.NewUnknown(nil), []ast.Expression{ast.NewNumber(42), ast.NewString("foo")}),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(tt, types.NewNumber(nil), nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewCaseWithoutDefault(
+					ast.NewVariable("y"),
+					types.NewUnknown(nil),
+					[]ast.Alternative{
+						ast.NewAlternative(
+							ast.NewTuple(
+								types.NewUnknown(nil),
+								[]ast.Expression{ast.NewVariable("z"), ast.NewVariable("v")},
+							),
+							ast.NewVariable("z"),
 						),
 					},
 				),
 			),
-		},
-	)
+		),
+	} {
+		_, err := tinfer.InferTypes(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
 
-	assert.Nil(t, err)
+		assert.Nil(t, err)
+	}
 }
 
-func TestInferTypesErrorWithImportedModules(t *testing.T) {
-	_, err := tinfer.InferTypes(
+func TestInferTypesErrorWithTuples(t *testing.T) {
+	tt := types.NewTuple([]types.Type{types.NewNumber(nil), types.NewString(nil)}, nil)
+
+	for _, b := range []ast.Bind{
+		ast.NewBind(
+			"x",
+			tt,
+			ast.NewTuple(types.NewUnknown(nil), []ast.Expression{ast.NewNumber(42), ast.NewNumber(42)}),
+		),
+		ast.NewBind(
+			"x",
+			tt,
+			ast.NewTuple(
+				types.NewUnknown(nil),
+				[]ast.Expression{ast.NewNumber(42), ast.NewString("foo"), ast.NewNumber(42)},
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(tt, types.NewNumber(nil), nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewCaseWithoutDefault(
+					ast.NewVariable("y"),
+					types.NewUnknown(nil),
+					[]ast.Alternative{
+						ast.NewAlternative(
+							ast.NewTuple(
+								types.NewUnknown(nil),
+								[]ast.Expression{ast.NewVariable("z"), ast.NewVariable("v")},
+							),
+							ast.NewVariable("v"),
+						),
+					},
+				),
+			),
+		),
+	} {
+		_, err := tinfer.InferTypes(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
+
+		assert.Error(t, err)
+	}
+}
+
+func TestInferTypesWithPolymorphicBinds(t *testing.T) {
+	a := types.NewParameter("a", nil)
+	n := types.NewNumber(nil)
+	s := types.NewString(nil)
+
+	m, err := tinfer.InferTypes(
 		ast.NewModule(
 			"",
 			ast.NewExport(),
-			[]ast.Import{ast.NewImport("foo/bar")},
-			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("bar.x"))},
-		),
-		[]metadata.Module{
-			metadata.NewModule(
-				ast.NewModule(
-					"foo/bar",
-					ast.NewExport(),
-					nil,
-					[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"id",
+					types.NewFunction(a, a, nil),
+					ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
 				),
-			),
-		},
+				ast.NewBind(
+					"x",
+					n,
+					ast.NewApplication(ast.NewVariable("id"), []ast.Expression{ast.NewNumber(42)}),
+				),
+				ast.NewBind(
+					"y",
+					s,
+					ast.NewApplication(ast.NewVariable("id"), []ast.Expression{ast.NewString("foo")}),
+				),
+			},
+		),
+		nil,
 	)
 
-	assert.Error(t, err)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					n,
+					ast.NewApplication(
+						ast.NewVariable("id<(Number->Number)>"),
+						[]ast.Expression{ast.NewNumberWithType(42, n)},
+					),
+				),
+				ast.NewBind(
+					"y",
+					s,
+					ast.NewApplication(
+						ast.NewVariable("id<(String->String)>"),
+						[]ast.Expression{ast.NewString("foo")},
+					),
+				),
+				ast.NewBind(
+					"id<(Number->Number)>",
+					types.NewFunction(n, n, nil),
+					ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+				),
+				ast.NewBind(
+					"id<(String->String)>",
+					types.NewFunction(s, s, nil),
+					ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+				),
+			},
+		),
+		m,
+	)
+}
+
+func TestInferTypesWithExportedPolymorphicBinds(t *testing.T) {
+	m, err := tinfer.InferTypes(newPolymorphicModule(), nil)
+	assert.Nil(t, err)
+
+	a := types.NewParameter("a", nil)
+	n := types.NewNumber(nil)
+
+	assert.Equal(
+		t,
+		ast.NewModule(
+			"foo",
+			ast.NewExport("f"),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind("z", n, ast.NewNumberWithType(42, n)),
+				ast.NewBind(
+					"f",
+					types.NewFunction(a, a, nil),
+					ast.NewLambda(
+						[]string{"x"},
+						ast.NewApplication(ast.NewVariable("$foo.g"), []ast.Expression{ast.NewVariable("x")}),
+					),
+				),
+				ast.NewBind(
+					"g",
+					types.NewFunction(a, a, nil),
+					ast.NewLambda(
+						[]string{"z"},
+						ast.NewLet(
+							[]ast.Bind{ast.NewBind("y", types.NewUnknown(nil), ast.NewVariable("$toInt"))},
+							ast.NewVariable("z"),
+						),
+					),
+				),
+			},
+		),
+		m,
+	)
+}
+
+func TestInferTypesWithImportedPolymorphicBinds(t *testing.T) {
+	m, err := tinfer.InferTypes(newPolymorphicModule(), nil)
+	assert.Nil(t, err)
+
+	n := types.NewNumber(nil)
+	f := types.NewFunction(n, n, nil)
+
+	m, err = tinfer.InferTypes(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("foo", nil)},
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					n,
+					ast.NewApplication(ast.NewVariable("foo.f"), []ast.Expression{ast.NewNumber(42)}),
+				),
+			},
+		),
+		[]metadata.Module{newMetadataModule(m)},
+	)
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("foo", nil)},
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					n,
+					ast.NewApplication(
+						ast.NewVariable("$foo.f<(Number->Number)>"),
+						[]ast.Expression{ast.NewNumberWithType(42, n)},
+					),
+				),
+				ast.NewBind(
+					"$foo.f<(Number->Number)>",
+					f,
+					ast.NewLambda(
+						[]string{"x"},
+						ast.NewApplication(
+							ast.NewVariable("$foo.g<(Number->Number)>"),
+							[]ast.Expression{ast.NewVariable("x")},
+						),
+					),
+				),
+				ast.NewBind(
+					"$foo.g<(Number->Number)>",
+					f,
+					ast.NewLambda(
+						[]string{"z"},
+						ast.NewLet(
+							[]ast.Bind{
+								ast.NewBind("y", types.NewFunction(n, types.NewInt(nil), nil), ast.NewVariable("$toInt")),
+							},
+							ast.NewVariable("z"),
+						),
+					),
+				),
+			},
+		),
+		m,
+	)
+}
+
+func TestInferTypesWithLetPolymorphism(t *testing.T) {
+	for _, b := range []ast.Bind{
+		ast.NewBind(
+			"x",
+			types.NewNumber(nil),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewUnknown(nil),
+						ast.NewLambda([]string{"y"}, ast.NewVariable("y")),
+					),
+					ast.NewBind(
+						"s",
+						types.NewUnknown(nil),
+						ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewString("foo")}),
+					),
+				},
+				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewNumber(42)}),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+			ast.NewLambda(
+				[]string{"y"},
+				ast.NewLet(
+					[]ast.Bind{
+						ast.NewBind(
+							"f",
+							types.NewUnknown(nil),
+							ast.NewLambda([]string{"z"}, ast.NewVariable("y")),
+						),
+						ast.NewBind(
+							"s",
+							types.NewUnknown(nil),
+							ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewString("foo")}),
+						),
+					},
+					ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewNumber(42)}),
+				),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewNumber(nil),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"f",
+						types.NewUnknown(nil),
+						ast.NewLambda(
+							[]string{"y"},
+							ast.NewApplication(ast.NewVariable("g"), []ast.Expression{ast.NewVariable("y")}),
+						),
+					),
+					ast.NewBind(
+						"g",
+						types.NewUnknown(nil),
+						ast.NewLambda([]string{"y"}, ast.NewVariable("y")),
+					),
+					ast.NewBind(
+						"s",
+						types.NewUnknown(nil),
+						ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewString("foo")}),
+					),
+				},
+				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewNumber(42)}),
+			),
+		),
+		ast.NewBind(
+			"x",
+			types.NewNumber(nil),
+			ast.NewLet(
+				[]ast.Bind{
+					ast.NewBind(
+						"g",
+						types.NewUnknown(nil),
+						ast.NewLet(
+							[]ast.Bind{
+								ast.NewBind(
+									"f",
+									types.NewUnknown(nil),
+									ast.NewLambda(
+										[]string{"y"},
+										ast.NewLet(
+											[]ast.Bind{ast.NewBind("z", types.NewUnknown(nil), ast.NewNumber(42))},
+											ast.NewVariable("z"),
+										),
+									),
+								),
+							},
+							ast.NewVariable("f"),
+						),
+					),
+				},
+				ast.NewApplication(ast.NewVariable("g"), []ast.Expression{ast.NewString("foo")}),
+			),
+		),
+	} {
+		_, err := tinfer.InferTypes(ast.NewModule("", ast.NewExport(), nil, nil, []ast.Bind{b}), nil)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestInferTypesErrorWithPolymorphicBinds(t *testing.T) {
+	a := types.NewParameter("a", nil)
+
+	for _, m := range []ast.Module{
+		// Rigid type parameters
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					types.NewFunction(a, a, nil),
+					ast.NewLambda([]string{"x"}, ast.NewNumber(42)),
+				),
+			},
+		),
+		// Polymorphic recursion
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					types.NewFunction(a, types.NewNumber(nil), nil),
+					ast.NewLambda(
+						[]string{"x"},
+						ast.NewApplication(
+							ast.NewVariable("f"),
+							[]ast.Expression{
+								ast.NewList(
+									types.NewUnknown(nil),
+									[]ast.ListArgument{ast.NewListArgument(ast.NewVariable("x"), false)},
+								),
+							},
+						),
+					),
+				),
+				ast.NewBind(
+					"x",
+					types.NewNumber(nil),
+					ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewNumber(42)}),
+				),
+			},
+		),
+	} {
+		_, err := tinfer.InferTypes(m, nil)
+
+		assert.Error(t, err)
+	}
+}
+
+func TestInferTypesWithUntypedBinds(t *testing.T) {
+	n := types.NewNumber(nil)
+	f := ast.NewLambda(
+		[]string{"y"},
+		ast.NewApplication(ast.NewVariable("g"), []ast.Expression{ast.NewVariable("y")}),
+	)
+	g := ast.NewLambda(
+		[]string{"y"},
+		ast.NewBinaryOperation(
+			ast.Add,
+			ast.NewVariable("y"),
+			ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+		),
+	)
+
+	m, err := tinfer.InferTypes(
+		ast.NewModule(
+			"",
+			ast.NewExport("f"),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind("f", types.NewUnknown(nil), f),
+				ast.NewBind("g", types.NewUnknown(nil), g),
+				ast.NewBind("x", types.NewUnknown(nil), ast.NewNumber(42)),
+			},
+		),
+		nil,
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		ast.NewModule(
+			"",
+			ast.NewExport("f"),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind("f", types.NewFunction(n, n, nil), f),
+				ast.NewBind("g", types.NewFunction(n, n, nil), g),
+				ast.NewBind("x", n, ast.NewNumberWithType(42, n)),
+			},
+		),
+		m,
+	)
+}
+
+func TestInferTypesErrorWithUnknownVarabiles(t *testing.T) {
+	_, err := tinfer.InferTypes(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					types.NewNumber(nil),
+					ast.NewLet(
+						[]ast.Bind{
+							ast.NewBind("y", types.NewUnknown(nil), ast.NewVariable("z")),
+						},
+						ast.NewNumber(42),
+					),
+				),
+			},
+		),
+		nil,
+	)
+
+	assert.Error(t, err)
+}
+
+func TestInferTypesErrorWithUnresolvedTypes(t *testing.T) {
+	i := debug.NewInformation("", 1, 1, "")
+
+	_, err := tinfer.InferTypes(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					types.NewFunction(types.NewReference("Foo", i), types.NewNumber(nil), nil),
+					ast.NewLambda([]string{"y"}, ast.NewNumber(42)),
+				),
+			},
+		),
+		nil,
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, i, err.(debug.Error).DebugInformation())
+}
+
+func TestInferTypesWithImportedModules(t *testing.T) {
+	_, err := tinfer.InferTypes(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("foo/bar", nil)},
+			nil,
+			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("bar.x"))},
+		),
+		[]metadata.Module{
+			newMetadataModule(
+				ast.NewModule(
+					"foo/bar",
+					ast.NewExport("x"),
+					nil,
+					nil,
+					[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+				),
+			),
+		},
+	)
+
+	assert.Nil(t, err)
+}
+
+func TestInferTypesWithUnboxedTypesImportedModules(t *testing.T) {
+	_, err := tinfer.InferTypes(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("foo/bar", nil)},
+			nil,
+			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("bar.x"))},
+		),
+		[]metadata.Module{
+			newMetadataModule(
+				ast.NewModule(
+					"foo/bar",
+					ast.NewExport("x"),
+					nil,
+					nil,
+					[]ast.Bind{
+						ast.NewBind(
+							"x",
+							types.NewUnboxed(types.NewNumber(nil), nil),
+							ast.NewUnboxed(ast.NewNumber(42)),
+						),
+					},
+				),
+			),
+		},
+	)
+
+	assert.Nil(t, err)
+}
+
+func TestInferTypesErrorWithImportedModules(t *testing.T) {
+	_, err := tinfer.InferTypes(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("foo/bar", nil)},
+			nil,
+			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewVariable("bar.x"))},
+		),
+		[]metadata.Module{
+			newMetadataModule(
+				ast.NewModule(
+					"foo/bar",
+					ast.NewExport(),
+					nil,
+					nil,
+					[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+				),
+			),
+		},
+	)
+
+	assert.Error(t, err)
+}
+
+func TestInferTypesWithAliasedAndSelectiveImports(t *testing.T) {
+	ms := []metadata.Module{
+		newMetadataModule(
+			ast.NewModule(
+				"a/util",
+				ast.NewExport("x"),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("x", types.NewNumber(nil), ast.NewNumber(42))},
+			),
+		),
+		newMetadataModule(
+			ast.NewModule(
+				"b/util",
+				ast.NewExport("x"),
+				nil,
+				nil,
+				[]ast.Bind{ast.NewBind("x", types.NewString(nil), ast.NewString("foo"))},
+			),
+		),
+	}
+
+	for _, c := range []struct {
+		imports    []ast.Import
+		expression ast.Expression
+	}{
+		{
+			[]ast.Import{
+				ast.NewImport("a/util", nil),
+				ast.NewAliasedImport("b/util", "butil", nil),
+			},
+			ast.NewVariable("util.x"),
+		},
+		{
+			[]ast.Import{
+				ast.NewAliasedImport("a/util", "autil", nil),
+				ast.NewImport("b/util", nil),
+			},
+			ast.NewVariable("autil.x"),
+		},
+		{
+			[]ast.Import{
+				ast.NewSelectiveImport("a/util", []string{"x"}, nil),
+				ast.NewImport("b/util", nil),
+			},
+			ast.NewVariable("x"),
+		},
+	} {
+		_, err := tinfer.InferTypes(
+			ast.NewModule(
+				"",
+				ast.NewExport(),
+				c.imports,
+				nil,
+				[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), c.expression)},
+			),
+			ms,
+		)
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestInferTypesErrorWithDuplicateImportQualifiers(t *testing.T) {
+	_, err := tinfer.InferTypes(
+		ast.NewModule(
+			"",
+			ast.NewExport(),
+			[]ast.Import{ast.NewImport("a/util", nil), ast.NewImport("b/util", nil)},
+			nil,
+			[]ast.Bind{ast.NewBind("y", types.NewNumber(nil), ast.NewNumber(42))},
+		),
+		[]metadata.Module{
+			newMetadataModule(ast.NewModule("a/util", ast.NewExport(), nil, nil, nil)),
+			newMetadataModule(ast.NewModule("b/util", ast.NewExport(), nil, nil, nil)),
+		},
+	)
+
+	assert.Error(t, err)
+}
+
+func newMetadataModule(m ast.Module) metadata.Module {
+	mm, err := metadata.NewModule(m, nil)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return mm
+}
+
+// newPolymorphicModule creates a module exporting a polymorphic bind which
+// depends on another polymorphic bind, a built-in function and variables
+// shadowing global ones.
+func newPolymorphicModule() ast.Module {
+	a := types.NewParameter("a", nil)
+
+	return ast.NewModule(
+		"foo",
+		ast.NewExport("f"),
+		nil,
+		nil,
+		[]ast.Bind{
+			ast.NewBind(
+				"f",
+				types.NewFunction(a, a, nil),
+				ast.NewLambda(
+					[]string{"x"},
+					ast.NewApplication(ast.NewVariable("g"), []ast.Expression{ast.NewVariable("x")}),
+				),
+			),
+			ast.NewBind(
+				"g",
+				types.NewFunction(a, a, nil),
+				ast.NewLambda(
+					[]string{"z"},
+					ast.NewLet(
+						[]ast.Bind{ast.NewBind("y", types.NewUnknown(nil), ast.NewVariable("toInt"))},
+						ast.NewVariable("z"),
+					),
+				),
+			),
+			ast.NewBind("z", types.NewNumber(nil), ast.NewNumber(42)),
+		},
+	)
 }
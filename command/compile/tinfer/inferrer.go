@@ -9,22 +9,100 @@ import (
 )
 
 type inferrer struct {
-	variables         map[string]types.Type
+	variables         map[string]typeScheme
 	typeVariableCount *int
+	fieldConstraints  *[]fieldConstraint
+	numberConstraints *[]types.Type
 }
 
-func newInferrer(m ast.Module, ms []metadata.Module) inferrer {
-	vs := map[string]types.Type{}
+func newInferrer(m ast.Module, is map[string]metadata.ImportedBind) inferrer {
+	vs := ast.BuiltinTypes()
 
-	for _, m := range ms {
-		for n, t := range m.ExportedBinds() {
-			vs[m.Name().Qualify(n)] = t
-		}
+	for s, b := range is {
+		vs[s] = b.Type()
 	}
 
 	c := 0
+	fs := []fieldConstraint{}
+	ns := []types.Type{}
+
+	bs := make([]ast.Bind, 0, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		if !isUntypedBind(b) {
+			bs = append(bs, b)
+		}
+	}
+
+	return inferrer{map[string]typeScheme{}, &c, &fs, &ns}.addVariables(vs).addVariablesFromTopLevelBinds(bs)
+}
+
+// InferTopLevelTypes infers types of top-level binds without type signatures
+// in order of their dependencies.
+func (i inferrer) InferTopLevelTypes(m ast.Module) (ast.Module, error) {
+	ts := map[string]types.Type{}
+
+	for _, bs := range sortBindsByDependencies(i.insertTypeVariables(m).Binds()) {
+		vs := map[string]types.Type{}
+
+		for _, b := range bs {
+			if isUntypedBind(b) {
+				vs[b.Name()] = i.createTypeVariable()
+			}
+		}
+
+		if len(vs) == 0 {
+			continue
+		}
+
+		ii := i.addVariables(vs)
+		es := []types.Equation{}
+		*i.fieldConstraints = nil
+		*i.numberConstraints = nil
+
+		for _, b := range bs {
+			if _, ok := vs[b.Name()]; !ok {
+				continue
+			}
+
+			t, ees, err := ii.inferExpression(b.Expression())
+
+			if err != nil {
+				return ast.Module{}, err
+			}
+
+			es = append(append(es, ees...), types.NewEquation(vs[b.Name()], t))
+		}
+
+		ss, err := i.solve(es)
+
+		if err != nil {
+			return ast.Module{}, err
+		}
+
+		bbs := make([]ast.Bind, 0, len(vs))
+
+		for _, b := range bs {
+			if v, ok := vs[b.Name()]; ok {
+				ts[b.Name()] = parameterizeTypeVariables(i.substituteVariable(v, ss))
+				bbs = append(bbs, ast.NewBind(b.Name(), ts[b.Name()], b.Expression()))
+			}
+		}
+
+		i = i.addVariablesFromTopLevelBinds(bbs)
+	}
 
-	return inferrer{map[string]types.Type{}, &c}.addVariables(vs).addVariablesFromBinds(m.Binds())
+	bs := make([]ast.Bind, 0, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		if t, ok := ts[b.Name()]; ok {
+			b = ast.NewBind(b.Name(), t, b.Expression())
+		}
+
+		bs = append(bs, b)
+	}
+
+	return ast.NewModule(m.Name(), m.Export(), m.Imports(), m.TypeDefinitions(), bs), nil
 }
 
 func (i inferrer) Infer(m ast.Module) (ast.Module, error) {
@@ -32,6 +110,8 @@ func (i inferrer) Infer(m ast.Module) (ast.Module, error) {
 	ss := map[int]types.Type{}
 
 	for _, b := range m.Binds() {
+		*i.fieldConstraints = nil
+		*i.numberConstraints = nil
 		t, es, err := i.inferExpression(b.Expression())
 
 		if err != nil {
@@ -44,7 +124,7 @@ func (i inferrer) Infer(m ast.Module) (ast.Module, error) {
 			return ast.Module{}, err
 		}
 
-		sss, err := i.createSubstitutions(append(es, ees...))
+		sss, err := i.solve(append(es, ees...))
 
 		if err != nil {
 			return ast.Module{}, err
@@ -64,8 +144,22 @@ func (i inferrer) inferExpression(e ast.Expression) (types.Type, []types.Equatio
 		return i.inferApplication(e)
 	case ast.BinaryOperation:
 		return i.inferBinaryOperation(e)
+	case ast.Boolean:
+		return types.NewBoolean(nil), nil, nil
 	case ast.Case:
 		return i.inferCase(e)
+	case ast.ConstructorApplication:
+		return i.inferConstructorApplication(e)
+	case ast.ConstructorPattern:
+		_, t, err := i.inferConstructorPattern(e)
+		return t, nil, err
+	case ast.If:
+		return i.inferIf(e)
+	case ast.FieldAccess:
+		return i.inferFieldAccess(e)
+	case ast.ForeignFunction:
+		// Types of foreign functions are given by their type signatures.
+		return i.createTypeVariable(), nil, nil
 	case ast.Lambda:
 		return i.inferLambda(e)
 	case ast.Let:
@@ -73,7 +167,15 @@ func (i inferrer) inferExpression(e ast.Expression) (types.Type, []types.Equatio
 	case ast.List:
 		return i.inferList(e)
 	case ast.Number:
-		return types.NewNumber(nil), nil, nil
+		return i.inferNumber(e), nil, nil
+	case ast.Record:
+		return i.inferRecord(e)
+	case ast.Tuple:
+		return i.inferTuple(e)
+	case ast.RecordUpdate:
+		return i.inferRecordUpdate(e)
+	case ast.String:
+		return types.NewString(nil), nil, nil
 	case ast.Unboxed:
 		return i.inferUnboxed(e)
 	case ast.Variable:
@@ -127,6 +229,7 @@ func (i inferrer) inferApplication(a ast.Application) (types.Type, []types.Equat
 }
 
 func (i inferrer) inferBinaryOperation(o ast.BinaryOperation) (types.Type, []types.Equation, error) {
+	t := i.createTypeVariable()
 	es := []types.Equation{}
 
 	for _, e := range []ast.Expression{o.LHS(), o.RHS()} {
@@ -136,10 +239,103 @@ func (i inferrer) inferBinaryOperation(o ast.BinaryOperation) (types.Type, []typ
 			return nil, nil, err
 		}
 
-		es = append(append(es, ees...), types.NewEquation(l, types.NewNumber(nil)))
+		es = append(append(es, ees...), types.NewEquation(l, t))
+	}
+
+	i.addNumberConstraint(t)
+
+	if o.Operator().IsComparison() {
+		return types.NewBoolean(nil), es, nil
+	}
+
+	return t, es, nil
+}
+
+// inferNumber infers a type of a number literal. Integer literals can be
+// either of numbers or integers.
+func (i inferrer) inferNumber(n ast.Number) types.Type {
+	if _, ok := n.Type().(types.Variable); ok {
+		i.addNumberConstraint(n.Type())
+	}
+
+	return n.Type()
+}
+
+func (i inferrer) inferConstructorApplication(
+	a ast.ConstructorApplication,
+) (types.Type, []types.Equation, error) {
+	es := []types.Equation{}
+
+	for j, t := range a.Type().ConstructorElements(a.Index()) {
+		tt, ees, err := i.inferExpression(a.Arguments()[j])
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		es = append(append(es, ees...), types.NewEquation(tt, t))
 	}
 
-	return types.NewNumber(nil), es, nil
+	return a.Type(), es, nil
+}
+
+// inferConstructorPattern infers types of arguments and a result of a
+// constructor pattern.
+func (i inferrer) inferConstructorPattern(p ast.ConstructorPattern) ([]types.Type, types.Type, error) {
+	s, ok := i.variables[p.Constructor()]
+
+	if !ok {
+		return nil, nil, fmt.Errorf("constructor '%s' not found", p.Constructor())
+	}
+
+	t := s.typ
+
+	ts := make([]types.Type, 0, len(p.Arguments()))
+
+	for range p.Arguments() {
+		f, ok := t.(types.Function)
+
+		if !ok {
+			return nil, nil, fmt.Errorf("too many arguments to constructor '%s'", p.Constructor())
+		}
+
+		ts = append(ts, f.Argument())
+		t = f.Result()
+	}
+
+	if a, ok := t.(types.Algebraic); !ok {
+		return nil, nil, fmt.Errorf("too few arguments to constructor '%s'", p.Constructor())
+	} else if _, ok := a.ConstructorIndex(p.UnqualifiedConstructor()); !ok {
+		return nil, nil, fmt.Errorf("'%s' is not a constructor", p.Constructor())
+	}
+
+	return ts, t, nil
+}
+
+func (i inferrer) inferIf(ii ast.If) (types.Type, []types.Equation, error) {
+	t, es, err := i.inferExpression(ii.Condition())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	es = append(es, types.NewEquation(t, types.NewBoolean(nil)))
+
+	t, ees, err := i.inferExpression(ii.Then())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	es = append(es, ees...)
+
+	tt, ees, err := i.inferExpression(ii.Else())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return t, append(append(es, ees...), types.NewEquation(t, tt)), nil
 }
 
 func (i inferrer) inferCase(c ast.Case) (types.Type, []types.Equation, error) {
@@ -244,25 +440,40 @@ func (i inferrer) inferLambda(l ast.Lambda) (types.Type, []types.Equation, error
 	return t, es, nil
 }
 
+// inferLet infers types of binds in a let expression generalizing them in
+// order of their dependencies.
 func (i inferrer) inferLet(l ast.Let) (types.Type, []types.Equation, error) {
 	es := []types.Equation{}
-	i = i.addVariablesFromBinds(l.Binds())
 
-	for _, b := range l.Binds() {
-		t, ees, err := i.inferExpression(b.Expression())
+	for _, bs := range sortBindsByDependencies(l.Binds()) {
+		ii := i.addVariablesFromBinds(bs)
+		ees := []types.Equation{}
 
-		if err != nil {
-			return nil, nil, err
-		}
+		for _, b := range bs {
+			t, eees, err := ii.inferExpression(b.Expression())
 
-		es = append(es, ees...)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			ees = append(ees, eees...)
+
+			eees, err = b.Type().Unify(t)
 
-		ees, err = b.Type().Unify(t)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			ees = append(ees, eees...)
+		}
+
+		ss, cs, err := i.solveFieldConstraints(ees, *i.fieldConstraints)
 
 		if err != nil {
 			return nil, nil, err
 		}
 
+		i = i.addGeneralizedVariablesFromBinds(bs, ss, cs)
 		es = append(es, ees...)
 	}
 
@@ -310,6 +521,104 @@ func (i inferrer) inferList(l ast.List) (types.Type, []types.Equation, error) {
 	return t, es, nil
 }
 
+func (i inferrer) inferRecord(r ast.Record) (types.Type, []types.Equation, error) {
+	fs := make(map[string]types.Type, len(r.Fields()))
+	es := []types.Equation{}
+
+	for _, f := range r.Fields() {
+		t, ees, err := i.inferExpression(f.Expression())
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fs[f.Name()] = t
+		es = append(es, ees...)
+	}
+
+	t := types.NewRecord(fs, r.Type().DebugInformation())
+	ees, err := r.Type().Unify(t)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return t, append(es, ees...), nil
+}
+
+func (i inferrer) inferTuple(t ast.Tuple) (types.Type, []types.Equation, error) {
+	ts := make([]types.Type, 0, len(t.Elements()))
+	es := []types.Equation{}
+
+	for _, e := range t.Elements() {
+		t, ees, err := i.inferExpression(e)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ts = append(ts, t)
+		es = append(es, ees...)
+	}
+
+	tt := types.NewTuple(ts, t.Type().DebugInformation())
+	ees, err := t.Type().Unify(tt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tt, append(es, ees...), nil
+}
+
+func (i inferrer) inferRecordUpdate(u ast.RecordUpdate) (types.Type, []types.Equation, error) {
+	t, es, err := i.inferExpression(u.Record())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ees, err := u.Type().Unify(t)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	es = append(es, ees...)
+
+	for _, f := range u.Fields() {
+		tt, ees, err := i.inferExpression(f.Expression())
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		es = append(es, ees...)
+		i.addFieldConstraint(newFieldConstraint(t, f.Name(), tt))
+	}
+
+	return t, es, nil
+}
+
+func (i inferrer) inferFieldAccess(a ast.FieldAccess) (types.Type, []types.Equation, error) {
+	t, es, err := i.inferExpression(a.Record())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ees, err := a.Type().Unify(t)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tt := i.createTypeVariable()
+	i.addFieldConstraint(newFieldConstraint(t, a.Field(), tt))
+
+	return tt, append(es, ees...), nil
+}
+
 func (i inferrer) inferUnboxed(u ast.Unboxed) (types.Type, []types.Equation, error) {
 	t, es, err := i.inferExpression(u.Content())
 
@@ -321,13 +630,117 @@ func (i inferrer) inferUnboxed(u ast.Unboxed) (types.Type, []types.Equation, err
 }
 
 func (i inferrer) inferVariable(v ast.Variable) (types.Type, []types.Equation, error) {
-	t, ok := i.variables[v.Name()]
+	s, ok := i.variables[v.Name()]
 
 	if !ok {
 		return nil, nil, fmt.Errorf("variable '%s' not found", v.Name())
 	}
 
-	return t, nil, nil
+	t := i.instantiate(s)
+	es, err := v.Type().Unify(t)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return t, es, nil
+}
+
+func (i inferrer) instantiate(s typeScheme) types.Type {
+	t := s.typ
+
+	for _, v := range s.variables {
+		t = t.SubstituteVariable(v, i.createTypeVariable())
+	}
+
+	return t
+}
+
+// solve creates substitutions from equations resolving all field and number
+// constraints.
+func (i inferrer) solve(es []types.Equation) (map[int]types.Type, error) {
+	ss, cs, err := i.solveFieldConstraints(es, *i.fieldConstraints)
+
+	if err != nil {
+		return nil, err
+	} else if len(cs) != 0 {
+		return nil, fmt.Errorf("failed to infer a record type of field '%v'", cs[0].name)
+	}
+
+	return i.solveNumberConstraints(ss)
+}
+
+// solveNumberConstraints checks if types constrained to be numeric are numbers
+// or integers. Types left unresolved default to numbers.
+func (i inferrer) solveNumberConstraints(ss map[int]types.Type) (map[int]types.Type, error) {
+	for _, t := range *i.numberConstraints {
+		switch t := i.substituteVariable(t, ss).(type) {
+		case types.Variable:
+			n := types.NewNumber(t.DebugInformation())
+			ss = i.substituteVariablesInSubstitutions(ss, t, n)
+			ss[t.Identifier()] = n
+		case types.Number, types.Int:
+		default:
+			return nil, types.NewTypeError("not a number", t.DebugInformation())
+		}
+	}
+
+	return ss, nil
+}
+
+// solveFieldConstraints creates substitutions from equations resolving field
+// constraints whose record types are inferred. It returns the other field
+// constraints left unresolved.
+func (i inferrer) solveFieldConstraints(
+	es []types.Equation,
+	cs []fieldConstraint,
+) (map[int]types.Type, []fieldConstraint, error) {
+	for {
+		ss, err := i.createSubstitutions(es)
+
+		if err != nil {
+			return nil, nil, err
+		} else if len(cs) == 0 {
+			return ss, nil, nil
+		}
+
+		ccs := []fieldConstraint{}
+
+		for _, c := range cs {
+			t := c.record
+
+			if v, ok := t.(types.Variable); ok {
+				if tt, ok := ss[v.Identifier()]; ok {
+					t = tt
+				}
+			}
+
+			switch t := t.(type) {
+			case types.Variable:
+				ccs = append(ccs, c)
+				continue
+			case types.Record:
+				tt, ok := t.Fields()[c.name]
+
+				if !ok {
+					return nil, nil, types.NewTypeError(
+						fmt.Sprintf("field '%v' not found", c.name),
+						t.DebugInformation(),
+					)
+				}
+
+				es = append(es, types.NewEquation(tt, c.field))
+			default:
+				return nil, nil, types.NewTypeError("not a record", t.DebugInformation())
+			}
+		}
+
+		if len(ccs) == len(cs) {
+			return ss, cs, nil
+		}
+
+		cs = ccs
+	}
 }
 
 func (i inferrer) createSubstitutions(es []types.Equation) (map[int]types.Type, error) {
@@ -406,6 +819,12 @@ func (i inferrer) substituteVariablesInModule(m ast.Module, ss map[int]types.Typ
 							switch e := e.(type) {
 							case ast.List:
 								return ast.NewList(i.substituteVariable(e.Type(), ss), e.Arguments())
+							case ast.Number:
+								return ast.NewNumberWithType(e.Value(), i.substituteVariable(e.Type(), ss))
+							case ast.Tuple:
+								return ast.NewTuple(i.substituteVariable(e.Type(), ss), e.Elements())
+							case ast.Variable:
+								return ast.NewVariableWithType(e.Name(), i.substituteVariable(e.Type(), ss))
 							}
 
 							return e
@@ -436,14 +855,34 @@ func (i inferrer) substituteVariablesInModule(m ast.Module, ss map[int]types.Typ
 			return ast.NewLet(bs, e.Expression())
 		case ast.List:
 			return ast.NewList(i.substituteVariable(e.Type(), ss), e.Arguments())
+		case ast.Number:
+			return ast.NewNumberWithType(e.Value(), i.substituteVariable(e.Type(), ss))
+		case ast.Record:
+			return ast.NewRecord(i.substituteVariable(e.Type(), ss), e.Fields())
+		case ast.RecordUpdate:
+			return ast.NewRecordUpdate(i.substituteVariable(e.Type(), ss), e.Record(), e.Fields())
+		case ast.Tuple:
+			return ast.NewTuple(i.substituteVariable(e.Type(), ss), e.Elements())
+		case ast.FieldAccess:
+			return ast.NewFieldAccess(i.substituteVariable(e.Type(), ss), e.Record(), e.Field())
+		case ast.Variable:
+			return ast.NewVariableWithType(e.Name(), i.substituteVariable(e.Type(), ss))
 		}
 
 		return e
 	})
 }
 
+// substituteVariable substitutes type variables in a type. Type variables
+// left unsolved are kept as they are.
 func (inferrer) substituteVariable(t types.Type, ss map[int]types.Type) types.Type {
-	return ss[t.(types.Variable).Identifier()]
+	for _, v := range findTypeVariables(t) {
+		if tt, ok := ss[v.Identifier()]; ok {
+			t = t.SubstituteVariable(v, tt)
+		}
+	}
+
+	return t
 }
 
 func (i inferrer) addVariablesFromBinds(bs []ast.Bind) inferrer {
@@ -456,7 +895,99 @@ func (i inferrer) addVariablesFromBinds(bs []ast.Bind) inferrer {
 	return i.addVariables(m)
 }
 
+// addVariablesFromTopLevelBinds adds variables of top-level binds quantifying
+// type parameters in their types.
+func (i inferrer) addVariablesFromTopLevelBinds(bs []ast.Bind) inferrer {
+	m := make(map[string]typeScheme, len(bs))
+
+	for _, b := range bs {
+		ps := map[string]types.Variable{}
+		vs := []types.Variable{}
+
+		t := substituteParameters(types.Box(b.Type()), func(p types.Parameter) types.Type {
+			if _, ok := ps[p.Name()]; !ok {
+				ps[p.Name()] = i.createTypeVariable()
+				vs = append(vs, ps[p.Name()])
+			}
+
+			return ps[p.Name()]
+		})
+
+		m[b.Name()] = newTypeScheme(vs, t)
+	}
+
+	return i.addTypeSchemes(m)
+}
+
+// addGeneralizedVariablesFromBinds adds variables of binds quantifying type
+// variables which are free neither in an environment nor in unresolved field
+// constraints. Type variables constrained to be numeric are never quantified.
+func (i inferrer) addGeneralizedVariablesFromBinds(
+	bs []ast.Bind,
+	ss map[int]types.Type,
+	cs []fieldConstraint,
+) inferrer {
+	vs := []types.Variable{}
+
+	for _, s := range i.variables {
+		for _, v := range s.freeVariables() {
+			vs = append(vs, findTypeVariables(i.substituteVariable(v, ss))...)
+		}
+	}
+
+	for _, c := range cs {
+		for _, t := range []types.Type{c.record, c.field} {
+			vs = append(vs, findTypeVariables(i.substituteVariable(t, ss))...)
+		}
+	}
+
+	for _, t := range *i.numberConstraints {
+		vs = append(vs, findTypeVariables(i.substituteVariable(t, ss))...)
+	}
+
+	m := make(map[string]typeScheme, len(bs))
+
+	for _, b := range bs {
+		t := i.substituteVariable(types.Box(b.Type()), ss)
+		vvs := []types.Variable{}
+
+		for _, v := range findTypeVariables(t) {
+			if !containsTypeVariable(vs, v) {
+				vvs = append(vvs, v)
+			}
+		}
+
+		m[b.Name()] = newTypeScheme(vvs, t)
+	}
+
+	return i.addTypeSchemes(m)
+}
+
 func (i inferrer) addVariablesFromPattern(e ast.Expression) (inferrer, []types.Equation, error) {
+	if p, ok := e.(ast.ConstructorPattern); ok {
+		ts, _, err := i.inferConstructorPattern(p)
+
+		if err != nil {
+			return inferrer{}, nil, err
+		}
+
+		m := make(map[string]types.Type, len(ts))
+
+		for j, v := range p.Arguments() {
+			m[v.Name()] = ts[j]
+		}
+
+		return i.addVariables(m), nil, nil
+	} else if t, ok := e.(ast.Tuple); ok {
+		m := make(map[string]types.Type, len(t.Elements()))
+
+		for _, e := range t.Elements() {
+			m[e.(ast.Variable).Name()] = i.createTypeVariable()
+		}
+
+		return i.addVariables(m), nil, nil
+	}
+
 	l, ok := e.(ast.List)
 
 	if !ok {
@@ -495,17 +1026,35 @@ func (i inferrer) addVariablesFromPattern(e ast.Expression) (inferrer, []types.E
 }
 
 func (i inferrer) addVariables(vs map[string]types.Type) inferrer {
-	m := make(map[string]types.Type, len(i.variables)+len(vs))
+	m := make(map[string]typeScheme, len(vs))
 
-	for k, v := range i.variables {
-		m[k] = v
+	for k, v := range vs {
+		m[k] = newMonomorphicTypeScheme(v)
 	}
 
-	for k, v := range vs {
-		m[k] = v
+	return i.addTypeSchemes(m)
+}
+
+func (i inferrer) addTypeSchemes(ss map[string]typeScheme) inferrer {
+	m := make(map[string]typeScheme, len(i.variables)+len(ss))
+
+	for k, s := range i.variables {
+		m[k] = s
 	}
 
-	return inferrer{m, i.typeVariableCount}
+	for k, s := range ss {
+		m[k] = s
+	}
+
+	return inferrer{m, i.typeVariableCount, i.fieldConstraints, i.numberConstraints}
+}
+
+func (i inferrer) addFieldConstraint(c fieldConstraint) {
+	*i.fieldConstraints = append(*i.fieldConstraints, c)
+}
+
+func (i inferrer) addNumberConstraint(t types.Type) {
+	*i.numberConstraints = append(*i.numberConstraints, t)
 }
 
 func (i inferrer) createTypeVariable() types.Variable {
@@ -528,6 +1077,12 @@ func (i inferrer) insertTypeVariables(m ast.Module) ast.Module {
 							switch e := e.(type) {
 							case ast.List:
 								return ast.NewList(i.createTypeVariable(), e.Arguments())
+							case ast.Number:
+								return i.insertNumberTypeVariable(e)
+							case ast.Tuple:
+								return ast.NewTuple(i.createTypeVariable(), e.Elements())
+							case ast.Variable:
+								return ast.NewVariableWithType(e.Name(), i.createTypeVariable())
 							}
 
 							return e
@@ -548,14 +1103,43 @@ func (i inferrer) insertTypeVariables(m ast.Module) ast.Module {
 			bs := make([]ast.Bind, 0, len(e.Binds()))
 
 			for _, b := range e.Binds() {
-				bs = append(bs, ast.NewBind(b.Name(), i.createTypeVariable(), b.Expression()))
+				t := b.Type()
+
+				// Types of binds inferred or specialized already are kept.
+				if !isInferredType(t) {
+					t = i.createTypeVariable()
+				}
+
+				bs = append(bs, ast.NewBind(b.Name(), t, b.Expression()))
 			}
 
 			return ast.NewLet(bs, e.Expression())
 		case ast.List:
 			return ast.NewList(i.createTypeVariable(), e.Arguments())
+		case ast.Number:
+			return i.insertNumberTypeVariable(e)
+		case ast.Record:
+			return ast.NewRecord(i.createTypeVariable(), e.Fields())
+		case ast.RecordUpdate:
+			return ast.NewRecordUpdate(i.createTypeVariable(), e.Record(), e.Fields())
+		case ast.Tuple:
+			return ast.NewTuple(i.createTypeVariable(), e.Elements())
+		case ast.FieldAccess:
+			return ast.NewFieldAccess(i.createTypeVariable(), e.Record(), e.Field())
+		case ast.Variable:
+			return ast.NewVariableWithType(e.Name(), i.createTypeVariable())
 		}
 
 		return e
 	})
 }
+
+// insertNumberTypeVariable inserts a type variable into a number literal.
+// Types of fractional literals are kept as numbers.
+func (i inferrer) insertNumberTypeVariable(n ast.Number) ast.Number {
+	if _, ok := n.Type().(types.Number); ok {
+		return n
+	}
+
+	return ast.NewNumberWithType(n.Value(), i.createTypeVariable())
+}
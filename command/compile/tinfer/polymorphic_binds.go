@@ -0,0 +1,120 @@
+package tinfer
+
+import (
+	"sort"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/compile/metadata"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// importPolymorphicBinds adds imported polymorphic binds into a module by
+// their global names so that they are specialized like the module's own
+// ones. It returns the module and imported monomorphic binds.
+func importPolymorphicBinds(
+	m ast.Module,
+	is map[string]metadata.ImportedBind,
+) (ast.Module, map[string]metadata.ImportedBind) {
+	ss := make([]string, 0, len(is))
+
+	for s := range is {
+		ss = append(ss, s)
+	}
+
+	sort.Strings(ss)
+
+	bs := []ast.Bind{}
+	ns := map[string]string{}
+	iis := make(map[string]metadata.ImportedBind, len(is))
+
+	for _, s := range ss {
+		b := is[s]
+		e, ok := b.Expression()
+
+		if !ok {
+			iis[s] = b
+		} else if s == b.GlobalName() {
+			bs = append(bs, ast.NewBind(s, b.Type(), e))
+		} else {
+			ns[s] = b.GlobalName()
+		}
+	}
+
+	return renameGlobalVariables(m, ns).AddBinds(bs), iis
+}
+
+// exportPolymorphicBinds returns exported polymorphic binds and the other
+// polymorphic ones they depend on. Their global variables are renamed into
+// global names so that they can be imported into other modules.
+func exportPolymorphicBinds(m ast.Module, is map[string]metadata.ImportedBind) []ast.Bind {
+	ps := map[string]ast.Bind{}
+	ns := map[string]string{}
+
+	for s := range ast.BuiltinTypes() {
+		if _, ok := ast.BuiltinTypes()["$"+s]; ok {
+			ns[s] = "$" + s
+		}
+	}
+
+	for s, b := range is {
+		if _, ok := b.Expression(); !ok {
+			ns[s] = b.GlobalName()
+		}
+	}
+
+	for _, b := range m.Binds() {
+		// Imported polymorphic binds are exported by their original modules.
+		if _, ok := is[b.Name()]; ok {
+			continue
+		} else if types.IsPolymorphic(b.Type()) {
+			ps[b.Name()] = b
+		}
+
+		ns[b.Name()] = metadata.GlobalName(m.Name().FullyQualify(b.Name()))
+	}
+
+	ss := []string{}
+	ms := map[string]struct{}{}
+
+	for _, s := range m.Export().Names() {
+		if _, ok := ps[s]; ok {
+			ss = append(ss, s)
+			ms[s] = struct{}{}
+		}
+	}
+
+	bs := []ast.Bind{}
+
+	for ; len(ss) != 0; ss = ss[1:] {
+		b := ps[ss[0]]
+
+		b.Expression().ConvertExpressions(func(e ast.Expression) ast.Expression {
+			if v, ok := e.(ast.Variable); ok {
+				if _, ok := ps[v.Name()]; ok {
+					if _, ok := ms[v.Name()]; !ok {
+						ss = append(ss, v.Name())
+						ms[v.Name()] = struct{}{}
+					}
+				}
+			}
+
+			return e
+		})
+
+		bs = append(bs, b)
+	}
+
+	return renameGlobalVariables(ast.NewModule(m.Name(), m.Export(), nil, nil, bs), ns).Binds()
+}
+
+// renameGlobalVariables renames global variables in binds of a module.
+func renameGlobalVariables(m ast.Module, ns map[string]string) ast.Module {
+	r := newVariableRenamer(ns)
+	bs := make([]ast.Bind, 0, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		bs = append(bs, ast.NewBindWithComment(b.Name(), b.Type(), r.Rename(b.Expression()), b.Comment()))
+	}
+
+	return ast.NewModule(m.Name(), m.Export(), m.Imports(), m.TypeDefinitions(), bs)
+}
@@ -0,0 +1,100 @@
+package tinfer
+
+import "github.com/raviqqe/lazy-ein/command/ast"
+
+// sortBindsByDependencies groups binds into strongly connected components of
+// their dependency graph and sorts them so that each group comes after the
+// ones it depends on.
+func sortBindsByDependencies(bs []ast.Bind) [][]ast.Bind {
+	is := make(map[string]int, len(bs))
+
+	for i, b := range bs {
+		is[b.Name()] = i
+	}
+
+	ds := make([][]int, len(bs))
+
+	for i, b := range bs {
+		b.Expression().ConvertExpressions(func(e ast.Expression) ast.Expression {
+			if v, ok := e.(ast.Variable); ok {
+				if j, ok := is[v.Name()]; ok {
+					ds[i] = append(ds[i], j)
+				}
+			}
+
+			return e
+		})
+	}
+
+	s := bindSorter{ds, make([]int, len(bs)), make([]int, len(bs)), make([]bool, len(bs)), nil, 1, nil}
+
+	for i := range bs {
+		if s.indices[i] == 0 {
+			s.visit(i)
+		}
+	}
+
+	bss := make([][]ast.Bind, 0, len(s.components))
+
+	for _, is := range s.components {
+		bbs := make([]ast.Bind, 0, len(is))
+
+		for _, i := range is {
+			bbs = append(bbs, bs[i])
+		}
+
+		bss = append(bss, bbs)
+	}
+
+	return bss
+}
+
+// bindSorter finds strongly connected components with Tarjan's algorithm.
+type bindSorter struct {
+	dependencies [][]int
+	indices      []int
+	lowLinks     []int
+	onStack      []bool
+	stack        []int
+	index        int
+	components   [][]int
+}
+
+func (s *bindSorter) visit(i int) {
+	s.indices[i] = s.index
+	s.lowLinks[i] = s.index
+	s.index++
+	s.stack = append(s.stack, i)
+	s.onStack[i] = true
+
+	for _, j := range s.dependencies[i] {
+		if s.indices[j] == 0 {
+			s.visit(j)
+
+			if s.lowLinks[j] < s.lowLinks[i] {
+				s.lowLinks[i] = s.lowLinks[j]
+			}
+		} else if s.onStack[j] && s.indices[j] < s.lowLinks[i] {
+			s.lowLinks[i] = s.indices[j]
+		}
+	}
+
+	if s.lowLinks[i] != s.indices[i] {
+		return
+	}
+
+	is := []int{}
+
+	for {
+		j := s.stack[len(s.stack)-1]
+		s.stack = s.stack[:len(s.stack)-1]
+		s.onStack[j] = false
+		is = append([]int{j}, is...)
+
+		if j == i {
+			break
+		}
+	}
+
+	s.components = append(s.components, is)
+}
@@ -0,0 +1,447 @@
+package tinfer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+// specializer specializes polymorphic binds into monomorphic ones for each
+// concrete type at which they are used. Polymorphic binds are the top-level
+// ones with type parameters and the local ones with generalized types.
+type specializer struct {
+	variables map[string]func(ast.Variable) ast.Expression
+	changed   *bool
+}
+
+// specialize specializes a module once. It returns true if the module is
+// changed and needs to be inferred again.
+func specialize(m ast.Module) (ast.Module, bool) {
+	c := false
+	s := specializer{map[string]func(ast.Variable) ast.Expression{}, &c}
+
+	ps := map[string]ast.Bind{}
+	is := map[string]map[string]types.Type{}
+
+	for _, b := range m.Binds() {
+		if types.IsPolymorphic(b.Type()) {
+			ps[b.Name()] = b
+			is[b.Name()] = map[string]types.Type{}
+			s.variables[b.Name()] = s.instantiate(b.Name(), is[b.Name()], new(bool))
+		}
+	}
+
+	bs := make([]ast.Bind, 0, len(m.Binds()))
+	ns := make(map[string]struct{}, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		ns[b.Name()] = struct{}{}
+
+		if _, ok := ps[b.Name()]; !ok {
+			b = ast.NewBind(b.Name(), b.Type(), s.specializeExpression(b.Expression()))
+		}
+
+		bs = append(bs, b)
+	}
+
+	for _, b := range m.Binds() {
+		for _, n := range sortedInstanceNames(is[b.Name()]) {
+			if _, ok := ns[n]; !ok {
+				bs = append(bs, ast.NewBind(n, is[b.Name()][n], resetLetTypes(b.Expression())))
+			}
+		}
+	}
+
+	if c {
+		return ast.NewModule(m.Name(), m.Export(), m.Imports(), m.TypeDefinitions(), bs), true
+	}
+
+	bbs := make([]ast.Bind, 0, len(bs))
+
+	for _, b := range bs {
+		if _, ok := ps[b.Name()]; !ok {
+			bbs = append(bbs, b)
+		}
+	}
+
+	return ast.NewModule(m.Name(), m.Export(), m.Imports(), m.TypeDefinitions(), bbs), false
+}
+
+func (s specializer) specializeExpression(e ast.Expression) ast.Expression {
+	switch e := e.(type) {
+	case ast.Application:
+		as := make([]ast.Expression, 0, len(e.Arguments()))
+
+		for _, a := range e.Arguments() {
+			as = append(as, s.specializeExpression(a))
+		}
+
+		return ast.NewApplication(s.specializeExpression(e.Function()), as)
+	case ast.BinaryOperation:
+		return ast.NewBinaryOperation(
+			e.Operator(),
+			s.specializeExpression(e.LHS()),
+			s.specializeExpression(e.RHS()),
+		)
+	case ast.Case:
+		as := make([]ast.Alternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlternative(
+					a.Pattern(),
+					s.removeVariables(findPatternVariables(a.Pattern())...).specializeExpression(
+						a.Expression(),
+					),
+				),
+			)
+		}
+
+		d, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewCaseWithoutDefault(s.specializeExpression(e.Argument()), e.Type(), as)
+		}
+
+		return ast.NewCase(
+			s.specializeExpression(e.Argument()),
+			e.Type(),
+			as,
+			ast.NewDefaultAlternative(
+				d.Variable(),
+				s.removeVariables(d.Variable()).specializeExpression(d.Expression()),
+			),
+		)
+	case ast.ConstructorApplication:
+		as := make([]ast.Expression, 0, len(e.Arguments()))
+
+		for _, a := range e.Arguments() {
+			as = append(as, s.specializeExpression(a))
+		}
+
+		return ast.NewConstructorApplication(e.Type(), e.Index(), as)
+	case ast.FieldAccess:
+		return ast.NewFieldAccess(e.Type(), s.specializeExpression(e.Record()), e.Field())
+	case ast.If:
+		return ast.NewIf(
+			s.specializeExpression(e.Condition()),
+			s.specializeExpression(e.Then()),
+			s.specializeExpression(e.Else()),
+		)
+	case ast.Lambda:
+		return ast.NewLambda(
+			e.Arguments(),
+			s.removeVariables(e.Arguments()...).specializeExpression(e.Expression()),
+		)
+	case ast.Let:
+		return s.specializeLet(e)
+	case ast.List:
+		as := make([]ast.ListArgument, 0, len(e.Arguments()))
+
+		for _, a := range e.Arguments() {
+			as = append(as, ast.NewListArgument(s.specializeExpression(a.Expression()), a.Expanded()))
+		}
+
+		return ast.NewList(e.Type(), as)
+	case ast.Record:
+		return ast.NewRecord(e.Type(), s.specializeRecordFields(e.Fields()))
+	case ast.Tuple:
+		es := make([]ast.Expression, 0, len(e.Elements()))
+
+		for _, e := range e.Elements() {
+			es = append(es, s.specializeExpression(e))
+		}
+
+		return ast.NewTuple(e.Type(), es)
+	case ast.RecordUpdate:
+		return ast.NewRecordUpdate(
+			e.Type(),
+			s.specializeExpression(e.Record()),
+			s.specializeRecordFields(e.Fields()),
+		)
+	case ast.Variable:
+		if f, ok := s.variables[e.Name()]; ok {
+			return f(e)
+		}
+	}
+
+	return e
+}
+
+// specializeLet specializes a let expression creating monomorphic binds from
+// its polymorphic ones. Polymorphic binds are removed unless they are still
+// referred to from somewhere with non-concrete types.
+func (s specializer) specializeLet(l ast.Let) ast.Expression {
+	ns := make([]string, 0, len(l.Binds()))
+
+	for _, b := range l.Binds() {
+		ns = append(ns, b.Name())
+	}
+
+	s = s.removeVariables(ns...)
+	l, rs := restoreInstanceNames(l)
+
+	for _, n := range sortedKeys(rs) {
+		s = s.removeVariables(rs[n])
+	}
+
+	ss, sss := s, s
+
+	ps := []ast.Bind{}
+	is := map[string]map[string]types.Type{}
+	us := map[string]*bool{}
+
+	for _, b := range l.Binds() {
+		if isInferredType(b.Type()) {
+			continue
+		}
+
+		ps = append(ps, b)
+		is[b.Name()] = map[string]types.Type{}
+		us[b.Name()] = new(bool)
+		ss = ss.addVariable(b.Name(), s.instantiate(b.Name(), is[b.Name()], us[b.Name()]))
+		sss = sss.addVariable(b.Name(), s.instantiate(b.Name(), is[b.Name()], new(bool)))
+	}
+
+	// Instances restored into their original binds are specialized again
+	// because their types might be reset.
+	for _, n := range sortedKeys(rs) {
+		ss = ss.addVariable(n, ss.renameInstance(rs[n]))
+		sss = sss.addVariable(n, sss.renameInstance(rs[n]))
+	}
+
+	bs := make([]ast.Bind, 0, len(l.Binds()))
+	ms := map[string]struct{}{}
+
+	for _, b := range l.Binds() {
+		if _, ok := is[b.Name()]; !ok {
+			bs = append(bs, ast.NewBind(b.Name(), b.Type(), ss.specializeExpression(b.Expression())))
+			ms[b.Name()] = struct{}{}
+		}
+	}
+
+	e := ss.specializeExpression(l.Expression())
+
+	for c := true; c; {
+		c = false
+
+		for _, b := range ps {
+			for _, n := range sortedInstanceNames(is[b.Name()]) {
+				if _, ok := ms[n]; ok {
+					continue
+				}
+
+				bs = append(
+					bs,
+					ast.NewBind(
+						n,
+						is[b.Name()][n],
+						ss.addVariable(b.Name(), renameVariable(n)).specializeExpression(
+							resetLetTypes(b.Expression()),
+						),
+					),
+				)
+				ms[n] = struct{}{}
+				c = true
+			}
+		}
+	}
+
+	for _, b := range ps {
+		if *us[b.Name()] {
+			bs = append(bs, ast.NewBind(b.Name(), b.Type(), sss.specializeExpression(b.Expression())))
+		}
+	}
+
+	if len(bs) == 0 {
+		return e
+	}
+
+	return ast.NewLet(bs, e)
+}
+
+func (s specializer) specializeRecordFields(fs []ast.RecordField) []ast.RecordField {
+	ffs := make([]ast.RecordField, 0, len(fs))
+
+	for _, f := range fs {
+		ffs = append(ffs, ast.NewRecordField(f.Name(), s.specializeExpression(f.Expression())))
+	}
+
+	return ffs
+}
+
+// instantiate creates a function which renames variables of a polymorphic
+// bind with concrete types into ones of its instances. It marks the bind as
+// unresolved if some variables are not of concrete types yet.
+func (s specializer) instantiate(
+	n string,
+	is map[string]types.Type,
+	unresolved *bool,
+) func(ast.Variable) ast.Expression {
+	return func(v ast.Variable) ast.Expression {
+		if !isConcreteType(v.Type()) {
+			*unresolved = true
+			return v
+		}
+
+		nn := instanceName(n, v.Type())
+		is[nn] = v.Type()
+		*s.changed = true
+
+		return ast.NewVariableWithType(nn, v.Type())
+	}
+}
+
+// renameInstance creates a function which renames variables of an instance
+// into ones of its original bind and specializes them.
+func (s specializer) renameInstance(n string) func(ast.Variable) ast.Expression {
+	f, ok := s.variables[n]
+
+	return func(v ast.Variable) ast.Expression {
+		v = ast.NewVariableWithType(n, v.Type())
+
+		if !ok {
+			return v
+		}
+
+		return f(v)
+	}
+}
+
+func (s specializer) addVariable(n string, f func(ast.Variable) ast.Expression) specializer {
+	vs := make(map[string]func(ast.Variable) ast.Expression, len(s.variables)+1)
+
+	for k, v := range s.variables {
+		vs[k] = v
+	}
+
+	vs[n] = f
+
+	return specializer{vs, s.changed}
+}
+
+func (s specializer) removeVariables(ns ...string) specializer {
+	vs := make(map[string]func(ast.Variable) ast.Expression, len(s.variables))
+
+	for k, v := range s.variables {
+		vs[k] = v
+	}
+
+	for _, n := range ns {
+		delete(vs, n)
+	}
+
+	return specializer{vs, s.changed}
+}
+
+// restoreInstanceNames renames instances of let binds into their original
+// names if they are the only ones.
+func restoreInstanceNames(l ast.Let) (ast.Let, map[string]string) {
+	ns := map[string]int{}
+
+	for _, b := range l.Binds() {
+		ns[originalName(b.Name())]++
+	}
+
+	bs := make([]ast.Bind, 0, len(l.Binds()))
+	rs := map[string]string{}
+
+	for _, b := range l.Binds() {
+		if n := originalName(b.Name()); n != b.Name() && ns[n] == 1 {
+			rs[b.Name()] = n
+			b = ast.NewBind(n, b.Type(), b.Expression())
+		}
+
+		bs = append(bs, b)
+	}
+
+	return ast.NewLet(bs, l.Expression()), rs
+}
+
+func instanceName(n string, t types.Type) string {
+	return n + "<" + typeName(t) + ">"
+}
+
+func originalName(n string) string {
+	if i := strings.Index(n, "<"); i >= 0 {
+		return n[:i]
+	}
+
+	return n
+}
+
+func renameVariable(n string) func(ast.Variable) ast.Expression {
+	return func(v ast.Variable) ast.Expression {
+		return ast.NewVariableWithType(n, v.Type())
+	}
+}
+
+func findPatternVariables(e ast.Expression) []string {
+	if p, ok := e.(ast.ConstructorPattern); ok {
+		ns := make([]string, 0, len(p.Arguments()))
+
+		for _, v := range p.Arguments() {
+			ns = append(ns, v.Name())
+		}
+
+		return ns
+	}
+
+	ns := []string{}
+
+	e.ConvertExpressions(func(e ast.Expression) ast.Expression {
+		if v, ok := e.(ast.Variable); ok {
+			ns = append(ns, v.Name())
+		}
+
+		return e
+	})
+
+	return ns
+}
+
+// resetLetTypes resets types of let binds so that they are inferred again.
+func resetLetTypes(e ast.Expression) ast.Expression {
+	return e.ConvertExpressions(func(e ast.Expression) ast.Expression {
+		l, ok := e.(ast.Let)
+
+		if !ok {
+			return e
+		}
+
+		bs := make([]ast.Bind, 0, len(l.Binds()))
+
+		for _, b := range l.Binds() {
+			bs = append(bs, ast.NewBind(b.Name(), types.NewUnknown(nil), b.Expression()))
+		}
+
+		return ast.NewLet(bs, l.Expression())
+	}).(ast.Expression)
+}
+
+func sortedInstanceNames(is map[string]types.Type) []string {
+	ns := make([]string, 0, len(is))
+
+	for n := range is {
+		ns = append(ns, n)
+	}
+
+	sort.Strings(ns)
+
+	return ns
+}
+
+func sortedKeys(m map[string]string) []string {
+	ks := make([]string, 0, len(m))
+
+	for k := range m {
+		ks = append(ks, k)
+	}
+
+	sort.Strings(ks)
+
+	return ks
+}
@@ -0,0 +1,30 @@
+package tinfer
+
+import "github.com/raviqqe/lazy-ein/command/types"
+
+// typeScheme is a type quantified over type variables.
+type typeScheme struct {
+	variables []types.Variable
+	typ       types.Type
+}
+
+func newTypeScheme(vs []types.Variable, t types.Type) typeScheme {
+	return typeScheme{vs, t}
+}
+
+func newMonomorphicTypeScheme(t types.Type) typeScheme {
+	return typeScheme{nil, t}
+}
+
+// freeVariables returns type variables which are not quantified.
+func (s typeScheme) freeVariables() []types.Variable {
+	vs := []types.Variable{}
+
+	for _, v := range findTypeVariables(s.typ) {
+		if !containsTypeVariable(s.variables, v) {
+			vs = append(vs, v)
+		}
+	}
+
+	return vs
+}
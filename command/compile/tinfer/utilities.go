@@ -0,0 +1,159 @@
+package tinfer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+func findTypeVariables(t types.Type) []types.Variable {
+	vs := []types.Variable{}
+
+	if err := t.VisitTypes(func(t types.Type) error {
+		if v, ok := t.(types.Variable); ok && !containsTypeVariable(vs, v) {
+			vs = append(vs, v)
+		}
+
+		return nil
+	}); err != nil {
+		panic(err)
+	}
+
+	return vs
+}
+
+func containsTypeVariable(vs []types.Variable, v types.Variable) bool {
+	for _, vv := range vs {
+		if vv.Identifier() == v.Identifier() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// substituteParameters substitutes type parameters in a type.
+func substituteParameters(t types.Type, f func(types.Parameter) types.Type) types.Type {
+	switch t := t.(type) {
+	case types.Function:
+		return types.NewFunction(
+			substituteParameters(t.Argument(), f),
+			substituteParameters(t.Result(), f),
+			t.DebugInformation(),
+		)
+	case types.List:
+		return types.NewList(substituteParameters(t.Element(), f), t.DebugInformation())
+	case types.Parameter:
+		return f(t)
+	case types.Record:
+		fs := make(map[string]types.Type, len(t.Fields()))
+
+		for s, tt := range t.Fields() {
+			fs[s] = substituteParameters(tt, f)
+		}
+
+		return types.NewRecord(fs, t.DebugInformation())
+	case types.Tuple:
+		ts := make([]types.Type, 0, len(t.Elements()))
+
+		for _, tt := range t.Elements() {
+			ts = append(ts, substituteParameters(tt, f))
+		}
+
+		return types.NewTuple(ts, t.DebugInformation())
+	case types.Unboxed:
+		return types.NewUnboxed(substituteParameters(t.Content(), f), t.DebugInformation())
+	}
+
+	return t
+}
+
+func isUntypedBind(b ast.Bind) bool {
+	_, ok := types.Box(b.Type()).(types.Unknown)
+	return ok
+}
+
+// parameterizeTypeVariables converts type variables in a type into type
+// parameters.
+func parameterizeTypeVariables(t types.Type) types.Type {
+	for i, v := range findTypeVariables(t) {
+		n := "t" + strconv.Itoa(i)
+
+		if i < 26 {
+			n = string(rune('a' + i))
+		}
+
+		t = t.SubstituteVariable(v, types.NewParameter(n, nil))
+	}
+
+	return t
+}
+
+// isConcreteType checks if a type has neither type variables nor type
+// parameters.
+func isConcreteType(t types.Type) bool {
+	return t.VisitTypes(func(t types.Type) error {
+		switch t.(type) {
+		case types.Parameter, types.Unknown, types.Variable:
+			return fmt.Errorf("non-concrete type found")
+		}
+
+		return nil
+	}) == nil
+}
+
+// isInferredType checks if a type has neither type variables nor unknown
+// types.
+func isInferredType(t types.Type) bool {
+	return t.VisitTypes(func(t types.Type) error {
+		switch t.(type) {
+		case types.Unknown, types.Variable:
+			return fmt.Errorf("uninferred type found")
+		}
+
+		return nil
+	}) == nil
+}
+
+// typeName returns a unique name of a concrete type.
+func typeName(t types.Type) string {
+	switch t := t.(type) {
+	case types.Algebraic:
+		return t.Name()
+	case types.Boolean:
+		return "Bool"
+	case types.Function:
+		return "(" + typeName(t.Argument()) + "->" + typeName(t.Result()) + ")"
+	case types.Int:
+		return "Int"
+	case types.List:
+		return "[" + typeName(t.Element()) + "]"
+	case types.Number:
+		return "Number"
+	case types.Record:
+		ss := make([]string, 0, len(t.Fields()))
+
+		for _, s := range t.FieldNames() {
+			ss = append(ss, s+":"+typeName(t.Fields()[s]))
+		}
+
+		return "{" + strings.Join(ss, ",") + "}"
+	case types.String:
+		return "String"
+	case types.Tuple:
+		ss := make([]string, 0, len(t.Elements()))
+
+		for _, t := range t.Elements() {
+			ss = append(ss, typeName(t))
+		}
+
+		return "(" + strings.Join(ss, ",") + ")"
+	case types.Unboxed:
+		return "#" + typeName(t.Content())
+	}
+
+	panic("unreachable")
+}
@@ -0,0 +1,131 @@
+package tinfer
+
+import "github.com/raviqqe/lazy-ein/command/ast"
+
+// variableRenamer renames free variables in expressions.
+type variableRenamer struct {
+	names map[string]string
+}
+
+func newVariableRenamer(ns map[string]string) variableRenamer {
+	return variableRenamer{ns}
+}
+
+func (r variableRenamer) Rename(e ast.Expression) ast.Expression {
+	switch e := e.(type) {
+	case ast.Application:
+		as := make([]ast.Expression, 0, len(e.Arguments()))
+
+		for _, a := range e.Arguments() {
+			as = append(as, r.Rename(a))
+		}
+
+		return ast.NewApplication(r.Rename(e.Function()), as)
+	case ast.BinaryOperation:
+		return ast.NewBinaryOperation(e.Operator(), r.Rename(e.LHS()), r.Rename(e.RHS()))
+	case ast.Case:
+		as := make([]ast.Alternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlternative(
+					a.Pattern(),
+					r.removeNames(findPatternVariables(a.Pattern())...).Rename(a.Expression()),
+				),
+			)
+		}
+
+		d, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewCaseWithoutDefault(r.Rename(e.Argument()), e.Type(), as)
+		}
+
+		return ast.NewCase(
+			r.Rename(e.Argument()),
+			e.Type(),
+			as,
+			ast.NewDefaultAlternative(d.Variable(), r.removeNames(d.Variable()).Rename(d.Expression())),
+		)
+	case ast.ConstructorApplication:
+		as := make([]ast.Expression, 0, len(e.Arguments()))
+
+		for _, a := range e.Arguments() {
+			as = append(as, r.Rename(a))
+		}
+
+		return ast.NewConstructorApplication(e.Type(), e.Index(), as)
+	case ast.FieldAccess:
+		return ast.NewFieldAccess(e.Type(), r.Rename(e.Record()), e.Field())
+	case ast.If:
+		return ast.NewIf(r.Rename(e.Condition()), r.Rename(e.Then()), r.Rename(e.Else()))
+	case ast.Lambda:
+		return ast.NewLambda(e.Arguments(), r.removeNames(e.Arguments()...).Rename(e.Expression()))
+	case ast.Let:
+		ns := make([]string, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			ns = append(ns, b.Name())
+		}
+
+		r = r.removeNames(ns...)
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			bs = append(bs, ast.NewBind(b.Name(), b.Type(), r.Rename(b.Expression())))
+		}
+
+		return ast.NewLet(bs, r.Rename(e.Expression()))
+	case ast.List:
+		as := make([]ast.ListArgument, 0, len(e.Arguments()))
+
+		for _, a := range e.Arguments() {
+			as = append(as, ast.NewListArgument(r.Rename(a.Expression()), a.Expanded()))
+		}
+
+		return ast.NewList(e.Type(), as)
+	case ast.Record:
+		return ast.NewRecord(e.Type(), r.renameRecordFields(e.Fields()))
+	case ast.RecordUpdate:
+		return ast.NewRecordUpdate(e.Type(), r.Rename(e.Record()), r.renameRecordFields(e.Fields()))
+	case ast.Tuple:
+		es := make([]ast.Expression, 0, len(e.Elements()))
+
+		for _, e := range e.Elements() {
+			es = append(es, r.Rename(e))
+		}
+
+		return ast.NewTuple(e.Type(), es)
+	case ast.Variable:
+		if n, ok := r.names[e.Name()]; ok {
+			return ast.NewVariableWithType(n, e.Type())
+		}
+	}
+
+	return e
+}
+
+func (r variableRenamer) renameRecordFields(fs []ast.RecordField) []ast.RecordField {
+	ffs := make([]ast.RecordField, 0, len(fs))
+
+	for _, f := range fs {
+		ffs = append(ffs, ast.NewRecordField(f.Name(), r.Rename(f.Expression())))
+	}
+
+	return ffs
+}
+
+func (r variableRenamer) removeNames(ns ...string) variableRenamer {
+	m := make(map[string]string, len(r.names))
+
+	for k, v := range r.names {
+		m[k] = v
+	}
+
+	for _, n := range ns {
+		delete(m, n)
+	}
+
+	return variableRenamer{m}
+}
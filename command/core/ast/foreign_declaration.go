@@ -0,0 +1,36 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/core/types"
+
+// ForeignDeclaration is a declaration of a foreign function which takes and
+// returns unboxed values.
+type ForeignDeclaration struct {
+	name        string
+	foreignName string
+	typ         types.Function
+}
+
+// NewForeignDeclaration creates a foreign declaration.
+func NewForeignDeclaration(n, nn string, t types.Function) ForeignDeclaration {
+	return ForeignDeclaration{n, nn, t}
+}
+
+// Name returns a name.
+func (d ForeignDeclaration) Name() string {
+	return d.name
+}
+
+// ForeignName returns a name in a foreign language.
+func (d ForeignDeclaration) ForeignName() string {
+	return d.foreignName
+}
+
+// Type returns a type.
+func (d ForeignDeclaration) Type() types.Function {
+	return d.typ
+}
+
+// ConvertTypes converts types.
+func (d ForeignDeclaration) ConvertTypes(f func(types.Type) types.Type) ForeignDeclaration {
+	return ForeignDeclaration{d.name, d.foreignName, d.typ.ConvertTypes(f).(types.Function)}
+}
@@ -0,0 +1,41 @@
+package ast
+
+import "github.com/raviqqe/lazy-ein/command/core/types"
+
+// Int64 is an int64 literal.
+type Int64 struct {
+	value int64
+}
+
+// NewInt64 creates an int64 number.
+func NewInt64(i int64) Int64 {
+	return Int64{i}
+}
+
+// Value returns a value.
+func (i Int64) Value() int64 {
+	return i.value
+}
+
+// VisitExpressions visits expressions.
+func (i Int64) VisitExpressions(f func(Expression) error) error {
+	return f(i)
+}
+
+// ConvertTypes converts types.
+func (i Int64) ConvertTypes(func(types.Type) types.Type) Expression {
+	return i
+}
+
+// RenameVariables renames variables.
+func (i Int64) RenameVariables(map[string]string) Expression {
+	return i
+}
+
+// RenameVariablesInAtom renames variables.
+func (i Int64) RenameVariablesInAtom(map[string]string) Atom {
+	return i
+}
+
+func (Int64) isAtom()       {}
+func (Int64) isExpression() {}
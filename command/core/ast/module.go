@@ -4,13 +4,24 @@ import "github.com/raviqqe/lazy-ein/command/core/types"
 
 // Module is a module.
 type Module struct {
-	declarations []Declaration
-	binds        []Bind
+	foreignDeclarations []ForeignDeclaration
+	declarations        []Declaration
+	binds               []Bind
 }
 
 // NewModule creates a module.
 func NewModule(ds []Declaration, bs []Bind) Module {
-	return Module{ds, bs}
+	return Module{nil, ds, bs}
+}
+
+// NewModuleWithForeignDeclarations creates a module with foreign declarations.
+func NewModuleWithForeignDeclarations(fs []ForeignDeclaration, ds []Declaration, bs []Bind) Module {
+	return Module{fs, ds, bs}
+}
+
+// ForeignDeclarations returns foreign declarations.
+func (m Module) ForeignDeclarations() []ForeignDeclaration {
+	return m.foreignDeclarations
 }
 
 // Declarations returns declarations.
@@ -54,6 +65,12 @@ func (m Module) VisitExpressions(f func(Expression) error) error {
 
 // ConvertTypes converts types.
 func (m Module) ConvertTypes(f func(types.Type) types.Type) Module {
+	fs := make([]ForeignDeclaration, 0, len(m.foreignDeclarations))
+
+	for _, d := range m.foreignDeclarations {
+		fs = append(fs, d.ConvertTypes(f))
+	}
+
 	ds := make([]Declaration, 0, len(m.declarations))
 
 	for _, d := range m.declarations {
@@ -66,7 +83,7 @@ func (m Module) ConvertTypes(f func(types.Type) types.Type) Module {
 		bs = append(bs, b.ConvertTypes(f))
 	}
 
-	return Module{ds, bs}
+	return Module{fs, ds, bs}
 }
 
 // RenameVariables renames variables.
@@ -77,5 +94,5 @@ func (m Module) RenameVariables(vs map[string]string) Module {
 		bs = append(bs, b.RenameVariables(vs))
 	}
 
-	return NewModule(m.declarations, bs)
+	return Module{m.foreignDeclarations, m.declarations, bs}
 }
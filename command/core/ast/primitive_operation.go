@@ -14,6 +14,58 @@ const (
 	MultiplyFloat64 = "*"
 	// DivideFloat64 is a primitive operator.
 	DivideFloat64 = "/"
+	// EqualFloat64 is a primitive operator which returns 1 or 0.
+	EqualFloat64 = "=="
+	// NotEqualFloat64 is a primitive operator which returns 1 or 0.
+	NotEqualFloat64 = "/="
+	// LessThanFloat64 is a primitive operator which returns 1 or 0.
+	LessThanFloat64 = "<"
+	// LessThanOrEqualFloat64 is a primitive operator which returns 1 or 0.
+	LessThanOrEqualFloat64 = "<="
+	// GreaterThanFloat64 is a primitive operator which returns 1 or 0.
+	GreaterThanFloat64 = ">"
+	// GreaterThanOrEqualFloat64 is a primitive operator which returns 1 or 0.
+	GreaterThanOrEqualFloat64 = ">="
+	// ModuloFloat64 is a primitive operator whose results have the same signs
+	// as dividends.
+	ModuloFloat64 = "%"
+	// PowerFloat64 is a primitive operator.
+	PowerFloat64 = "^"
+	// AddInt64 is a primitive operator.
+	AddInt64 = "+i"
+	// SubtractInt64 is a primitive operator.
+	SubtractInt64 = "-i"
+	// MultiplyInt64 is a primitive operator.
+	MultiplyInt64 = "*i"
+	// DivideInt64 is a primitive operator which rounds results toward negative
+	// infinity.
+	DivideInt64 = "div"
+	// ModuloInt64 is a primitive operator whose results have the same signs as
+	// divisors.
+	ModuloInt64 = "mod"
+	// RemainderInt64 is a primitive operator whose results have the same signs
+	// as dividends.
+	RemainderInt64 = "rem"
+	// PowerInt64 is a primitive operator which returns 1 for negative
+	// exponents.
+	PowerInt64 = "^i"
+	// EqualInt64 is a primitive operator which returns 1 or 0.
+	EqualInt64 = "==i"
+	// NotEqualInt64 is a primitive operator which returns 1 or 0.
+	NotEqualInt64 = "/=i"
+	// LessThanInt64 is a primitive operator which returns 1 or 0.
+	LessThanInt64 = "<i"
+	// LessThanOrEqualInt64 is a primitive operator which returns 1 or 0.
+	LessThanOrEqualInt64 = "<=i"
+	// GreaterThanInt64 is a primitive operator which returns 1 or 0.
+	GreaterThanInt64 = ">i"
+	// GreaterThanOrEqualInt64 is a primitive operator which returns 1 or 0.
+	GreaterThanOrEqualInt64 = ">=i"
+	// Float64ToInt64 is a unary primitive operator which truncates a float64
+	// number.
+	Float64ToInt64 = "f64->i64"
+	// Int64ToFloat64 is a unary primitive operator.
+	Int64ToFloat64 = "i64->f64"
 )
 
 // PrimitiveOperation is a saturated primitive operation.
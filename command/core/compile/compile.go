@@ -3,6 +3,8 @@ package compile
 import (
 	"github.com/raviqqe/lazy-ein/command/core/ast"
 	"github.com/raviqqe/lazy-ein/command/core/compile/canonicalize"
+	"github.com/raviqqe/lazy-ein/command/core/compile/strictness"
+	"github.com/raviqqe/lazy-ein/command/core/optimize"
 	"github.com/raviqqe/lazy-ein/command/core/validate"
 	"github.com/llvm-mirror/llvm/bindings/go/llvm"
 )
@@ -13,5 +15,5 @@ func Compile(m ast.Module) (llvm.Module, error) {
 		return llvm.Module{}, err
 	}
 
-	return newModuleGenerator().Generate(canonicalize.Canonicalize(m))
+	return newModuleGenerator().Generate(canonicalize.Canonicalize(strictness.Optimize(optimize.Optimize(m))))
 }
@@ -35,6 +35,79 @@ func TestCompile(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestCompileWithStrictArguments(t *testing.T) {
+	a := types.NewAlgebraic(types.NewConstructor(types.NewFloat64()))
+	c := ast.NewConstructor(a, 0)
+
+	m, err := compile.Compile(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewBoxed(a))},
+						ast.NewAlgebraicCaseWithoutDefault(
+							ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+							[]ast.AlgebraicAlternative{
+								ast.NewAlgebraicAlternative(
+									c,
+									[]string{"y"},
+									ast.NewPrimitiveCase(
+										ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+										types.NewFloat64(),
+										[]ast.PrimitiveAlternative{
+											ast.NewPrimitiveAlternative(
+												ast.NewFloat64(0),
+												ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+											),
+										},
+										ast.NewDefaultAlternative(
+											"",
+											ast.NewLet(
+												[]ast.Bind{
+													ast.NewBind(
+														"z",
+														ast.NewVariableLambda(
+															[]ast.Argument{ast.NewArgument("y", types.NewFloat64())},
+															ast.NewPrimitiveCase(
+																ast.NewPrimitiveOperation(
+																	ast.SubtractFloat64,
+																	[]ast.Atom{ast.NewVariable("y"), ast.NewFloat64(1)},
+																),
+																types.NewFloat64(),
+																nil,
+																ast.NewDefaultAlternative(
+																	"w",
+																	ast.NewConstructorApplication(c, []ast.Atom{ast.NewVariable("w")}),
+																),
+															),
+															a,
+														),
+													),
+												},
+												ast.NewFunctionApplication(
+													ast.NewVariable("f"),
+													[]ast.Atom{ast.NewVariable("z")},
+												),
+											),
+										),
+									),
+								),
+							},
+						),
+						types.NewBoxed(a),
+					),
+				),
+			},
+		),
+	)
+
+	assert.NotEqual(t, llvm.Module{}, m)
+	assert.Nil(t, err)
+}
+
 // TODO: Re-enable this test when atomic operations are supported by Go bindings of LLVM.
 // func TestGlobalThunkForce(t *testing.T) {
 // 	a := types.NewAlgebraic(types.NewConstructor(types.NewFloat64()))
@@ -5,6 +5,8 @@ const (
 	blackHoleFunctionName = "core_black_hole"
 	panicFunctionName     = "core_panic"
 
+	powFunctionName = "llvm.pow.f64"
+
 	atomicLoadFunctionName    = "atomic.load"
 	atomicStoreFunctionName   = "atomic.store"
 	atomicCmpxchgFunctionName = "atomic.cmpxchg"
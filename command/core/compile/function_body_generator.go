@@ -37,6 +37,40 @@ func (g *functionBodyGenerator) Generate(e ast.Expression) (llvm.Value, error) {
 	return g.generateExpression(e)
 }
 
+// GenerateReturn generates an expression in a tail position and returns its
+// value from a function. Function applications in tail positions are
+// followed immediately by return instructions so that they are compiled into
+// tail calls. The tail callable calling convention guarantees that they are
+// never compiled into ordinary calls consuming stack frames.
+func (g *functionBodyGenerator) GenerateReturn(e ast.Expression) error {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		_, err := g.generateAlgebraicCase(e, true)
+		return err
+	case ast.PrimitiveCase:
+		_, err := g.generatePrimitiveCase(e, true)
+		return err
+	case ast.Let:
+		g, err := g.generateLetBinds(e)
+
+		if err != nil {
+			return err
+		}
+
+		return g.GenerateReturn(e.Expression())
+	}
+
+	v, err := g.generateExpression(e)
+
+	if err != nil {
+		return err
+	}
+
+	g.builder.CreateRet(v)
+
+	return nil
+}
+
 func (g *functionBodyGenerator) generateExpression(e ast.Expression) (llvm.Value, error) {
 	switch e := e.(type) {
 	case ast.FunctionApplication:
@@ -59,7 +93,6 @@ func (g *functionBodyGenerator) generateExpression(e ast.Expression) (llvm.Value
 func (g *functionBodyGenerator) generateFunctionApplication(
 	a ast.FunctionApplication,
 ) (llvm.Value, error) {
-	// TODO: Convert recursive function applications into thunks to allow tail-call elimination.
 	f, err := g.resolveName(a.Function().Name())
 
 	if err != nil {
@@ -93,15 +126,21 @@ func (g *functionBodyGenerator) generateFunctionApplication(
 func (g *functionBodyGenerator) generateCase(c ast.Case) (llvm.Value, error) {
 	switch c := c.(type) {
 	case ast.AlgebraicCase:
-		return g.generateAlgebraicCase(c)
+		return g.generateAlgebraicCase(c, false)
 	case ast.PrimitiveCase:
-		return g.generateFloatCase(c)
+		return g.generatePrimitiveCase(c, false)
 	}
 
 	panic("unreachable")
 }
 
-func (g *functionBodyGenerator) generateAlgebraicCase(c ast.AlgebraicCase) (llvm.Value, error) {
+// generateAlgebraicCase generates an algebraic case expression. If it is in a
+// tail position, its alternatives return their values directly instead of
+// joining them with a phi node.
+func (g *functionBodyGenerator) generateAlgebraicCase(
+	c ast.AlgebraicCase,
+	tail bool,
+) (llvm.Value, error) {
 	arg, err := g.generateExpression(c.Argument())
 
 	if err != nil {
@@ -115,7 +154,7 @@ func (g *functionBodyGenerator) generateAlgebraicCase(c ast.AlgebraicCase) (llvm
 		tag = g.builder.CreateExtractValue(arg, 0, "")
 	}
 
-	p := newPhiGenerator(llvm.AddBasicBlock(g.function(), "phi"))
+	p := g.createPhiGenerator(tail)
 	d := llvm.AddBasicBlock(g.function(), "default")
 	s := g.builder.CreateSwitch(tag, d, len(c.Alternatives()))
 
@@ -136,13 +175,9 @@ func (g *functionBodyGenerator) generateAlgebraicCase(c ast.AlgebraicCase) (llvm
 			vs[n] = g.builder.CreateExtractValue(es, i, "")
 		}
 
-		v, err := g.addVariables(vs).generateExpression(a.Expression())
-
-		if err != nil {
+		if err := g.addVariables(vs).generateAlternative(a.Expression(), p); err != nil {
 			return llvm.Value{}, err
 		}
-
-		p.CreateBr(g.builder, v)
 	}
 
 	// Pass down forced unboxed case arguments.
@@ -150,10 +185,13 @@ func (g *functionBodyGenerator) generateAlgebraicCase(c ast.AlgebraicCase) (llvm
 		return llvm.Value{}, err
 	}
 
-	return p.Generate(g.builder), nil
+	return g.generatePhi(p), nil
 }
 
-func (g *functionBodyGenerator) generateFloatCase(c ast.PrimitiveCase) (llvm.Value, error) {
+func (g *functionBodyGenerator) generatePrimitiveCase(
+	c ast.PrimitiveCase,
+	tail bool,
+) (llvm.Value, error) {
 	v, err := g.generateExpression(c.Argument())
 
 	if err != nil {
@@ -161,34 +199,27 @@ func (g *functionBodyGenerator) generateFloatCase(c ast.PrimitiveCase) (llvm.Val
 	}
 
 	b := g.builder.GetInsertBlock()
-	p := newPhiGenerator(llvm.AddBasicBlock(g.function(), "phi"))
+	p := g.createPhiGenerator(tail)
 
 	for i, a := range c.Alternatives() {
 		g.builder.SetInsertPointAtEnd(b)
 		b = llvm.AddBasicBlock(g.function(), fmt.Sprintf("else.%v", i))
 		bb := llvm.AddBasicBlock(g.function(), fmt.Sprintf("then.%v", i))
 
-		g.builder.CreateCondBr(
-			g.builder.CreateFCmp(llvm.FloatOEQ, v, g.generateLiteral(a.Literal()), ""),
-			bb,
-			b,
-		)
+		g.builder.CreateCondBr(g.generateLiteralEquality(v, a.Literal()), bb, b)
 
 		g.builder.SetInsertPointAtEnd(bb)
-		v, err := g.generateExpression(a.Expression())
 
-		if err != nil {
+		if err := g.generateAlternative(a.Expression(), p); err != nil {
 			return llvm.Value{}, err
 		}
-
-		p.CreateBr(g.builder, v)
 	}
 
 	if err = g.generateDefaultAlternative(c, v, b, p); err != nil {
 		return llvm.Value{}, err
 	}
 
-	return p.Generate(g.builder), nil
+	return g.generatePhi(p), nil
 }
 
 func (g *functionBodyGenerator) generateDefaultAlternative(
@@ -207,9 +238,17 @@ func (g *functionBodyGenerator) generateDefaultAlternative(
 		return nil
 	}
 
-	v, err := g.addVariables(
-		map[string]llvm.Value{a.Variable(): v},
-	).generateExpression(a.Expression())
+	return g.addVariables(map[string]llvm.Value{a.Variable(): v}).generateAlternative(a.Expression(), p)
+}
+
+// generateAlternative generates an expression of a case alternative. A nil phi
+// generator means that the case expression is in a tail position.
+func (g *functionBodyGenerator) generateAlternative(e ast.Expression, p *phiGenerator) error {
+	if p == nil {
+		return g.GenerateReturn(e)
+	}
+
+	v, err := g.generateExpression(e)
 
 	if err != nil {
 		return err
@@ -220,6 +259,22 @@ func (g *functionBodyGenerator) generateDefaultAlternative(
 	return nil
 }
 
+func (g *functionBodyGenerator) createPhiGenerator(tail bool) *phiGenerator {
+	if tail {
+		return nil
+	}
+
+	return newPhiGenerator(llvm.AddBasicBlock(g.function(), "phi"))
+}
+
+func (g *functionBodyGenerator) generatePhi(p *phiGenerator) llvm.Value {
+	if p == nil {
+		return llvm.Value{}
+	}
+
+	return p.Generate(g.builder)
+}
+
 func (g *functionBodyGenerator) generateConstructor(c ast.ConstructorApplication) (llvm.Value, error) {
 	vs, err := g.generateAtoms(c.Arguments())
 
@@ -239,6 +294,16 @@ func (g *functionBodyGenerator) generateConstructor(c ast.ConstructorApplication
 }
 
 func (g *functionBodyGenerator) generateLet(l ast.Let) (llvm.Value, error) {
+	g, err := g.generateLetBinds(l)
+
+	if err != nil {
+		return llvm.Value{}, err
+	}
+
+	return g.generateExpression(l.Expression())
+}
+
+func (g *functionBodyGenerator) generateLetBinds(l ast.Let) (*functionBodyGenerator, error) {
 	vs := make(map[string]llvm.Value, len(l.Binds()))
 
 	for _, b := range l.Binds() {
@@ -257,7 +322,7 @@ func (g *functionBodyGenerator) generateLet(l ast.Let) (llvm.Value, error) {
 		f, err := g.createLambda(g.nameGenerator.Generate(b.Name()), b.Lambda())
 
 		if err != nil {
-			return llvm.Value{}, err
+			return nil, err
 		}
 
 		p := vs[b.Name()]
@@ -274,20 +339,33 @@ func (g *functionBodyGenerator) generateLet(l ast.Let) (llvm.Value, error) {
 			v, err := g.resolveName(n)
 
 			if err != nil {
-				return llvm.Value{}, err
+				return nil, err
 			}
 
 			g.builder.CreateStore(v, g.builder.CreateStructGEP(e, i, ""))
 		}
 	}
 
-	return g.generateExpression(l.Expression())
+	return g, nil
 }
 
 func (g *functionBodyGenerator) generateLiteral(l ast.Literal) llvm.Value {
 	switch l := l.(type) {
 	case ast.Float64:
 		return llvm.ConstFloat(llvm.DoubleType(), l.Value())
+	case ast.Int64:
+		return llvm.ConstInt(llvm.Int64Type(), uint64(l.Value()), true)
+	}
+
+	panic("unreachable")
+}
+
+func (g *functionBodyGenerator) generateLiteralEquality(v llvm.Value, l ast.Literal) llvm.Value {
+	switch l.(type) {
+	case ast.Float64:
+		return g.builder.CreateFCmp(llvm.FloatOEQ, v, g.generateLiteral(l), "")
+	case ast.Int64:
+		return g.builder.CreateICmp(llvm.IntEQ, v, g.generateLiteral(l), "")
 	}
 
 	panic("unreachable")
@@ -298,7 +376,18 @@ func (g *functionBodyGenerator) generatePrimitiveOperation(o ast.PrimitiveOperat
 
 	if err != nil {
 		return llvm.Value{}, err
-	} else if len(vs) != 2 {
+	}
+
+	switch o.PrimitiveOperator() {
+	case ast.Float64ToInt64, ast.Int64ToFloat64:
+		if len(vs) != 1 {
+			return llvm.Value{}, errors.New("invalid number of arguments to a unary primitive operation")
+		}
+
+		return g.generateUnaryPrimitiveOperation(o.PrimitiveOperator(), vs[0]), nil
+	}
+
+	if len(vs) != 2 {
 		return llvm.Value{}, errors.New("invalid number of arguments to a binary primitive operation")
 	}
 
@@ -311,11 +400,155 @@ func (g *functionBodyGenerator) generatePrimitiveOperation(o ast.PrimitiveOperat
 		return g.builder.CreateFMul(vs[0], vs[1], ""), nil
 	case ast.DivideFloat64:
 		return g.builder.CreateFDiv(vs[0], vs[1], ""), nil
+	case ast.ModuloFloat64:
+		return g.builder.CreateFRem(vs[0], vs[1], ""), nil
+	case ast.PowerFloat64:
+		return g.builder.CreateCall(g.module().NamedFunction(powFunctionName), vs, ""), nil
+	case ast.EqualFloat64:
+		return g.generateComparison(llvm.FloatOEQ, vs[0], vs[1]), nil
+	case ast.NotEqualFloat64:
+		return g.generateComparison(llvm.FloatUNE, vs[0], vs[1]), nil
+	case ast.LessThanFloat64:
+		return g.generateComparison(llvm.FloatOLT, vs[0], vs[1]), nil
+	case ast.LessThanOrEqualFloat64:
+		return g.generateComparison(llvm.FloatOLE, vs[0], vs[1]), nil
+	case ast.GreaterThanFloat64:
+		return g.generateComparison(llvm.FloatOGT, vs[0], vs[1]), nil
+	case ast.GreaterThanOrEqualFloat64:
+		return g.generateComparison(llvm.FloatOGE, vs[0], vs[1]), nil
+	case ast.AddInt64:
+		return g.builder.CreateAdd(vs[0], vs[1], ""), nil
+	case ast.SubtractInt64:
+		return g.builder.CreateSub(vs[0], vs[1], ""), nil
+	case ast.MultiplyInt64:
+		return g.builder.CreateMul(vs[0], vs[1], ""), nil
+	case ast.DivideInt64:
+		return g.generateIntegerDivision(vs[0], vs[1]), nil
+	case ast.ModuloInt64:
+		return g.generateIntegerModulo(vs[0], vs[1]), nil
+	case ast.RemainderInt64:
+		return g.builder.CreateSRem(vs[0], vs[1], ""), nil
+	case ast.PowerInt64:
+		return g.generateIntegerPower(vs[0], vs[1]), nil
+	case ast.EqualInt64:
+		return g.generateIntegerComparison(llvm.IntEQ, vs[0], vs[1]), nil
+	case ast.NotEqualInt64:
+		return g.generateIntegerComparison(llvm.IntNE, vs[0], vs[1]), nil
+	case ast.LessThanInt64:
+		return g.generateIntegerComparison(llvm.IntSLT, vs[0], vs[1]), nil
+	case ast.LessThanOrEqualInt64:
+		return g.generateIntegerComparison(llvm.IntSLE, vs[0], vs[1]), nil
+	case ast.GreaterThanInt64:
+		return g.generateIntegerComparison(llvm.IntSGT, vs[0], vs[1]), nil
+	case ast.GreaterThanOrEqualInt64:
+		return g.generateIntegerComparison(llvm.IntSGE, vs[0], vs[1]), nil
+	}
+
+	panic("unreachable")
+}
+
+func (g *functionBodyGenerator) generateUnaryPrimitiveOperation(
+	o ast.PrimitiveOperator,
+	v llvm.Value,
+) llvm.Value {
+	switch o {
+	case ast.Float64ToInt64:
+		return g.builder.CreateFPToSI(v, llvm.Int64Type(), "")
+	case ast.Int64ToFloat64:
+		return g.builder.CreateSIToFP(v, llvm.DoubleType(), "")
 	}
 
 	panic("unreachable")
 }
 
+func (g *functionBodyGenerator) generateComparison(p llvm.FloatPredicate, x, y llvm.Value) llvm.Value {
+	return g.builder.CreateUIToFP(g.builder.CreateFCmp(p, x, y, ""), llvm.DoubleType(), "")
+}
+
+func (g *functionBodyGenerator) generateIntegerComparison(p llvm.IntPredicate, x, y llvm.Value) llvm.Value {
+	return g.builder.CreateZExt(g.builder.CreateICmp(p, x, y, ""), llvm.Int64Type(), "")
+}
+
+// generateIntegerDivision generates a division rounding a result toward
+// negative infinity.
+func (g *functionBodyGenerator) generateIntegerDivision(x, y llvm.Value) llvm.Value {
+	q := g.builder.CreateSDiv(x, y, "")
+
+	return g.builder.CreateSelect(
+		g.generateRemainderSignMismatch(g.builder.CreateSRem(x, y, ""), y),
+		g.builder.CreateSub(q, llvm.ConstInt(llvm.Int64Type(), 1, false), ""),
+		q,
+		"",
+	)
+}
+
+// generateIntegerModulo generates a modulo whose sign is the same as a
+// divisor's.
+func (g *functionBodyGenerator) generateIntegerModulo(x, y llvm.Value) llvm.Value {
+	r := g.builder.CreateSRem(x, y, "")
+
+	return g.builder.CreateSelect(
+		g.generateRemainderSignMismatch(r, y),
+		g.builder.CreateAdd(r, y, ""),
+		r,
+		"",
+	)
+}
+
+// generateIntegerPower generates an exponentiation by squaring.
+func (g *functionBodyGenerator) generateIntegerPower(x, y llvm.Value) llvm.Value {
+	b := g.builder.GetInsertBlock()
+	c := llvm.AddBasicBlock(g.function(), "power.condition")
+	bb := llvm.AddBasicBlock(g.function(), "power.body")
+	e := llvm.AddBasicBlock(g.function(), "power.end")
+
+	g.builder.CreateBr(c)
+	g.builder.SetInsertPointAtEnd(c)
+
+	r := g.builder.CreatePHI(llvm.Int64Type(), "")
+	xx := g.builder.CreatePHI(llvm.Int64Type(), "")
+	yy := g.builder.CreatePHI(llvm.Int64Type(), "")
+	z := llvm.ConstInt(llvm.Int64Type(), 0, false)
+	o := llvm.ConstInt(llvm.Int64Type(), 1, false)
+
+	g.builder.CreateCondBr(g.builder.CreateICmp(llvm.IntSGT, yy, z, ""), bb, e)
+	g.builder.SetInsertPointAtEnd(bb)
+
+	rr := g.builder.CreateSelect(
+		g.builder.CreateICmp(llvm.IntNE, g.builder.CreateAnd(yy, o, ""), z, ""),
+		g.builder.CreateMul(r, xx, ""),
+		r,
+		"",
+	)
+	xxx := g.builder.CreateMul(xx, xx, "")
+	yyy := g.builder.CreateAShr(yy, o, "")
+
+	g.builder.CreateBr(c)
+
+	r.AddIncoming([]llvm.Value{o, rr}, []llvm.BasicBlock{b, bb})
+	xx.AddIncoming([]llvm.Value{x, xxx}, []llvm.BasicBlock{b, bb})
+	yy.AddIncoming([]llvm.Value{y, yyy}, []llvm.BasicBlock{b, bb})
+
+	g.builder.SetInsertPointAtEnd(e)
+
+	return r
+}
+
+func (g *functionBodyGenerator) generateRemainderSignMismatch(r, y llvm.Value) llvm.Value {
+	z := llvm.ConstInt(llvm.Int64Type(), 0, false)
+
+	return g.builder.CreateAnd(
+		g.builder.CreateICmp(llvm.IntNE, r, z, ""),
+		g.builder.CreateICmp(
+			llvm.IntNE,
+			g.builder.CreateICmp(llvm.IntSLT, r, z, ""),
+			g.builder.CreateICmp(llvm.IntSLT, y, z, ""),
+			"",
+		),
+		"",
+	)
+}
+
 func (g *functionBodyGenerator) resolveName(s string) (llvm.Value, error) {
 	v, ok := g.variables[s]
 
@@ -328,8 +561,8 @@ func (g *functionBodyGenerator) resolveName(s string) (llvm.Value, error) {
 
 func (g *functionBodyGenerator) generateAtom(a ast.Atom) (llvm.Value, error) {
 	switch a := a.(type) {
-	case ast.Float64:
-		return llvm.ConstFloat(llvm.DoubleType(), a.Value()), nil
+	case ast.Literal:
+		return g.generateLiteral(a), nil
 	default:
 		return g.resolveName(a.(ast.Variable).Name())
 	}
@@ -0,0 +1,8 @@
+package llir
+
+import "github.com/llvm-mirror/llvm/bindings/go/llvm"
+
+// TailCallConv is the tail callable calling convention. It is the fast calling
+// convention with a guarantee that calls in tail positions are always compiled
+// into tail calls. The Go bindings of LLVM do not define its constant.
+const TailCallConv llvm.CallConv = 18
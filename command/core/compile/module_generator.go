@@ -44,6 +44,16 @@ func (g *moduleGenerator) initialize(m ast.Module) error {
 		llvm.FunctionType(llvm.VoidType(), nil, false),
 	)
 
+	llvm.AddFunction(
+		g.module,
+		powFunctionName,
+		llvm.FunctionType(
+			llvm.DoubleType(),
+			[]llvm.Type{llvm.DoubleType(), llvm.DoubleType()},
+			false,
+		),
+	)
+
 	llvm.AddFunction(
 		g.module,
 		atomicLoadFunctionName,
@@ -84,6 +94,10 @@ func (g *moduleGenerator) initialize(m ast.Module) error {
 		)
 	}
 
+	for _, d := range m.ForeignDeclarations() {
+		g.globalVariables[d.Name()] = g.createForeignFunction(d)
+	}
+
 	cg := newConstructorGenerator(g.module, g.typeGenerator)
 
 	for _, t := range m.Types() {
@@ -136,6 +150,47 @@ func (g *moduleGenerator) Generate(m ast.Module) (llvm.Module, error) {
 	return g.module, nil
 }
 
+// createForeignFunction creates a closure which calls a foreign function with
+// the C calling convention.
+func (g *moduleGenerator) createForeignFunction(d ast.ForeignDeclaration) llvm.Value {
+	f := g.module.NamedFunction(d.ForeignName())
+
+	if f.IsNil() {
+		f = llvm.AddFunction(
+			g.module,
+			d.ForeignName(),
+			llvm.FunctionType(
+				g.typeGenerator.Generate(d.Type().Result()),
+				g.typeGenerator.generateMany(d.Type().Arguments()),
+				false,
+			),
+		)
+	}
+
+	l := ast.NewLambdaDeclaration(nil, d.Type().Arguments(), d.Type().Result())
+	e := llir.AddFunction(
+		g.module,
+		names.ToEntry(d.Name()),
+		g.typeGenerator.GenerateLambdaEntryFunction(l),
+	)
+	e.SetLinkage(llvm.PrivateLinkage)
+
+	b := llvm.NewBuilder()
+	b.SetInsertPointAtEnd(llvm.AddBasicBlock(e, ""))
+	b.CreateRet(b.CreateCall(f, e.Params()[1:], ""))
+
+	v := llvm.AddGlobal(g.module, g.typeGenerator.GenerateSizedClosure(l), d.Name())
+	v.SetLinkage(llvm.PrivateLinkage)
+	v.SetInitializer(
+		llvm.ConstStruct(
+			[]llvm.Value{e, llvm.ConstNull(v.Type().ElementType().StructElementTypes()[1])},
+			false,
+		),
+	)
+
+	return v
+}
+
 func (g *moduleGenerator) createLambda(n string, l ast.Lambda) (llvm.Value, error) {
 	f := llir.AddFunction(
 		g.module,
@@ -162,20 +217,12 @@ func (g *moduleGenerator) createFunctionLambda(f llvm.Value, l ast.Lambda) error
 	b := llvm.NewBuilder()
 	b.SetInsertPointAtEnd(llvm.AddBasicBlock(f, ""))
 
-	v, err := newFunctionBodyGenerator(
+	return newFunctionBodyGenerator(
 		b,
 		g.createLogicalEnvironment(f, b, l),
 		g.createLambda,
 		g.typeGenerator,
-	).Generate(l.Body())
-
-	if err != nil {
-		return err
-	}
-
-	b.CreateRet(v)
-
-	return nil
+	).GenerateReturn(l.Body())
 }
 
 func (g *moduleGenerator) createVariableLambda(f llvm.Value, l ast.Lambda, n string) error {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/raviqqe/lazy-ein/command/core/compile/llir"
 	"github.com/raviqqe/lazy-ein/command/core/compile/names"
 	"github.com/raviqqe/lazy-ein/command/core/types"
 	"github.com/llvm-mirror/llvm/bindings/go/llvm"
@@ -167,6 +168,138 @@ func TestModuleGeneratorGenerate(t *testing.T) {
 				),
 			),
 		},
+		// Comparison primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewPrimitiveOperation(
+						ast.LessThanFloat64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewFloat64(42)},
+					),
+					types.NewFloat64(),
+				),
+			),
+		},
+		// Modulo primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewPrimitiveOperation(
+						ast.ModuloFloat64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewFloat64(42)},
+					),
+					types.NewFloat64(),
+				),
+			),
+		},
+		// Power primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewPrimitiveOperation(
+						ast.PowerFloat64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewFloat64(42)},
+					),
+					types.NewFloat64(),
+				),
+			),
+		},
+		// Integer power primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveOperation(
+						ast.PowerInt64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewInt64(42)},
+					),
+					types.NewInt64(),
+				),
+			),
+		},
+		// Integer primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveOperation(
+						ast.DivideInt64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewInt64(42)},
+					),
+					types.NewInt64(),
+				),
+			),
+		},
+		// Integer modulo primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveOperation(
+						ast.ModuloInt64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewInt64(42)},
+					),
+					types.NewInt64(),
+				),
+			),
+		},
+		// Integer remainder primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveOperation(
+						ast.RemainderInt64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewInt64(42)},
+					),
+					types.NewInt64(),
+				),
+			),
+		},
+		// Integer comparison primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveOperation(
+						ast.LessThanInt64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewInt64(42)},
+					),
+					types.NewInt64(),
+				),
+			),
+		},
+		// Conversion primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveOperation(ast.Int64ToFloat64, []ast.Atom{ast.NewVariable("x")}),
+					types.NewFloat64(),
+				),
+			),
+		},
 		// Let expressions
 		{
 			ast.NewBind(
@@ -357,6 +490,25 @@ func TestModuleGeneratorGenerate(t *testing.T) {
 				),
 			),
 		},
+		// Integer primitive case expressions
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveCase(
+						ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+						types.NewInt64(),
+						[]ast.PrimitiveAlternative{
+							ast.NewPrimitiveAlternative(ast.NewInt64(42), ast.NewInt64(0)),
+						},
+						ast.NewDefaultAlternative("y", ast.NewInt64(1)),
+					),
+					types.NewInt64(),
+				),
+			),
+		},
 		// Case expressions unboxing arguments
 		{
 			ast.NewBind(
@@ -622,3 +774,95 @@ func TestModuleGeneratorGenerateWithDeclarations(t *testing.T) {
 	_, err := newModuleGenerator().Generate(m)
 	assert.Nil(t, err)
 }
+
+func TestModuleGeneratorGenerateWithForeignDeclarations(t *testing.T) {
+	m := ast.NewModuleWithForeignDeclarations(
+		[]ast.ForeignDeclaration{
+			ast.NewForeignDeclaration(
+				"f",
+				"sin",
+				types.NewFunction([]types.Type{types.NewFloat64()}, types.NewFloat64()),
+			),
+		},
+		nil,
+		[]ast.Bind{
+			ast.NewBind(
+				"g",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewFunctionApplication(ast.NewVariable("f"), []ast.Atom{ast.NewVariable("x")}),
+					types.NewFloat64(),
+				),
+			),
+		},
+	)
+
+	mm, err := newModuleGenerator().Generate(m)
+	assert.Nil(t, err)
+	assert.False(t, mm.NamedFunction("sin").IsNil())
+	assert.Equal(t, llvm.CCallConv, mm.NamedFunction("sin").FunctionCallConv())
+}
+
+func TestModuleGeneratorGenerateWithTailCalls(t *testing.T) {
+	m := ast.NewModule(
+		nil,
+		[]ast.Bind{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewPrimitiveCase(
+						ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+						types.NewFloat64(),
+						[]ast.PrimitiveAlternative{
+							ast.NewPrimitiveAlternative(ast.NewFloat64(0), ast.NewFloat64(42)),
+						},
+						ast.NewDefaultAlternative(
+							"y",
+							ast.NewPrimitiveCase(
+								ast.NewPrimitiveOperation(
+									ast.SubtractFloat64,
+									[]ast.Atom{ast.NewVariable("y"), ast.NewFloat64(1)},
+								),
+								types.NewFloat64(),
+								nil,
+								ast.NewDefaultAlternative(
+									"z",
+									ast.NewFunctionApplication(
+										ast.NewVariable("f"),
+										[]ast.Atom{ast.NewVariable("z")},
+									),
+								),
+							),
+						),
+					),
+					types.NewFloat64(),
+				),
+			),
+		},
+	)
+
+	mm, err := newModuleGenerator().Generate(m)
+	assert.Nil(t, err)
+
+	f := mm.NamedFunction(names.ToEntry("f"))
+	n := 0
+
+	for b := f.FirstBasicBlock(); !b.IsNil(); b = llvm.NextBasicBlock(b) {
+		for i := b.FirstInstruction(); !i.IsNil(); i = llvm.NextInstruction(i) {
+			assert.NotEqual(t, llvm.PHI, i.InstructionOpcode())
+		}
+
+		if i := llvm.PrevInstruction(b.LastInstruction()); !i.IsNil() &&
+			i.InstructionOpcode() == llvm.Call && i.IsTailCall() {
+			assert.Equal(t, llir.TailCallConv, i.InstructionCallConv())
+			n++
+		}
+	}
+
+	assert.Equal(t, 1, n)
+	assert.Equal(t, llir.TailCallConv, f.FunctionCallConv())
+	assert.Regexp(t, `tail call tailcc .*\n\s*ret `, mm.String())
+}
@@ -0,0 +1,204 @@
+package strictness
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+// demandAnalyzer finds variables which are always forced when expressions are
+// evaluated. Arguments of saturated applications of global functions are
+// demanded if the functions are strict in them.
+type demandAnalyzer struct {
+	functions map[string][]bool
+}
+
+func newDemandAnalyzer(fs map[string][]bool) demandAnalyzer {
+	return demandAnalyzer{fs}
+}
+
+// findStrictArguments finds arguments of global functions which are always
+// demanded. Every function is assumed to be strict in all of its arguments at
+// first and the assumption is weakened until it holds for recursive functions.
+func findStrictArguments(bs []ast.Bind) map[string][]bool {
+	fs := make(map[string][]bool, len(bs))
+
+	for _, b := range bs {
+		if l := b.Lambda(); !l.IsThunk() {
+			fs[b.Name()] = make([]bool, len(l.ArgumentNames()))
+
+			for i := range fs[b.Name()] {
+				fs[b.Name()][i] = true
+			}
+		}
+	}
+
+	for {
+		changed := false
+
+		for _, b := range bs {
+			ss, ok := fs[b.Name()]
+
+			if !ok {
+				continue
+			}
+
+			l := b.Lambda()
+			vs := newDemandAnalyzer(fs).removeFunctions(l.ArgumentNames()...).Analyze(l.Body())
+
+			for i, s := range l.ArgumentNames() {
+				if _, ok := vs[s]; ss[i] && !ok {
+					ss[i] = false
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			return fs
+		}
+	}
+}
+
+// Analyze finds free variables which are always forced when an expression is
+// evaluated.
+func (a demandAnalyzer) Analyze(e ast.Expression) map[string]struct{} {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		vss := make([]map[string]struct{}, 0, len(e.Alternatives())+1)
+
+		for _, aa := range e.Alternatives() {
+			vss = append(
+				vss,
+				removeVariables(
+					a.removeFunctions(aa.ElementNames()...).Analyze(aa.Expression()),
+					aa.ElementNames()...,
+				),
+			)
+		}
+
+		if aa, ok := e.DefaultAlternative(); ok {
+			vss = append(
+				vss,
+				removeVariables(a.removeFunctions(aa.Variable()).Analyze(aa.Expression()), aa.Variable()),
+			)
+		}
+
+		return addVariables(a.Analyze(e.Argument()), intersectVariables(vss))
+	case ast.FunctionApplication:
+		if len(e.Arguments()) == 0 {
+			return map[string]struct{}{e.Function().Name(): {}}
+		}
+
+		return a.analyzeFunctionApplication(e)
+	case ast.Let:
+		return removeVariables(a.AnalyzeLet(e), bindsToNames(e.Binds())...)
+	case ast.PrimitiveCase:
+		vss := make([]map[string]struct{}, 0, len(e.Alternatives())+1)
+
+		for _, aa := range e.Alternatives() {
+			vss = append(vss, a.Analyze(aa.Expression()))
+		}
+
+		if aa, ok := e.DefaultAlternative(); ok {
+			vss = append(
+				vss,
+				removeVariables(a.removeFunctions(aa.Variable()).Analyze(aa.Expression()), aa.Variable()),
+			)
+		}
+
+		return addVariables(a.Analyze(e.Argument()), intersectVariables(vss))
+	}
+
+	return map[string]struct{}{}
+}
+
+// AnalyzeLet finds variables demanded by a body of a let expression including
+// ones bound by the let expression itself. Thunks bound by the let expression
+// and demanded by its body demand their free variables in turn.
+func (a demandAnalyzer) AnalyzeLet(l ast.Let) map[string]struct{} {
+	a = a.removeFunctions(bindsToNames(l.Binds())...)
+	vs := a.Analyze(l.Expression())
+	ss := map[string]struct{}{}
+
+	for {
+		n := len(ss)
+
+		for _, b := range l.Binds() {
+			if _, ok := vs[b.Name()]; !ok || !b.Lambda().IsThunk() {
+				continue
+			} else if _, ok := ss[b.Name()]; ok {
+				continue
+			}
+
+			ss[b.Name()] = struct{}{}
+			addVariables(vs, a.Analyze(b.Lambda().Body()))
+		}
+
+		if len(ss) == n {
+			return vs
+		}
+	}
+}
+
+func (a demandAnalyzer) analyzeFunctionApplication(e ast.FunctionApplication) map[string]struct{} {
+	vs := map[string]struct{}{}
+	ss, ok := a.functions[e.Function().Name()]
+
+	if !ok || len(ss) != len(e.Arguments()) {
+		return vs
+	}
+
+	for i, x := range e.Arguments() {
+		if v, ok := x.(ast.Variable); ok && ss[i] {
+			vs[v.Name()] = struct{}{}
+		}
+	}
+
+	return vs
+}
+
+// removeFunctions removes functions shadowed by local variables.
+func (a demandAnalyzer) removeFunctions(ss ...string) demandAnalyzer {
+	ok := false
+
+	for _, s := range ss {
+		if _, ok = a.functions[s]; ok {
+			break
+		}
+	}
+
+	if !ok {
+		return a
+	}
+
+	fs := make(map[string][]bool, len(a.functions))
+
+	for s, bs := range a.functions {
+		if !includesName(ss, s) {
+			fs[s] = bs
+		}
+	}
+
+	return demandAnalyzer{fs}
+}
+
+func intersectVariables(vss []map[string]struct{}) map[string]struct{} {
+	if len(vss) == 0 {
+		return map[string]struct{}{}
+	}
+
+	vs := map[string]struct{}{}
+
+	for s := range vss[0] {
+		ok := true
+
+		for _, vvs := range vss[1:] {
+			if _, ok = vvs[s]; !ok {
+				break
+			}
+		}
+
+		if ok {
+			vs[s] = struct{}{}
+		}
+	}
+
+	return vs
+}
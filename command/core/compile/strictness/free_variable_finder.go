@@ -0,0 +1,66 @@
+package strictness
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+func findFreeVariables(e ast.Expression) map[string]struct{} {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		vs := findFreeVariables(e.Argument())
+
+		for _, a := range e.Alternatives() {
+			addVariables(vs, removeVariables(findFreeVariables(a.Expression()), a.ElementNames()...))
+		}
+
+		if a, ok := e.DefaultAlternative(); ok {
+			addVariables(vs, removeVariables(findFreeVariables(a.Expression()), a.Variable()))
+		}
+
+		return vs
+	case ast.ConstructorApplication:
+		return findFreeVariablesInAtoms(e.Arguments())
+	case ast.FunctionApplication:
+		vs := findFreeVariablesInAtoms(e.Arguments())
+		vs[e.Function().Name()] = struct{}{}
+		return vs
+	case ast.Let:
+		vs := findFreeVariables(e.Expression())
+
+		for _, b := range e.Binds() {
+			for _, s := range b.Lambda().FreeVariableNames() {
+				vs[s] = struct{}{}
+			}
+		}
+
+		return removeVariables(vs, bindsToNames(e.Binds())...)
+	case ast.Literal:
+		return map[string]struct{}{}
+	case ast.PrimitiveCase:
+		vs := findFreeVariables(e.Argument())
+
+		for _, a := range e.Alternatives() {
+			addVariables(vs, findFreeVariables(a.Expression()))
+		}
+
+		if a, ok := e.DefaultAlternative(); ok {
+			addVariables(vs, removeVariables(findFreeVariables(a.Expression()), a.Variable()))
+		}
+
+		return vs
+	case ast.PrimitiveOperation:
+		return findFreeVariablesInAtoms(e.Arguments())
+	}
+
+	panic("unreachable")
+}
+
+func findFreeVariablesInAtoms(as []ast.Atom) map[string]struct{} {
+	vs := map[string]struct{}{}
+
+	for _, a := range as {
+		if v, ok := a.(ast.Variable); ok {
+			vs[v.Name()] = struct{}{}
+		}
+	}
+
+	return vs
+}
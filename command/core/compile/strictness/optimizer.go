@@ -0,0 +1,476 @@
+package strictness
+
+import (
+	"fmt"
+
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+)
+
+const workerSuffix = "$strict"
+
+// worker is a function which receives unboxed values of strict arguments of
+// its original function.
+type worker struct {
+	name      string
+	arguments map[int]unboxedType
+}
+
+type optimizer struct {
+	workers map[string]worker
+	index   *int
+}
+
+func newOptimizer() optimizer {
+	return optimizer{map[string]worker{}, new(int)}
+}
+
+func (o optimizer) Optimize(m ast.Module) ast.Module {
+	fs := findStrictArguments(m.Binds())
+
+	for _, b := range m.Binds() {
+		if w, ok := o.findWorker(b, fs[b.Name()]); ok {
+			o.workers[b.Name()] = w
+		}
+	}
+
+	bs := make([]ast.Bind, 0, len(m.Binds())+len(o.workers))
+
+	for _, b := range m.Binds() {
+		l := b.Lambda()
+		l = newLambda(
+			l,
+			nil,
+			getArguments(l),
+			o.rewriteCalls(l.Body(), addNames(nil, l.ArgumentNames()...)),
+		)
+
+		if w, ok := o.workers[b.Name()]; ok {
+			bs = append(bs, o.createWrapper(b.Name(), l, w), o.createWorker(l, w))
+			continue
+		}
+
+		bs = append(bs, ast.NewBind(b.Name(), l))
+	}
+
+	for i, b := range bs {
+		l := b.Lambda()
+		bs[i] = ast.NewBind(b.Name(), newLambda(l, nil, getArguments(l), o.optimizeExpression(l.Body())))
+	}
+
+	return ast.NewModuleWithForeignDeclarations(m.ForeignDeclarations(), m.Declarations(), bs)
+}
+
+// findWorker finds arguments of a global function which are always demanded
+// and can be passed as unboxed values.
+func (o optimizer) findWorker(b ast.Bind, ss []bool) (worker, bool) {
+	l := b.Lambda()
+
+	if l.IsThunk() {
+		return worker{}, false
+	}
+
+	ts := map[int]unboxedType{}
+
+	for i := range l.ArgumentNames() {
+		if !ss[i] {
+			continue
+		} else if t, ok := newUnboxedType(l.ArgumentTypes()[i]); ok {
+			ts[i] = t
+		}
+	}
+
+	if len(ts) == 0 {
+		return worker{}, false
+	}
+
+	return worker{b.Name() + workerSuffix, ts}, true
+}
+
+// createWrapper creates a function which evaluates strict arguments and calls
+// its worker function.
+func (o optimizer) createWrapper(s string, l ast.Lambda, w worker) ast.Bind {
+	as := make([]ast.Atom, 0, len(l.ArgumentNames()))
+
+	for _, s := range l.ArgumentNames() {
+		as = append(as, ast.NewVariable(s))
+	}
+
+	return ast.NewBind(
+		s,
+		newLambda(
+			l,
+			nil,
+			getArguments(l),
+			o.rewriteCalls(ast.NewFunctionApplication(ast.NewVariable(s), as), nil),
+		),
+	)
+}
+
+// createWorker creates a function which receives unboxed values of strict
+// arguments.
+func (o optimizer) createWorker(l ast.Lambda, w worker) ast.Bind {
+	as := getArguments(l)
+	e := l.Body()
+
+	for i, a := range as {
+		t, ok := w.arguments[i]
+
+		if !ok {
+			continue
+		}
+
+		s := o.generateName()
+		as[i] = ast.NewArgument(s, t.Primitive())
+		e = o.unboxVariable(e, a.Name(), s, t)
+	}
+
+	return ast.NewBind(w.name, newLambda(l, nil, as, e))
+}
+
+// rewriteCalls rewrites saturated applications of functions with workers into
+// ones of the workers with strict arguments evaluated in advance.
+func (o optimizer) rewriteCalls(e ast.Expression, ss map[string]struct{}) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlgebraicAlternative(
+					a.Constructor(),
+					a.ElementNames(),
+					o.rewriteCalls(a.Expression(), addNames(ss, a.ElementNames()...)),
+				),
+			)
+		}
+
+		a, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewAlgebraicCaseWithoutDefault(o.rewriteCalls(e.Argument(), ss), as)
+		}
+
+		return ast.NewAlgebraicCase(
+			o.rewriteCalls(e.Argument(), ss),
+			as,
+			ast.NewDefaultAlternative(
+				a.Variable(),
+				o.rewriteCalls(a.Expression(), addNames(ss, a.Variable())),
+			),
+		)
+	case ast.FunctionApplication:
+		return o.rewriteCall(e, ss)
+	case ast.Let:
+		ss = addNames(ss, bindsToNames(e.Binds())...)
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			l := b.Lambda()
+			bs = append(
+				bs,
+				ast.NewBind(
+					b.Name(),
+					newLambda(
+						l,
+						getFreeVariables(l),
+						getArguments(l),
+						o.rewriteCalls(l.Body(), addNames(ss, l.ArgumentNames()...)),
+					),
+				),
+			)
+		}
+
+		return ast.NewLet(bs, o.rewriteCalls(e.Expression(), ss))
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), o.rewriteCalls(a.Expression(), ss)))
+		}
+
+		a, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewPrimitiveCaseWithoutDefault(o.rewriteCalls(e.Argument(), ss), e.Type(), as)
+		}
+
+		return ast.NewPrimitiveCase(
+			o.rewriteCalls(e.Argument(), ss),
+			e.Type(),
+			as,
+			ast.NewDefaultAlternative(
+				a.Variable(),
+				o.rewriteCalls(a.Expression(), addNames(ss, a.Variable())),
+			),
+		)
+	}
+
+	return e
+}
+
+func (o optimizer) rewriteCall(a ast.FunctionApplication, ss map[string]struct{}) ast.Expression {
+	w, ok := o.workers[a.Function().Name()]
+
+	if _, shadowed := ss[a.Function().Name()]; !ok || shadowed || len(a.Arguments()) == 0 {
+		return a
+	}
+
+	as := append([]ast.Atom(nil), a.Arguments()...)
+	vs := make(map[int]string, len(w.arguments))
+
+	for i := range w.arguments {
+		if i >= len(as) {
+			return a
+		} else if _, ok := as[i].(ast.Variable); !ok {
+			return a
+		}
+	}
+
+	for i := range as {
+		if _, ok := w.arguments[i]; ok {
+			vs[i] = o.generateName()
+		}
+	}
+
+	for i, s := range vs {
+		as[i] = ast.NewVariable(s)
+	}
+
+	var e ast.Expression = ast.NewFunctionApplication(ast.NewVariable(w.name), as)
+
+	for i := len(as) - 1; i >= 0; i-- {
+		s, ok := vs[i]
+
+		if !ok {
+			continue
+		}
+
+		e = ast.NewAlgebraicCaseWithoutDefault(
+			ast.NewFunctionApplication(a.Arguments()[i].(ast.Variable), nil),
+			[]ast.AlgebraicAlternative{
+				ast.NewAlgebraicAlternative(w.arguments[i].Constructor(), []string{s}, e),
+			},
+		)
+	}
+
+	return e
+}
+
+// optimizeExpression evaluates thunks bound by let expressions eagerly if they
+// are always demanded.
+func (o optimizer) optimizeExpression(e ast.Expression) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlgebraicAlternative(
+					a.Constructor(),
+					a.ElementNames(),
+					o.optimizeExpression(a.Expression()),
+				),
+			)
+		}
+
+		a, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewAlgebraicCaseWithoutDefault(o.optimizeExpression(e.Argument()), as)
+		}
+
+		return ast.NewAlgebraicCase(
+			o.optimizeExpression(e.Argument()),
+			as,
+			ast.NewDefaultAlternative(a.Variable(), o.optimizeExpression(a.Expression())),
+		)
+	case ast.Let:
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			l := b.Lambda()
+			bs = append(
+				bs,
+				ast.NewBind(
+					b.Name(),
+					newLambda(
+						l,
+						getFreeVariables(l),
+						getArguments(l),
+						o.optimizeExpression(l.Body()),
+					),
+				),
+			)
+		}
+
+		return o.optimizeLet(ast.NewLet(bs, o.optimizeExpression(e.Expression())))
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), o.optimizeExpression(a.Expression())))
+		}
+
+		a, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewPrimitiveCaseWithoutDefault(o.optimizeExpression(e.Argument()), e.Type(), as)
+		}
+
+		return ast.NewPrimitiveCase(
+			o.optimizeExpression(e.Argument()),
+			e.Type(),
+			as,
+			ast.NewDefaultAlternative(a.Variable(), o.optimizeExpression(a.Expression())),
+		)
+	}
+
+	return e
+}
+
+// optimizeLet evaluates a demanded thunk bound by a let expression in advance
+// and binds its unboxed value with a primitive case expression.
+func (o optimizer) optimizeLet(l ast.Let) ast.Expression {
+	vs := newDemandAnalyzer(nil).AnalyzeLet(l)
+
+	for i, b := range l.Binds() {
+		t, ok := newUnboxedType(b.Lambda().ResultType())
+
+		if _, demanded := vs[b.Name()]; !ok || !demanded || !b.Lambda().IsThunk() {
+			continue
+		} else if o.isReferencedByBinds(b.Name(), l.Binds()) {
+			continue
+		}
+
+		s := o.generateName()
+		e := o.unboxVariable(l.Expression(), b.Name(), s, t)
+		e = ast.NewPrimitiveCase(
+			o.unboxResult(b.Lambda().Body(), t),
+			t.Primitive(),
+			nil,
+			ast.NewDefaultAlternative(s, e),
+		)
+
+		bs := append(append([]ast.Bind(nil), l.Binds()[:i]...), l.Binds()[i+1:]...)
+
+		if len(bs) == 0 {
+			return e
+		}
+
+		return o.optimizeLet(ast.NewLet(bs, e))
+	}
+
+	return l
+}
+
+func (optimizer) isReferencedByBinds(s string, bs []ast.Bind) bool {
+	for _, b := range bs {
+		if includesName(b.Lambda().FreeVariableNames(), s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unboxResult converts an expression of an unboxed type into one of its
+// primitive value.
+func (o optimizer) unboxResult(e ast.Expression, t unboxedType) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlgebraicAlternative(a.Constructor(), a.ElementNames(), o.unboxResult(a.Expression(), t)),
+			)
+		}
+
+		a, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewAlgebraicCaseWithoutDefault(e.Argument(), as)
+		}
+
+		return ast.NewAlgebraicCase(
+			e.Argument(),
+			as,
+			ast.NewDefaultAlternative(a.Variable(), o.unboxResult(a.Expression(), t)),
+		)
+	case ast.ConstructorApplication:
+		switch a := e.Arguments()[0].(type) {
+		case ast.Literal:
+			return a
+		case ast.Variable:
+			return ast.NewFunctionApplication(a, nil)
+		}
+	case ast.Let:
+		return o.optimizeLet(ast.NewLet(e.Binds(), o.unboxResult(e.Expression(), t)))
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), o.unboxResult(a.Expression(), t)))
+		}
+
+		a, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewPrimitiveCaseWithoutDefault(e.Argument(), e.Type(), as)
+		}
+
+		return ast.NewPrimitiveCase(
+			e.Argument(),
+			e.Type(),
+			as,
+			ast.NewDefaultAlternative(a.Variable(), o.unboxResult(a.Expression(), t)),
+		)
+	}
+
+	s := o.generateName()
+
+	return ast.NewAlgebraicCaseWithoutDefault(
+		e,
+		[]ast.AlgebraicAlternative{
+			ast.NewAlgebraicAlternative(
+				t.Constructor(),
+				[]string{s},
+				ast.NewFunctionApplication(ast.NewVariable(s), nil),
+			),
+		},
+	)
+}
+
+// unboxVariable replaces pattern matches on a variable in an expression with
+// its unboxed value. If the variable is still referenced, its boxed value is
+// bound again.
+func (o optimizer) unboxVariable(e ast.Expression, s, ss string, t unboxedType) ast.Expression {
+	e = newVariableUnboxer(s, ss, t).Unbox(e)
+
+	if _, ok := findFreeVariables(e)[s]; !ok {
+		return e
+	}
+
+	return ast.NewLet(
+		[]ast.Bind{
+			ast.NewBind(
+				s,
+				ast.NewVariableLambda(
+					[]ast.Argument{ast.NewArgument(ss, t.Primitive())},
+					ast.NewConstructorApplication(t.Constructor(), []ast.Atom{ast.NewVariable(ss)}),
+					t.Constructor().AlgebraicType(),
+				),
+			),
+		},
+		e,
+	)
+}
+
+func (o optimizer) generateName() string {
+	s := fmt.Sprintf("$unboxed-%v", *o.index)
+	*o.index++
+	return s
+}
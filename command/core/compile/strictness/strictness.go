@@ -0,0 +1,16 @@
+// Package strictness evaluates strict values eagerly and passes them unboxed.
+//
+// Only values of algebraic types with a single constructor of a single
+// primitive element, such as numbers, are unboxed. Functions with strict
+// arguments of such types are split into wrappers which evaluate the
+// arguments and workers which receive their primitive values. Values of the
+// other types are still passed as thunks.
+package strictness
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+// Optimize evaluates binds whose values are always demanded eagerly and passes
+// their unboxed values directly instead of allocating thunks.
+func Optimize(m ast.Module) ast.Module {
+	return newOptimizer().Optimize(m)
+}
@@ -0,0 +1,393 @@
+package strictness_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/raviqqe/lazy-ein/command/core/compile/strictness"
+	"github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/core/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+var numberType = types.NewAlgebraic(types.NewConstructor(types.NewFloat64()))
+var numberConstructor = ast.NewConstructor(numberType, 0)
+
+func TestOptimizeWithStrictLetBinds(t *testing.T) {
+	m := strictness.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+						ast.NewLet(
+							[]ast.Bind{
+								ast.NewBind(
+									"y",
+									ast.NewVariableLambda(
+										[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+										ast.NewConstructorApplication(
+											numberConstructor,
+											[]ast.Atom{ast.NewVariable("x")},
+										),
+										numberType,
+									),
+								),
+							},
+							ast.NewAlgebraicCaseWithoutDefault(
+								ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+								[]ast.AlgebraicAlternative{
+									ast.NewAlgebraicAlternative(
+										numberConstructor,
+										[]string{"z"},
+										ast.NewFunctionApplication(ast.NewVariable("z"), nil),
+									),
+								},
+							),
+						),
+						types.NewFloat64(),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(
+		t,
+		ast.NewPrimitiveCase(
+			ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+			types.NewFloat64(),
+			nil,
+			ast.NewDefaultAlternative(
+				"$unboxed-0",
+				ast.NewFunctionApplication(ast.NewVariable("$unboxed-0"), nil),
+			),
+		),
+		m.Binds()[0].Lambda().Body(),
+	)
+}
+
+func TestOptimizeWithLazyLetBinds(t *testing.T) {
+	e := ast.NewLet(
+		[]ast.Bind{
+			ast.NewBind(
+				"y",
+				ast.NewVariableLambda(
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewConstructorApplication(
+						numberConstructor,
+						[]ast.Atom{ast.NewVariable("x")},
+					),
+					numberType,
+				),
+			),
+		},
+		ast.NewPrimitiveCase(
+			ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+			types.NewFloat64(),
+			[]ast.PrimitiveAlternative{
+				ast.NewPrimitiveAlternative(
+					ast.NewFloat64(0),
+					ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+				),
+			},
+			ast.NewDefaultAlternative(
+				"",
+				ast.NewConstructorApplication(numberConstructor, []ast.Atom{ast.NewFloat64(42)}),
+			),
+		),
+	)
+
+	m := strictness.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+						e,
+						numberType,
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(t, e, m.Binds()[0].Lambda().Body())
+}
+
+func TestOptimizeWithStrictArguments(t *testing.T) {
+	m := strictness.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewBoxed(numberType))},
+						ast.NewAlgebraicCaseWithoutDefault(
+							ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+							[]ast.AlgebraicAlternative{
+								ast.NewAlgebraicAlternative(
+									numberConstructor,
+									[]string{"y"},
+									ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+								),
+							},
+						),
+						types.NewFloat64(),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualBinds(
+		t,
+		[]ast.Bind{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewBoxed(numberType))},
+					ast.NewAlgebraicCaseWithoutDefault(
+						ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+						[]ast.AlgebraicAlternative{
+							ast.NewAlgebraicAlternative(
+								numberConstructor,
+								[]string{"$unboxed-0"},
+								ast.NewFunctionApplication(
+									ast.NewVariable("f$strict"),
+									[]ast.Atom{ast.NewVariable("$unboxed-0")},
+								),
+							),
+						},
+					),
+					types.NewFloat64(),
+				),
+			),
+			ast.NewBind(
+				"f$strict",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("$unboxed-1", types.NewFloat64())},
+					ast.NewFunctionApplication(ast.NewVariable("$unboxed-1"), nil),
+					types.NewFloat64(),
+				),
+			),
+		},
+		m.Binds(),
+	)
+}
+
+func TestOptimizeWithRecursiveFunctions(t *testing.T) {
+	m := strictness.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewBoxed(numberType))},
+						ast.NewAlgebraicCaseWithoutDefault(
+							ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+							[]ast.AlgebraicAlternative{
+								ast.NewAlgebraicAlternative(
+									numberConstructor,
+									[]string{"y"},
+									ast.NewPrimitiveCase(
+										ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+										types.NewFloat64(),
+										[]ast.PrimitiveAlternative{
+											ast.NewPrimitiveAlternative(
+												ast.NewFloat64(0),
+												ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+											),
+										},
+										ast.NewDefaultAlternative(
+											"",
+											ast.NewLet(
+												[]ast.Bind{
+													ast.NewBind(
+														"z",
+														ast.NewVariableLambda(
+															[]ast.Argument{
+																ast.NewArgument("y", types.NewFloat64()),
+															},
+															ast.NewPrimitiveCase(
+																ast.NewPrimitiveOperation(
+																	ast.SubtractFloat64,
+																	[]ast.Atom{ast.NewVariable("y"), ast.NewFloat64(1)},
+																),
+																types.NewFloat64(),
+																nil,
+																ast.NewDefaultAlternative(
+																	"w",
+																	ast.NewConstructorApplication(
+																		numberConstructor,
+																		[]ast.Atom{ast.NewVariable("w")},
+																	),
+																),
+															),
+															numberType,
+														),
+													),
+												},
+												ast.NewFunctionApplication(
+													ast.NewVariable("f"),
+													[]ast.Atom{ast.NewVariable("z")},
+												),
+											),
+										),
+									),
+								),
+							},
+						),
+						types.NewBoxed(numberType),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+
+	err := m.Binds()[1].Lambda().VisitExpressions(func(e ast.Expression) error {
+		if l, ok := e.(ast.Let); ok {
+			for _, b := range l.Binds() {
+				assert.NotEqual(t, "z", b.Name())
+			}
+		}
+
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestOptimizeWithAccumulatingArguments(t *testing.T) {
+	boxedNumber := func(o ast.PrimitiveOperator, as []ast.Atom) ast.Expression {
+		return ast.NewPrimitiveCase(
+			ast.NewPrimitiveOperation(o, as),
+			types.NewFloat64(),
+			nil,
+			ast.NewDefaultAlternative(
+				"r",
+				ast.NewConstructorApplication(numberConstructor, []ast.Atom{ast.NewVariable("r")}),
+			),
+		)
+	}
+
+	m := ast.NewModule(
+		nil,
+		[]ast.Bind{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{
+						ast.NewArgument("x", types.NewBoxed(numberType)),
+						ast.NewArgument("y", types.NewBoxed(numberType)),
+					},
+					ast.NewAlgebraicCaseWithoutDefault(
+						ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+						[]ast.AlgebraicAlternative{
+							ast.NewAlgebraicAlternative(
+								numberConstructor,
+								[]string{"z"},
+								ast.NewPrimitiveCase(
+									ast.NewFunctionApplication(ast.NewVariable("z"), nil),
+									types.NewFloat64(),
+									[]ast.PrimitiveAlternative{
+										ast.NewPrimitiveAlternative(
+											ast.NewFloat64(0),
+											ast.NewAlgebraicCaseWithoutDefault(
+												ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+												[]ast.AlgebraicAlternative{
+													ast.NewAlgebraicAlternative(
+														numberConstructor,
+														[]string{"w"},
+														ast.NewFunctionApplication(ast.NewVariable("w"), nil),
+													),
+												},
+											),
+										),
+									},
+									ast.NewDefaultAlternative(
+										"",
+										ast.NewLet(
+											[]ast.Bind{
+												ast.NewBind(
+													"v",
+													ast.NewVariableLambda(
+														[]ast.Argument{ast.NewArgument("z", types.NewFloat64())},
+														boxedNumber(
+															ast.SubtractFloat64,
+															[]ast.Atom{ast.NewVariable("z"), ast.NewFloat64(1)},
+														),
+														numberType,
+													),
+												),
+												ast.NewBind(
+													"u",
+													ast.NewVariableLambda(
+														[]ast.Argument{
+															ast.NewArgument("y", types.NewBoxed(numberType)),
+															ast.NewArgument("z", types.NewFloat64()),
+														},
+														ast.NewAlgebraicCaseWithoutDefault(
+															ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+															[]ast.AlgebraicAlternative{
+																ast.NewAlgebraicAlternative(
+																	numberConstructor,
+																	[]string{"w"},
+																	boxedNumber(
+																		ast.AddFloat64,
+																		[]ast.Atom{ast.NewVariable("w"), ast.NewVariable("z")},
+																	),
+																),
+															},
+														),
+														numberType,
+													),
+												),
+											},
+											ast.NewFunctionApplication(
+												ast.NewVariable("f"),
+												[]ast.Atom{ast.NewVariable("v"), ast.NewVariable("u")},
+											),
+										),
+									),
+								),
+							),
+						},
+					),
+					types.NewFloat64(),
+				),
+			),
+		},
+	)
+
+	assert.Nil(t, validate.Validate(m))
+
+	m = strictness.Optimize(m)
+
+	assert.Nil(t, validate.Validate(m))
+	assert.Equal(t, 2, len(m.Binds()))
+	assert.Equal(t, "f$strict", m.Binds()[1].Name())
+	assert.Equal(
+		t,
+		[]types.Type{types.NewFloat64(), types.NewFloat64()},
+		m.Binds()[1].Lambda().ArgumentTypes(),
+	)
+}
@@ -0,0 +1,37 @@
+package strictness
+
+import (
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/raviqqe/lazy-ein/command/core/types"
+)
+
+// unboxedType is an algebraic type with a single constructor of a single
+// primitive element whose values can be represented by primitive ones.
+type unboxedType struct {
+	constructor ast.Constructor
+	primitive   types.Primitive
+}
+
+func newUnboxedType(t types.Type) (unboxedType, bool) {
+	a, ok := types.Unbox(t).(types.Algebraic)
+
+	if !ok || len(a.Constructors()) != 1 || len(a.Constructors()[0].Elements()) != 1 {
+		return unboxedType{}, false
+	}
+
+	p, ok := a.Constructors()[0].Elements()[0].(types.Primitive)
+
+	if !ok {
+		return unboxedType{}, false
+	}
+
+	return unboxedType{ast.NewConstructor(a, 0), p}, true
+}
+
+func (t unboxedType) Constructor() ast.Constructor {
+	return t.constructor
+}
+
+func (t unboxedType) Primitive() types.Primitive {
+	return t.primitive
+}
@@ -0,0 +1,84 @@
+package strictness
+
+import (
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/raviqqe/lazy-ein/command/core/types"
+)
+
+func addVariables(vs, vvs map[string]struct{}) map[string]struct{} {
+	for s := range vvs {
+		vs[s] = struct{}{}
+	}
+
+	return vs
+}
+
+func removeVariables(vs map[string]struct{}, ss ...string) map[string]struct{} {
+	for _, s := range ss {
+		delete(vs, s)
+	}
+
+	return vs
+}
+
+func includesName(ss []string, s string) bool {
+	for _, t := range ss {
+		if t == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bindsToNames(bs []ast.Bind) []string {
+	ss := make([]string, 0, len(bs))
+
+	for _, b := range bs {
+		ss = append(ss, b.Name())
+	}
+
+	return ss
+}
+
+func getFreeVariables(l ast.Lambda) []ast.Argument {
+	as := make([]ast.Argument, 0, len(l.FreeVariableNames()))
+
+	for i, s := range l.FreeVariableNames() {
+		as = append(as, ast.NewArgument(s, l.FreeVariableTypes()[i]))
+	}
+
+	return as
+}
+
+func getArguments(l ast.Lambda) []ast.Argument {
+	as := make([]ast.Argument, 0, len(l.ArgumentNames()))
+
+	for i, s := range l.ArgumentNames() {
+		as = append(as, ast.NewArgument(s, l.ArgumentTypes()[i]))
+	}
+
+	return as
+}
+
+func newLambda(l ast.Lambda, vs []ast.Argument, as []ast.Argument, e ast.Expression) ast.Lambda {
+	if len(as) == 0 {
+		return ast.NewVariableLambda(vs, e, l.ResultType().(types.Bindable))
+	}
+
+	return ast.NewFunctionLambda(vs, as, e, l.ResultType())
+}
+
+func addNames(ss map[string]struct{}, sss ...string) map[string]struct{} {
+	m := make(map[string]struct{}, len(ss)+len(sss))
+
+	for s := range ss {
+		m[s] = struct{}{}
+	}
+
+	for _, s := range sss {
+		m[s] = struct{}{}
+	}
+
+	return m
+}
@@ -0,0 +1,28 @@
+package strictness_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertEqualExpressions asserts that expressions are equal regardless of
+// whether empty arguments of function applications are nil or not.
+func assertEqualExpressions(t *testing.T, e, ee ast.Expression) {
+	assert.Equal(t, e.RenameVariables(nil), ee.RenameVariables(nil))
+}
+
+func assertEqualBinds(t *testing.T, bs, bbs []ast.Bind) {
+	assert.Equal(t, renameBinds(bs), renameBinds(bbs))
+}
+
+func renameBinds(bs []ast.Bind) []ast.Bind {
+	bbs := make([]ast.Bind, 0, len(bs))
+
+	for _, b := range bs {
+		bbs = append(bbs, b.RenameVariables(nil))
+	}
+
+	return bbs
+}
@@ -0,0 +1,122 @@
+package strictness
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+// variableUnboxer replaces pattern matches on a boxed variable with its
+// unboxed value already evaluated.
+type variableUnboxer struct {
+	variable        string
+	unboxedVariable string
+	typ             unboxedType
+}
+
+func newVariableUnboxer(s, ss string, t unboxedType) variableUnboxer {
+	return variableUnboxer{s, ss, t}
+}
+
+func (u variableUnboxer) Unbox(e ast.Expression) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		if a, ok := e.Argument().(ast.FunctionApplication); ok &&
+			a.Function().Name() == u.variable &&
+			len(a.Arguments()) == 0 &&
+			len(e.Alternatives()) != 0 {
+			a := e.Alternatives()[0]
+			e := a.Expression()
+
+			if s := a.ElementNames()[0]; s != "" {
+				e = e.RenameVariables(map[string]string{s: u.unboxedVariable})
+			}
+
+			return u.Unbox(e)
+		}
+
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlgebraicAlternative(
+					a.Constructor(),
+					a.ElementNames(),
+					u.unboxInScope(a.Expression(), a.ElementNames()...),
+				),
+			)
+		}
+
+		a, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewAlgebraicCaseWithoutDefault(u.Unbox(e.Argument()), as)
+		}
+
+		return ast.NewAlgebraicCase(
+			u.Unbox(e.Argument()),
+			as,
+			ast.NewDefaultAlternative(a.Variable(), u.unboxInScope(a.Expression(), a.Variable())),
+		)
+	case ast.Let:
+		if includesName(bindsToNames(e.Binds()), u.variable) {
+			return e
+		}
+
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			bs = append(bs, ast.NewBind(b.Name(), u.unboxLambda(b.Lambda())))
+		}
+
+		return ast.NewLet(bs, u.Unbox(e.Expression()))
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), u.Unbox(a.Expression())))
+		}
+
+		a, ok := e.DefaultAlternative()
+
+		if !ok {
+			return ast.NewPrimitiveCaseWithoutDefault(u.Unbox(e.Argument()), e.Type(), as)
+		}
+
+		return ast.NewPrimitiveCase(
+			u.Unbox(e.Argument()),
+			e.Type(),
+			as,
+			ast.NewDefaultAlternative(a.Variable(), u.unboxInScope(a.Expression(), a.Variable())),
+		)
+	}
+
+	return e
+}
+
+func (u variableUnboxer) unboxInScope(e ast.Expression, ss ...string) ast.Expression {
+	if includesName(ss, u.variable) {
+		return e
+	}
+
+	return u.Unbox(e)
+}
+
+func (u variableUnboxer) unboxLambda(l ast.Lambda) ast.Lambda {
+	if !includesName(l.FreeVariableNames(), u.variable) {
+		return l
+	}
+
+	e := u.Unbox(l.Body())
+	vs := findFreeVariables(e)
+	as := make([]ast.Argument, 0, len(l.FreeVariableNames())+1)
+
+	for _, a := range getFreeVariables(l) {
+		if _, ok := vs[a.Name()]; ok || a.Name() != u.variable {
+			as = append(as, a)
+		}
+	}
+
+	if _, ok := vs[u.unboxedVariable]; ok && !includesName(l.FreeVariableNames(), u.unboxedVariable) {
+		as = append(as, ast.NewArgument(u.unboxedVariable, u.typ.Primitive()))
+	}
+
+	return newLambda(l, as, getArguments(l), e)
+}
@@ -31,6 +31,8 @@ func (g typeGenerator) Generate(t types.Type) llvm.Type {
 		)
 	case types.Float64:
 		return llvm.DoubleType()
+	case types.Int64:
+		return llvm.Int64Type()
 	case types.Function:
 		if types.IsRecursive(t) {
 			s := llvm.GlobalContext().StructCreateNamed(t.String())
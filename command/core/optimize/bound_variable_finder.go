@@ -0,0 +1,42 @@
+package optimize
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+func findBoundVariables(e ast.Expression) map[string]struct{} {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		vs := findBoundVariables(e.Argument())
+
+		for _, a := range e.Alternatives() {
+			addVariables(vs, addNames(findBoundVariables(a.Expression()), a.ElementNames()...))
+		}
+
+		if a, ok := e.DefaultAlternative(); ok {
+			addVariables(vs, addNames(findBoundVariables(a.Expression()), a.Variable()))
+		}
+
+		return vs
+	case ast.Let:
+		vs := addNames(findBoundVariables(e.Expression()), bindsToNames(e.Binds())...)
+
+		for _, b := range e.Binds() {
+			addVariables(vs, addNames(findBoundVariables(b.Lambda().Body()), b.Lambda().ArgumentNames()...))
+		}
+
+		return vs
+	case ast.PrimitiveCase:
+		vs := findBoundVariables(e.Argument())
+
+		for _, a := range e.Alternatives() {
+			addVariables(vs, findBoundVariables(a.Expression()))
+		}
+
+		if a, ok := e.DefaultAlternative(); ok {
+			addVariables(vs, addNames(findBoundVariables(a.Expression()), a.Variable()))
+		}
+
+		return vs
+	}
+
+	return map[string]struct{}{}
+}
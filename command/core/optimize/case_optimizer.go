@@ -0,0 +1,267 @@
+package optimize
+
+import (
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/raviqqe/lazy-ein/command/core/types"
+)
+
+// knownConstructor is a constructor application which a thunk is bound to.
+type knownConstructor struct {
+	application ast.ConstructorApplication
+	global      bool
+}
+
+type caseOptimizer struct {
+	constructors map[string]knownConstructor
+}
+
+func newCaseOptimizer(m ast.Module) caseOptimizer {
+	cs := map[string]knownConstructor{}
+
+	for _, b := range m.Binds() {
+		if a, ok := b.Lambda().Body().(ast.ConstructorApplication); ok && b.Lambda().IsThunk() {
+			cs[b.Name()] = knownConstructor{a, true}
+		}
+	}
+
+	return caseOptimizer{cs}
+}
+
+func (o caseOptimizer) Optimize(m ast.Module) ast.Module {
+	return convertBodies(m, func(l ast.Lambda) ast.Expression {
+		return o.optimizeLambda(l).Body()
+	})
+}
+
+func (o caseOptimizer) optimize(e ast.Expression) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		arg := o.optimize(e.Argument())
+
+		if a, ok := arg.(ast.FunctionApplication); ok && len(a.Arguments()) == 0 {
+			if c, ok := o.constructors[a.Function().Name()]; ok {
+				if e, ok := o.selectAlternative(e, c.application); ok {
+					return o.optimize(e)
+				}
+			}
+		}
+
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlgebraicAlternative(
+					a.Constructor(),
+					a.ElementNames(),
+					o.bindVariables(a.ElementNames()...).optimize(a.Expression()),
+				),
+			)
+		}
+
+		return ast.NewAlgebraicCase(arg, as, o.optimizeDefaultAlternative(e))
+	case ast.Let:
+		o = o.bindVariables(bindsToNames(e.Binds())...)
+		o.addConstructors(e.Binds())
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			bs = append(bs, ast.NewBind(b.Name(), collapseThunk(o.optimizeLambda(b.Lambda()))))
+		}
+
+		o.addConstructors(bs)
+
+		// Dead binds are eliminated here so that thunks are collapsed.
+		return eliminateDeadLet(ast.NewLet(bs, o.optimize(e.Expression())))
+	case ast.PrimitiveCase:
+		arg := o.optimize(e.Argument())
+
+		if l, ok := arg.(ast.Literal); ok {
+			for _, a := range e.Alternatives() {
+				if a.Literal() == l {
+					return o.optimize(a.Expression())
+				}
+			}
+
+			if a, ok := e.DefaultAlternative(); ok {
+				return o.optimize(substituteLiteral(a.Expression(), a.Variable(), l))
+			}
+		}
+
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), o.optimize(a.Expression())))
+		}
+
+		return ast.NewPrimitiveCase(arg, e.Type(), as, o.optimizeDefaultAlternative(e))
+	case ast.PrimitiveOperation:
+		return foldPrimitiveOperation(e)
+	}
+
+	return e
+}
+
+func (o caseOptimizer) optimizeDefaultAlternative(c ast.Case) ast.DefaultAlternative {
+	a, ok := c.DefaultAlternative()
+
+	if !ok {
+		return ast.DefaultAlternative{}
+	}
+
+	return ast.NewDefaultAlternative(
+		a.Variable(),
+		o.bindVariables(a.Variable()).optimize(a.Expression()),
+	)
+}
+
+// optimizeLambda optimizes a lambda body with constructors only available
+// inside it.
+func (o caseOptimizer) optimizeLambda(l ast.Lambda) ast.Lambda {
+	cs := make(map[string]knownConstructor, len(o.constructors))
+	vs := addNames(nil, l.FreeVariableNames()...)
+
+	for s, c := range o.constructors {
+		if _, ok := vs[s]; c.global || ok && isSubset(findFreeVariablesInAtoms(c.application.Arguments()), vs) {
+			cs[s] = c
+		}
+	}
+
+	return newLambda(
+		l,
+		getFreeVariables(l),
+		getArguments(l),
+		caseOptimizer{cs}.bindVariables(l.ArgumentNames()...).optimize(l.Body()),
+	)
+}
+
+// addConstructors adds constructor applications which thunks are bound to.
+func (o caseOptimizer) addConstructors(bs []ast.Bind) {
+	for _, b := range bs {
+		if a, ok := b.Lambda().Body().(ast.ConstructorApplication); ok && b.Lambda().IsThunk() {
+			o.constructors[b.Name()] = knownConstructor{a, false}
+		}
+	}
+}
+
+// selectAlternative selects an alternative matched with a known constructor
+// and binds its elements to arguments of the constructor application.
+func (caseOptimizer) selectAlternative(c ast.AlgebraicCase, a ast.ConstructorApplication) (ast.Expression, bool) {
+	for _, aa := range c.Alternatives() {
+		if aa.Constructor().Index() != a.Constructor().Index() {
+			continue
+		}
+
+		e := aa.Expression()
+		vs := findBoundVariables(e)
+		vvs := make(map[string]string, len(aa.ElementNames()))
+
+		for i, s := range aa.ElementNames() {
+			if v, ok := a.Arguments()[i].(ast.Variable); ok {
+				if _, ok := vs[v.Name()]; ok || includesName(aa.ElementNames(), v.Name()) {
+					return nil, false
+				}
+
+				vvs[s] = v.Name()
+			}
+		}
+
+		e = e.RenameVariables(vvs)
+
+		for i, s := range aa.ElementNames() {
+			if l, ok := a.Arguments()[i].(ast.Literal); ok {
+				e = ast.NewPrimitiveCase(
+					l,
+					a.Constructor().ConstructorType().Elements()[i].(types.Primitive),
+					nil,
+					ast.NewDefaultAlternative(s, e),
+				)
+			}
+		}
+
+		return e, true
+	}
+
+	if aa, ok := c.DefaultAlternative(); ok && !isFreeVariable(aa.Variable(), aa.Expression()) {
+		return aa.Expression(), true
+	}
+
+	return nil, false
+}
+
+// bindVariables forgets constructors shadowed by variables.
+func (o caseOptimizer) bindVariables(ss ...string) caseOptimizer {
+	vs := addNames(nil, ss...)
+	cs := make(map[string]knownConstructor, len(o.constructors))
+
+	for s, c := range o.constructors {
+		if _, ok := vs[s]; !ok && !hasCommonVariables(findFreeVariablesInAtoms(c.application.Arguments()), vs) {
+			cs[s] = c
+		}
+	}
+
+	return caseOptimizer{cs}
+}
+
+// collapseThunk replaces a thunk which only evaluates another thunk with the
+// latter. Global thunks are never collapsed because their result types are
+// visible from other modules.
+func collapseThunk(l ast.Lambda) ast.Lambda {
+	e, ok := l.Body().(ast.Let)
+
+	if !l.IsThunk() || !ok || len(e.Binds()) != 1 {
+		return l
+	}
+
+	b := e.Binds()[0]
+	a, ok := e.Expression().(ast.FunctionApplication)
+
+	if !ok ||
+		a.Function().Name() != b.Name() ||
+		len(a.Arguments()) != 0 ||
+		!b.Lambda().IsThunk() ||
+		isFreeVariable(b.Name(), b.Lambda().Body()) {
+		return l
+	}
+
+	return ast.NewVariableLambda(
+		getFreeVariables(l),
+		b.Lambda().Body(),
+		b.Lambda().ResultType().(types.Bindable),
+	)
+}
+
+func isFreeVariable(s string, e ast.Expression) bool {
+	_, ok := findFreeVariables(e)[s]
+	return ok
+}
+
+func isSubset(vs, vvs map[string]struct{}) bool {
+	for s := range vs {
+		if _, ok := vvs[s]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasCommonVariables(vs, vvs map[string]struct{}) bool {
+	for s := range vs {
+		if _, ok := vvs[s]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func includesName(ss []string, s string) bool {
+	for _, t := range ss {
+		if t == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+package optimize
+
+import (
+	"math"
+
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+)
+
+// foldPrimitiveOperation evaluates a primitive operation on literals at
+// compile time. Operations whose results are undefined at runtime are left
+// as they are.
+func foldPrimitiveOperation(o ast.PrimitiveOperation) ast.Expression {
+	switch as := o.Arguments(); o.PrimitiveOperator() {
+	case ast.Float64ToInt64:
+		if x, ok := as[0].(ast.Float64); ok && x.Value() >= math.MinInt64 && x.Value() < math.MaxInt64 {
+			return ast.NewInt64(int64(x.Value()))
+		}
+	case ast.Int64ToFloat64:
+		if x, ok := as[0].(ast.Int64); ok {
+			return ast.NewFloat64(float64(x.Value()))
+		}
+	default:
+		if x, ok := as[0].(ast.Float64); ok {
+			if y, ok := as[1].(ast.Float64); ok {
+				return foldFloat64Operation(o, x.Value(), y.Value())
+			}
+		} else if x, ok := as[0].(ast.Int64); ok {
+			if y, ok := as[1].(ast.Int64); ok {
+				return foldInt64Operation(o, x.Value(), y.Value())
+			}
+		}
+	}
+
+	return o
+}
+
+func foldFloat64Operation(o ast.PrimitiveOperation, x, y float64) ast.Expression {
+	switch o.PrimitiveOperator() {
+	case ast.AddFloat64:
+		return ast.NewFloat64(x + y)
+	case ast.SubtractFloat64:
+		return ast.NewFloat64(x - y)
+	case ast.MultiplyFloat64:
+		return ast.NewFloat64(x * y)
+	case ast.DivideFloat64:
+		return ast.NewFloat64(x / y)
+	case ast.ModuloFloat64:
+		return ast.NewFloat64(math.Mod(x, y))
+	case ast.PowerFloat64:
+		return ast.NewFloat64(math.Pow(x, y))
+	case ast.EqualFloat64:
+		return booleanToFloat64(x == y)
+	case ast.NotEqualFloat64:
+		return booleanToFloat64(x != y)
+	case ast.LessThanFloat64:
+		return booleanToFloat64(x < y)
+	case ast.LessThanOrEqualFloat64:
+		return booleanToFloat64(x <= y)
+	case ast.GreaterThanFloat64:
+		return booleanToFloat64(x > y)
+	case ast.GreaterThanOrEqualFloat64:
+		return booleanToFloat64(x >= y)
+	}
+
+	return o
+}
+
+func foldInt64Operation(o ast.PrimitiveOperation, x, y int64) ast.Expression {
+	switch o.PrimitiveOperator() {
+	case ast.AddInt64:
+		return ast.NewInt64(x + y)
+	case ast.SubtractInt64:
+		return ast.NewInt64(x - y)
+	case ast.MultiplyInt64:
+		return ast.NewInt64(x * y)
+	case ast.DivideInt64, ast.ModuloInt64:
+		if y == 0 || x == math.MinInt64 && y == -1 {
+			return o
+		}
+
+		q, r := x/y, x%y
+
+		// Round results toward negative infinity.
+		if r != 0 && (r < 0) != (y < 0) {
+			q, r = q-1, r+y
+		}
+
+		if o.PrimitiveOperator() == ast.DivideInt64 {
+			return ast.NewInt64(q)
+		}
+
+		return ast.NewInt64(r)
+	case ast.RemainderInt64:
+		if y == 0 || x == math.MinInt64 && y == -1 {
+			return o
+		}
+
+		return ast.NewInt64(x % y)
+	case ast.PowerInt64:
+		z := int64(1)
+
+		for ; y > 0; y >>= 1 {
+			if y&1 != 0 {
+				z *= x
+			}
+
+			x *= x
+		}
+
+		return ast.NewInt64(z)
+	case ast.EqualInt64:
+		return booleanToInt64(x == y)
+	case ast.NotEqualInt64:
+		return booleanToInt64(x != y)
+	case ast.LessThanInt64:
+		return booleanToInt64(x < y)
+	case ast.LessThanOrEqualInt64:
+		return booleanToInt64(x <= y)
+	case ast.GreaterThanInt64:
+		return booleanToInt64(x > y)
+	case ast.GreaterThanOrEqualInt64:
+		return booleanToInt64(x >= y)
+	}
+
+	return o
+}
+
+func booleanToFloat64(b bool) ast.Float64 {
+	if b {
+		return ast.NewFloat64(1)
+	}
+
+	return ast.NewFloat64(0)
+}
+
+func booleanToInt64(b bool) ast.Int64 {
+	if b {
+		return ast.NewInt64(1)
+	}
+
+	return ast.NewInt64(0)
+}
@@ -0,0 +1,91 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoldPrimitiveOperation(t *testing.T) {
+	for _, c := range []struct {
+		operation ast.PrimitiveOperation
+		result    ast.Expression
+	}{
+		{
+			ast.NewPrimitiveOperation(ast.SubtractFloat64, []ast.Atom{ast.NewFloat64(1), ast.NewFloat64(2)}),
+			ast.NewFloat64(-1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.ModuloFloat64, []ast.Atom{ast.NewFloat64(-7), ast.NewFloat64(2)}),
+			ast.NewFloat64(-1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.ModuloFloat64, []ast.Atom{ast.NewFloat64(7), ast.NewFloat64(-2)}),
+			ast.NewFloat64(1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.LessThanFloat64, []ast.Atom{ast.NewFloat64(1), ast.NewFloat64(2)}),
+			ast.NewFloat64(1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.NotEqualFloat64, []ast.Atom{ast.NewFloat64(math.NaN()), ast.NewFloat64(0)}),
+			ast.NewFloat64(1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.DivideInt64, []ast.Atom{ast.NewInt64(-7), ast.NewInt64(2)}),
+			ast.NewInt64(-4),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.ModuloInt64, []ast.Atom{ast.NewInt64(-7), ast.NewInt64(2)}),
+			ast.NewInt64(1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.ModuloInt64, []ast.Atom{ast.NewInt64(7), ast.NewInt64(-2)}),
+			ast.NewInt64(-1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.RemainderInt64, []ast.Atom{ast.NewInt64(-7), ast.NewInt64(2)}),
+			ast.NewInt64(-1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.RemainderInt64, []ast.Atom{ast.NewInt64(7), ast.NewInt64(-2)}),
+			ast.NewInt64(1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.PowerInt64, []ast.Atom{ast.NewInt64(3), ast.NewInt64(5)}),
+			ast.NewInt64(243),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.PowerInt64, []ast.Atom{ast.NewInt64(3), ast.NewInt64(-1)}),
+			ast.NewInt64(1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.GreaterThanOrEqualInt64, []ast.Atom{ast.NewInt64(1), ast.NewInt64(2)}),
+			ast.NewInt64(0),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.Float64ToInt64, []ast.Atom{ast.NewFloat64(-1.5)}),
+			ast.NewInt64(-1),
+		},
+		{
+			ast.NewPrimitiveOperation(ast.Int64ToFloat64, []ast.Atom{ast.NewInt64(42)}),
+			ast.NewFloat64(42),
+		},
+	} {
+		assert.Equal(t, c.result, foldPrimitiveOperation(c.operation))
+	}
+}
+
+func TestFoldPrimitiveOperationWithUnfoldableOperations(t *testing.T) {
+	for _, o := range []ast.PrimitiveOperation{
+		ast.NewPrimitiveOperation(ast.AddFloat64, []ast.Atom{ast.NewVariable("x"), ast.NewFloat64(1)}),
+		ast.NewPrimitiveOperation(ast.DivideInt64, []ast.Atom{ast.NewInt64(1), ast.NewInt64(0)}),
+		ast.NewPrimitiveOperation(ast.ModuloInt64, []ast.Atom{ast.NewInt64(math.MinInt64), ast.NewInt64(-1)}),
+		ast.NewPrimitiveOperation(ast.RemainderInt64, []ast.Atom{ast.NewInt64(1), ast.NewInt64(0)}),
+		ast.NewPrimitiveOperation(ast.Float64ToInt64, []ast.Atom{ast.NewFloat64(math.Inf(1))}),
+	} {
+		assert.Equal(t, o, foldPrimitiveOperation(o))
+	}
+}
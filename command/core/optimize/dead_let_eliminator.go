@@ -0,0 +1,72 @@
+package optimize
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+func eliminateDeadLets(m ast.Module) ast.Module {
+	return convertBodies(m, func(l ast.Lambda) ast.Expression {
+		return convertExpression(l.Body(), eliminateDeadLet)
+	})
+}
+
+// eliminateDeadLet removes binds never used and free variables of closures
+// which are not used anymore.
+func eliminateDeadLet(e ast.Expression) ast.Expression {
+	l, ok := e.(ast.Let)
+
+	if !ok {
+		return e
+	}
+
+	bs := make(map[string]ast.Bind, len(l.Binds()))
+
+	for _, b := range l.Binds() {
+		bs[b.Name()] = ast.NewBind(b.Name(), removeUnusedFreeVariables(b.Lambda()))
+	}
+
+	vs := findFreeVariables(l.Expression())
+
+	for ss := vs; len(ss) != 0; {
+		sss := map[string]struct{}{}
+
+		for s := range ss {
+			if b, ok := bs[s]; ok {
+				for _, s := range b.Lambda().FreeVariableNames() {
+					if _, ok := vs[s]; !ok {
+						sss[s] = struct{}{}
+					}
+				}
+			}
+		}
+
+		addVariables(vs, sss)
+		ss = sss
+	}
+
+	bbs := make([]ast.Bind, 0, len(bs))
+
+	for _, b := range l.Binds() {
+		if _, ok := vs[b.Name()]; ok {
+			bbs = append(bbs, bs[b.Name()])
+		}
+	}
+
+	if len(bbs) == 0 {
+		return l.Expression()
+	}
+
+	return ast.NewLet(bbs, l.Expression())
+}
+
+func removeUnusedFreeVariables(l ast.Lambda) ast.Lambda {
+	vs := removeVariables(findFreeVariables(l.Body()), l.ArgumentNames()...)
+	as := make([]ast.Argument, 0, len(vs))
+
+	for _, a := range getFreeVariables(l) {
+		if _, ok := vs[a.Name()]; ok {
+			as = append(as, a)
+			delete(vs, a.Name())
+		}
+	}
+
+	return newLambda(l, as, getArguments(l), l.Body())
+}
@@ -0,0 +1,278 @@
+package optimize
+
+import (
+	"fmt"
+
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/raviqqe/lazy-ein/command/core/types"
+)
+
+// maxInlinedFunctionSize is the maximum number of expressions in bodies of
+// functions to be inlined.
+const maxInlinedFunctionSize = 16
+
+type inliner struct {
+	functions map[string]ast.Lambda
+	index     *int
+}
+
+func newInliner(m ast.Module) inliner {
+	fs := map[string]ast.Lambda{}
+
+	for _, b := range m.Binds() {
+		l := b.Lambda()
+
+		if _, ok := findFreeVariables(l.Body())[b.Name()]; l.IsThunk() || ok || countExpressions(l.Body()) > maxInlinedFunctionSize {
+			continue
+		}
+
+		fs[b.Name()] = l
+	}
+
+	return inliner{fs, new(int)}
+}
+
+func (i inliner) Inline(m ast.Module) ast.Module {
+	return convertBodies(m, func(l ast.Lambda) ast.Expression {
+		return i.inline(l.Body(), addNames(nil, l.ArgumentNames()...))
+	})
+}
+
+func (i inliner) inline(e ast.Expression, ss map[string]struct{}) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlgebraicAlternative(
+					a.Constructor(),
+					a.ElementNames(),
+					i.inline(a.Expression(), addNames(ss, a.ElementNames()...)),
+				),
+			)
+		}
+
+		return ast.NewAlgebraicCase(i.inline(e.Argument(), ss), as, i.inlineDefaultAlternative(e, ss))
+	case ast.FunctionApplication:
+		return i.inlineApplication(e, ss)
+	case ast.Let:
+		ss = addNames(ss, bindsToNames(e.Binds())...)
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			l := b.Lambda()
+			bs = append(
+				bs,
+				ast.NewBind(
+					b.Name(),
+					newLambda(
+						l,
+						getFreeVariables(l),
+						getArguments(l),
+						i.inline(l.Body(), addNames(ss, l.ArgumentNames()...)),
+					),
+				),
+			)
+		}
+
+		return ast.NewLet(bs, i.inline(e.Expression(), ss))
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), i.inline(a.Expression(), ss)))
+		}
+
+		return ast.NewPrimitiveCase(i.inline(e.Argument(), ss), e.Type(), as, i.inlineDefaultAlternative(e, ss))
+	}
+
+	return e
+}
+
+func (i inliner) inlineDefaultAlternative(c ast.Case, ss map[string]struct{}) ast.DefaultAlternative {
+	a, ok := c.DefaultAlternative()
+
+	if !ok {
+		return ast.DefaultAlternative{}
+	}
+
+	return ast.NewDefaultAlternative(a.Variable(), i.inline(a.Expression(), addNames(ss, a.Variable())))
+}
+
+// inlineApplication replaces a saturated application of a known function with
+// its body. Applications in inlined bodies are not inlined again so that
+// mutually recursive functions are never expanded infinitely.
+func (i inliner) inlineApplication(a ast.FunctionApplication, ss map[string]struct{}) ast.Expression {
+	l, ok := i.functions[a.Function().Name()]
+
+	if _, shadowed := ss[a.Function().Name()]; !ok || shadowed || len(a.Arguments()) != len(l.ArgumentNames()) {
+		return a
+	}
+
+	for s := range removeVariables(findFreeVariables(l.Body()), l.ArgumentNames()...) {
+		if _, ok := ss[s]; ok {
+			return a
+		}
+	}
+
+	vs := make(map[string]string, len(a.Arguments()))
+	ls := map[int]string{}
+
+	for j, x := range a.Arguments() {
+		switch x := x.(type) {
+		case ast.Variable:
+			vs[l.ArgumentNames()[j]] = x.Name()
+		case ast.Literal:
+			ls[j] = i.generateName()
+			vs[l.ArgumentNames()[j]] = ls[j]
+		}
+	}
+
+	// Bound variables are renamed in advance to avoid capturing arguments.
+	e := i.renameBoundVariables(l.Body()).RenameVariables(vs)
+
+	for j := len(a.Arguments()) - 1; j >= 0; j-- {
+		if s, ok := ls[j]; ok {
+			e = ast.NewPrimitiveCase(
+				a.Arguments()[j].(ast.Literal),
+				l.ArgumentTypes()[j].(types.Primitive),
+				nil,
+				ast.NewDefaultAlternative(s, e),
+			)
+		}
+	}
+
+	return e
+}
+
+func (i inliner) renameBoundVariables(e ast.Expression) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			ss, vs := i.generateNames(a.ElementNames())
+			as = append(
+				as,
+				ast.NewAlgebraicAlternative(
+					a.Constructor(),
+					ss,
+					i.renameBoundVariables(a.Expression().RenameVariables(vs)),
+				),
+			)
+		}
+
+		return ast.NewAlgebraicCase(
+			i.renameBoundVariables(e.Argument()),
+			as,
+			i.renameDefaultAlternative(e),
+		)
+	case ast.Let:
+		ss, vs := i.generateNames(bindsToNames(e.Binds()))
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for j, b := range e.Binds() {
+			bs = append(bs, ast.NewBind(ss[j], i.renameLambda(b.Lambda().RenameVariables(vs))))
+		}
+
+		return ast.NewLet(bs, i.renameBoundVariables(e.Expression().RenameVariables(vs)))
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), i.renameBoundVariables(a.Expression())))
+		}
+
+		return ast.NewPrimitiveCase(
+			i.renameBoundVariables(e.Argument()),
+			e.Type(),
+			as,
+			i.renameDefaultAlternative(e),
+		)
+	}
+
+	return e
+}
+
+func (i inliner) renameDefaultAlternative(c ast.Case) ast.DefaultAlternative {
+	a, ok := c.DefaultAlternative()
+
+	if !ok {
+		return ast.DefaultAlternative{}
+	}
+
+	ss, vs := i.generateNames([]string{a.Variable()})
+
+	return ast.NewDefaultAlternative(ss[0], i.renameBoundVariables(a.Expression().RenameVariables(vs)))
+}
+
+func (i inliner) renameLambda(l ast.Lambda) ast.Lambda {
+	ss, vs := i.generateNames(l.ArgumentNames())
+	as := make([]ast.Argument, 0, len(ss))
+
+	for j, s := range ss {
+		as = append(as, ast.NewArgument(s, l.ArgumentTypes()[j]))
+	}
+
+	return newLambda(l, getFreeVariables(l), as, i.renameBoundVariables(l.Body().RenameVariables(vs)))
+}
+
+func (i inliner) generateNames(ss []string) ([]string, map[string]string) {
+	sss := make([]string, 0, len(ss))
+	vs := make(map[string]string, len(ss))
+
+	for _, s := range ss {
+		t := i.generateName()
+		sss = append(sss, t)
+		vs[s] = t
+	}
+
+	return sss, vs
+}
+
+func (i inliner) generateName() string {
+	s := fmt.Sprintf("$inlined-%v", *i.index)
+	*i.index++
+	return s
+}
+
+func countExpressions(e ast.Expression) int {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		n := 1 + countExpressions(e.Argument())
+
+		for _, a := range e.Alternatives() {
+			n += countExpressions(a.Expression())
+		}
+
+		if a, ok := e.DefaultAlternative(); ok {
+			n += countExpressions(a.Expression())
+		}
+
+		return n
+	case ast.Let:
+		n := 1 + countExpressions(e.Expression())
+
+		for _, b := range e.Binds() {
+			n += countExpressions(b.Lambda().Body())
+		}
+
+		return n
+	case ast.PrimitiveCase:
+		n := 1 + countExpressions(e.Argument())
+
+		for _, a := range e.Alternatives() {
+			n += countExpressions(a.Expression())
+		}
+
+		if a, ok := e.DefaultAlternative(); ok {
+			n += countExpressions(a.Expression())
+		}
+
+		return n
+	}
+
+	return 1
+}
@@ -0,0 +1,154 @@
+package optimize
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+func floatLets(m ast.Module) ast.Module {
+	return convertBodies(m, func(l ast.Lambda) ast.Expression {
+		return convertExpression(l.Body(), floatLet)
+	})
+}
+
+// floatLet moves binds of a let expression into the only case alternatives
+// which use them so that closures are allocated only when they are needed.
+func floatLet(e ast.Expression) ast.Expression {
+	l, ok := e.(ast.Let)
+
+	if !ok {
+		return e
+	}
+
+	c, ok := l.Expression().(ast.Case)
+
+	if !ok {
+		return e
+	}
+
+	vs := findFreeVariables(c.Argument())
+
+	for _, b := range l.Binds() {
+		for _, s := range b.Lambda().FreeVariableNames() {
+			if s != b.Name() {
+				vs[s] = struct{}{}
+			}
+		}
+	}
+
+	ess, sss := getAlternatives(c)
+	bss := make([][]ast.Bind, len(ess))
+	bs := []ast.Bind{}
+
+	for _, b := range l.Binds() {
+		if i, ok := findFloatedAlternative(b, vs, ess, sss); ok {
+			bss[i] = append(bss[i], b)
+			continue
+		}
+
+		bs = append(bs, b)
+	}
+
+	if len(bs) == len(l.Binds()) {
+		return e
+	}
+
+	for i, bs := range bss {
+		if len(bs) != 0 {
+			ess[i] = floatLet(ast.NewLet(bs, ess[i]))
+		}
+	}
+
+	c = setAlternatives(c, ess)
+
+	if len(bs) == 0 {
+		return c
+	}
+
+	return ast.NewLet(bs, c)
+}
+
+// findFloatedAlternative finds the only alternative which uses a bind not used
+// by any other expression.
+func findFloatedAlternative(b ast.Bind, vs map[string]struct{}, es []ast.Expression, sss [][]string) (int, bool) {
+	if _, ok := vs[b.Name()]; ok {
+		return 0, false
+	}
+
+	i := -1
+
+	for j, e := range es {
+		if includesName(sss[j], b.Name()) || !isFreeVariable(b.Name(), e) {
+			continue
+		} else if i >= 0 {
+			return 0, false
+		}
+
+		i = j
+	}
+
+	if i < 0 {
+		return 0, false
+	}
+
+	for _, s := range b.Lambda().FreeVariableNames() {
+		if includesName(sss[i], s) {
+			return 0, false
+		}
+	}
+
+	return i, true
+}
+
+// getAlternatives returns expressions and bound variables of alternatives
+// including default ones.
+func getAlternatives(c ast.Case) ([]ast.Expression, [][]string) {
+	es := []ast.Expression{}
+	sss := [][]string{}
+
+	switch c := c.(type) {
+	case ast.AlgebraicCase:
+		for _, a := range c.Alternatives() {
+			es = append(es, a.Expression())
+			sss = append(sss, a.ElementNames())
+		}
+	case ast.PrimitiveCase:
+		for _, a := range c.Alternatives() {
+			es = append(es, a.Expression())
+			sss = append(sss, nil)
+		}
+	}
+
+	if a, ok := c.DefaultAlternative(); ok {
+		es = append(es, a.Expression())
+		sss = append(sss, []string{a.Variable()})
+	}
+
+	return es, sss
+}
+
+func setAlternatives(c ast.Case, es []ast.Expression) ast.Case {
+	d := ast.DefaultAlternative{}
+
+	if a, ok := c.DefaultAlternative(); ok {
+		d = ast.NewDefaultAlternative(a.Variable(), es[len(es)-1])
+	}
+
+	switch c := c.(type) {
+	case ast.AlgebraicCase:
+		as := make([]ast.AlgebraicAlternative, 0, len(c.Alternatives()))
+
+		for i, a := range c.Alternatives() {
+			as = append(as, ast.NewAlgebraicAlternative(a.Constructor(), a.ElementNames(), es[i]))
+		}
+
+		return ast.NewAlgebraicCase(c.Argument(), as, d)
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(c.Alternatives()))
+
+		for i, a := range c.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), es[i]))
+		}
+
+		return ast.NewPrimitiveCase(c.Argument(), c.Type(), as, d)
+	}
+
+	panic("unreachable")
+}
@@ -0,0 +1,108 @@
+package optimize
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+// substituteLiteral substitutes a literal for a primitive variable.
+func substituteLiteral(e ast.Expression, s string, l ast.Literal) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			if !includesName(a.ElementNames(), s) {
+				a = ast.NewAlgebraicAlternative(
+					a.Constructor(),
+					a.ElementNames(),
+					substituteLiteral(a.Expression(), s, l),
+				)
+			}
+
+			as = append(as, a)
+		}
+
+		return ast.NewAlgebraicCase(
+			substituteLiteral(e.Argument(), s, l),
+			as,
+			substituteLiteralInDefaultAlternative(e, s, l),
+		)
+	case ast.ConstructorApplication:
+		return ast.NewConstructorApplication(e.Constructor(), substituteLiteralInAtoms(e.Arguments(), s, l))
+	case ast.FunctionApplication:
+		if e.Function().Name() == s {
+			return l
+		} else if len(e.Arguments()) == 0 {
+			return e
+		}
+
+		return ast.NewFunctionApplication(e.Function(), substituteLiteralInAtoms(e.Arguments(), s, l))
+	case ast.Let:
+		if includesName(bindsToNames(e.Binds()), s) {
+			return e
+		}
+
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			ll := b.Lambda()
+
+			if !includesName(ll.ArgumentNames(), s) {
+				vs := make([]ast.Argument, 0, len(ll.FreeVariableNames()))
+
+				for _, v := range getFreeVariables(ll) {
+					if v.Name() != s {
+						vs = append(vs, v)
+					}
+				}
+
+				ll = newLambda(ll, vs, getArguments(ll), substituteLiteral(ll.Body(), s, l))
+			}
+
+			bs = append(bs, ast.NewBind(b.Name(), ll))
+		}
+
+		return ast.NewLet(bs, substituteLiteral(e.Expression(), s, l))
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), substituteLiteral(a.Expression(), s, l)))
+		}
+
+		return ast.NewPrimitiveCase(
+			substituteLiteral(e.Argument(), s, l),
+			e.Type(),
+			as,
+			substituteLiteralInDefaultAlternative(e, s, l),
+		)
+	case ast.PrimitiveOperation:
+		return ast.NewPrimitiveOperation(e.PrimitiveOperator(), substituteLiteralInAtoms(e.Arguments(), s, l))
+	}
+
+	return e
+}
+
+func substituteLiteralInDefaultAlternative(c ast.Case, s string, l ast.Literal) ast.DefaultAlternative {
+	a, ok := c.DefaultAlternative()
+
+	if !ok {
+		return ast.DefaultAlternative{}
+	} else if a.Variable() == s {
+		return a
+	}
+
+	return ast.NewDefaultAlternative(a.Variable(), substituteLiteral(a.Expression(), s, l))
+}
+
+func substituteLiteralInAtoms(as []ast.Atom, s string, l ast.Literal) []ast.Atom {
+	aas := make([]ast.Atom, 0, len(as))
+
+	for _, a := range as {
+		if v, ok := a.(ast.Variable); ok && v.Name() == s {
+			a = l
+		}
+
+		aas = append(aas, a)
+	}
+
+	return aas
+}
@@ -0,0 +1,17 @@
+package optimize
+
+import "github.com/raviqqe/lazy-ein/command/core/ast"
+
+// Optimize optimizes a module.
+func Optimize(m ast.Module) ast.Module {
+	for _, f := range []func(ast.Module) ast.Module{
+		func(m ast.Module) ast.Module { return newInliner(m).Inline(m) },
+		func(m ast.Module) ast.Module { return newCaseOptimizer(m).Optimize(m) },
+		eliminateDeadLets,
+		floatLets,
+	} {
+		m = f(m)
+	}
+
+	return m
+}
@@ -0,0 +1,411 @@
+package optimize_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/raviqqe/lazy-ein/command/core/optimize"
+	"github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/core/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+var numberType = types.NewAlgebraic(types.NewConstructor(types.NewFloat64()))
+var numberConstructor = ast.NewConstructor(numberType, 0)
+
+func TestOptimizeWithKnownFunctions(t *testing.T) {
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewBoxed(numberType))},
+						ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+						types.NewBoxed(numberType),
+					),
+				),
+				ast.NewBind(
+					"g",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("y", types.NewBoxed(numberType))},
+						ast.NewFunctionApplication(
+							ast.NewVariable("f"),
+							[]ast.Atom{ast.NewVariable("y")},
+						),
+						types.NewBoxed(numberType),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(
+		t,
+		ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+		m.Binds()[1].Lambda().Body(),
+	)
+}
+
+func TestOptimizeWithRecursiveFunctions(t *testing.T) {
+	e := ast.NewAlgebraicCaseWithoutDefault(
+		ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+		[]ast.AlgebraicAlternative{
+			ast.NewAlgebraicAlternative(
+				numberConstructor,
+				[]string{"y"},
+				ast.NewFunctionApplication(ast.NewVariable("f"), []ast.Atom{ast.NewVariable("x")}),
+			),
+		},
+	)
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewBoxed(numberType))},
+						e,
+						types.NewBoxed(numberType),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(t, e, m.Binds()[0].Lambda().Body())
+}
+
+func TestOptimizeWithKnownConstructors(t *testing.T) {
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+						ast.NewLet(
+							[]ast.Bind{
+								ast.NewBind(
+									"y",
+									ast.NewVariableLambda(
+										[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+										ast.NewConstructorApplication(
+											numberConstructor,
+											[]ast.Atom{ast.NewVariable("x")},
+										),
+										numberType,
+									),
+								),
+							},
+							ast.NewAlgebraicCaseWithoutDefault(
+								ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+								[]ast.AlgebraicAlternative{
+									ast.NewAlgebraicAlternative(
+										numberConstructor,
+										[]string{"z"},
+										ast.NewFunctionApplication(ast.NewVariable("z"), nil),
+									),
+								},
+							),
+						),
+						types.NewFloat64(),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(
+		t,
+		ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+		m.Binds()[0].Lambda().Body(),
+	)
+}
+
+func TestOptimizeWithGlobalKnownConstructors(t *testing.T) {
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"x",
+					ast.NewVariableLambda(
+						nil,
+						ast.NewConstructorApplication(numberConstructor, []ast.Atom{ast.NewFloat64(42)}),
+						numberType,
+					),
+				),
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("y", types.NewFloat64())},
+						ast.NewAlgebraicCaseWithoutDefault(
+							ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+							[]ast.AlgebraicAlternative{
+								ast.NewAlgebraicAlternative(
+									numberConstructor,
+									[]string{"z"},
+									ast.NewFunctionApplication(ast.NewVariable("z"), nil),
+								),
+							},
+						),
+						types.NewFloat64(),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(t, ast.NewFloat64(42), m.Binds()[1].Lambda().Body())
+}
+
+func TestOptimizeWithKnownLiterals(t *testing.T) {
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+						ast.NewPrimitiveCase(
+							ast.NewFloat64(42),
+							types.NewFloat64(),
+							[]ast.PrimitiveAlternative{
+								ast.NewPrimitiveAlternative(ast.NewFloat64(42), ast.NewFloat64(1)),
+							},
+							ast.NewDefaultAlternative("y", ast.NewFloat64(0)),
+						),
+						types.NewFloat64(),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(t, ast.NewFloat64(1), m.Binds()[0].Lambda().Body())
+}
+
+func TestOptimizeWithFloatedLets(t *testing.T) {
+	b := ast.NewBind(
+		"y",
+		ast.NewVariableLambda(
+			[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+			ast.NewConstructorApplication(numberConstructor, []ast.Atom{ast.NewVariable("x")}),
+			numberType,
+		),
+	)
+
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+						ast.NewLet(
+							[]ast.Bind{b},
+							ast.NewPrimitiveCase(
+								ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+								types.NewFloat64(),
+								[]ast.PrimitiveAlternative{
+									ast.NewPrimitiveAlternative(
+										ast.NewFloat64(0),
+										ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+									),
+								},
+								ast.NewDefaultAlternative(
+									"z",
+									ast.NewConstructorApplication(
+										numberConstructor,
+										[]ast.Atom{ast.NewVariable("z")},
+									),
+								),
+							),
+						),
+						numberType,
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(
+		t,
+		ast.NewPrimitiveCase(
+			ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+			types.NewFloat64(),
+			[]ast.PrimitiveAlternative{
+				ast.NewPrimitiveAlternative(
+					ast.NewFloat64(0),
+					ast.NewLet(
+						[]ast.Bind{b},
+						ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+					),
+				),
+			},
+			ast.NewDefaultAlternative(
+				"z",
+				ast.NewConstructorApplication(numberConstructor, []ast.Atom{ast.NewVariable("z")}),
+			),
+		),
+		m.Binds()[0].Lambda().Body(),
+	)
+}
+
+func TestOptimizeWithDeadLets(t *testing.T) {
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+						ast.NewLet(
+							[]ast.Bind{
+								ast.NewBind(
+									"y",
+									ast.NewVariableLambda(
+										[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+										ast.NewConstructorApplication(
+											numberConstructor,
+											[]ast.Atom{ast.NewVariable("x")},
+										),
+										numberType,
+									),
+								),
+							},
+							ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+						),
+						types.NewFloat64(),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(
+		t,
+		ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+		m.Binds()[0].Lambda().Body(),
+	)
+}
+
+func TestOptimizeWithConstantFolding(t *testing.T) {
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+						ast.NewPrimitiveCase(
+							ast.NewPrimitiveOperation(
+								ast.AddFloat64,
+								[]ast.Atom{ast.NewFloat64(1), ast.NewFloat64(2)},
+							),
+							types.NewFloat64(),
+							nil,
+							ast.NewDefaultAlternative(
+								"y",
+								ast.NewPrimitiveCase(
+									ast.NewPrimitiveOperation(
+										ast.MultiplyFloat64,
+										[]ast.Atom{ast.NewVariable("y"), ast.NewFloat64(3)},
+									),
+									types.NewFloat64(),
+									nil,
+									ast.NewDefaultAlternative(
+										"z",
+										ast.NewConstructorApplication(
+											numberConstructor,
+											[]ast.Atom{ast.NewVariable("z")},
+										),
+									),
+								),
+							),
+						),
+						numberType,
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(
+		t,
+		ast.NewConstructorApplication(numberConstructor, []ast.Atom{ast.NewFloat64(9)}),
+		m.Binds()[0].Lambda().Body(),
+	)
+}
+
+func TestOptimizeWithCollapsedThunks(t *testing.T) {
+	c := ast.NewConstructorApplication(numberConstructor, []ast.Atom{ast.NewFloat64(42)})
+	m := optimize.Optimize(
+		ast.NewModule(
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"f",
+					ast.NewFunctionLambda(
+						nil,
+						[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+						ast.NewLet(
+							[]ast.Bind{
+								ast.NewBind(
+									"y",
+									ast.NewVariableLambda(
+										nil,
+										ast.NewLet(
+											[]ast.Bind{
+												ast.NewBind("z", ast.NewVariableLambda(nil, c, numberType)),
+											},
+											ast.NewFunctionApplication(ast.NewVariable("z"), nil),
+										),
+										types.NewBoxed(numberType),
+									),
+								),
+							},
+							ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+						),
+						types.NewBoxed(numberType),
+					),
+				),
+			},
+		),
+	)
+
+	assert.Nil(t, validate.Validate(m))
+	assertEqualExpressions(
+		t,
+		ast.NewLet(
+			[]ast.Bind{ast.NewBind("y", ast.NewVariableLambda(nil, c, numberType))},
+			ast.NewFunctionApplication(ast.NewVariable("y"), nil),
+		),
+		m.Binds()[0].Lambda().Body(),
+	)
+}
@@ -0,0 +1,155 @@
+package optimize
+
+import (
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/raviqqe/lazy-ein/command/core/types"
+)
+
+// convertExpression converts expressions from leaves to roots.
+func convertExpression(e ast.Expression, f func(ast.Expression) ast.Expression) ast.Expression {
+	switch e := e.(type) {
+	case ast.AlgebraicCase:
+		as := make([]ast.AlgebraicAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(
+				as,
+				ast.NewAlgebraicAlternative(
+					a.Constructor(),
+					a.ElementNames(),
+					convertExpression(a.Expression(), f),
+				),
+			)
+		}
+
+		return f(
+			ast.NewAlgebraicCase(
+				convertExpression(e.Argument(), f),
+				as,
+				convertDefaultAlternative(e, f),
+			),
+		)
+	case ast.Let:
+		bs := make([]ast.Bind, 0, len(e.Binds()))
+
+		for _, b := range e.Binds() {
+			l := b.Lambda()
+			bs = append(
+				bs,
+				ast.NewBind(
+					b.Name(),
+					newLambda(l, getFreeVariables(l), getArguments(l), convertExpression(l.Body(), f)),
+				),
+			)
+		}
+
+		return f(ast.NewLet(bs, convertExpression(e.Expression(), f)))
+	case ast.PrimitiveCase:
+		as := make([]ast.PrimitiveAlternative, 0, len(e.Alternatives()))
+
+		for _, a := range e.Alternatives() {
+			as = append(as, ast.NewPrimitiveAlternative(a.Literal(), convertExpression(a.Expression(), f)))
+		}
+
+		return f(
+			ast.NewPrimitiveCase(
+				convertExpression(e.Argument(), f),
+				e.Type(),
+				as,
+				convertDefaultAlternative(e, f),
+			),
+		)
+	}
+
+	return f(e)
+}
+
+func convertDefaultAlternative(c ast.Case, f func(ast.Expression) ast.Expression) ast.DefaultAlternative {
+	a, ok := c.DefaultAlternative()
+
+	if !ok {
+		return ast.DefaultAlternative{}
+	}
+
+	return ast.NewDefaultAlternative(a.Variable(), convertExpression(a.Expression(), f))
+}
+
+// convertBodies converts bodies of global binds.
+func convertBodies(m ast.Module, f func(ast.Lambda) ast.Expression) ast.Module {
+	bs := make([]ast.Bind, 0, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		l := b.Lambda()
+		bs = append(bs, ast.NewBind(b.Name(), newLambda(l, nil, getArguments(l), f(l))))
+	}
+
+	return ast.NewModuleWithForeignDeclarations(m.ForeignDeclarations(), m.Declarations(), bs)
+}
+
+func addVariables(vs, vvs map[string]struct{}) map[string]struct{} {
+	for s := range vvs {
+		vs[s] = struct{}{}
+	}
+
+	return vs
+}
+
+func removeVariables(vs map[string]struct{}, ss ...string) map[string]struct{} {
+	for _, s := range ss {
+		delete(vs, s)
+	}
+
+	return vs
+}
+
+func addNames(ss map[string]struct{}, sss ...string) map[string]struct{} {
+	m := make(map[string]struct{}, len(ss)+len(sss))
+
+	for s := range ss {
+		m[s] = struct{}{}
+	}
+
+	for _, s := range sss {
+		m[s] = struct{}{}
+	}
+
+	return m
+}
+
+func bindsToNames(bs []ast.Bind) []string {
+	ss := make([]string, 0, len(bs))
+
+	for _, b := range bs {
+		ss = append(ss, b.Name())
+	}
+
+	return ss
+}
+
+func getFreeVariables(l ast.Lambda) []ast.Argument {
+	as := make([]ast.Argument, 0, len(l.FreeVariableNames()))
+
+	for i, s := range l.FreeVariableNames() {
+		as = append(as, ast.NewArgument(s, l.FreeVariableTypes()[i]))
+	}
+
+	return as
+}
+
+func getArguments(l ast.Lambda) []ast.Argument {
+	as := make([]ast.Argument, 0, len(l.ArgumentNames()))
+
+	for i, s := range l.ArgumentNames() {
+		as = append(as, ast.NewArgument(s, l.ArgumentTypes()[i]))
+	}
+
+	return as
+}
+
+func newLambda(l ast.Lambda, vs []ast.Argument, as []ast.Argument, e ast.Expression) ast.Lambda {
+	if len(as) == 0 {
+		return ast.NewVariableLambda(vs, e, l.ResultType().(types.Bindable))
+	}
+
+	return ast.NewFunctionLambda(vs, as, e, l.ResultType())
+}
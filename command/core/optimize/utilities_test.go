@@ -0,0 +1,14 @@
+package optimize_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/core/ast"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertEqualExpressions asserts that expressions are equal regardless of
+// whether empty arguments of function applications are nil or not.
+func assertEqualExpressions(t *testing.T, e, ee ast.Expression) {
+	assert.Equal(t, e.RenameVariables(nil), ee.RenameVariables(nil))
+}
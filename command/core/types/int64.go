@@ -0,0 +1,25 @@
+package types
+
+// Int64 is a 64-bit integer type.
+type Int64 struct{}
+
+// NewInt64 creates a 64-bit integer type.
+func NewInt64() Int64 {
+	return Int64{}
+}
+
+// ConvertTypes converts types.
+func (i Int64) ConvertTypes(f func(Type) Type) Type {
+	return f(i)
+}
+
+func (Int64) String() string {
+	return "i64"
+}
+
+func (Int64) equal(t Type) bool {
+	_, ok := t.(Int64)
+	return ok
+}
+
+func (Int64) isPrimitive() {}
@@ -0,0 +1,12 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInt64Equal(t *testing.T) {
+	assert.True(t, NewInt64().equal(NewInt64()))
+	assert.False(t, NewInt64().equal(NewFunction([]Type{NewInt64()}, NewInt64())))
+}
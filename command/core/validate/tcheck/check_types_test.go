@@ -115,6 +115,52 @@ func TestCheckTypes(t *testing.T) {
 				),
 			),
 		},
+		// Integer primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewPrimitiveOperation(
+						ast.AddInt64,
+						[]ast.Atom{ast.NewInt64(42), ast.NewInt64(42)},
+					),
+					types.NewInt64(),
+				),
+			),
+		},
+		// Conversion primitive operations
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewPrimitiveOperation(ast.Float64ToInt64, []ast.Atom{ast.NewVariable("x")}),
+					types.NewInt64(),
+				),
+			),
+		},
+		// Integer primitive case expressions
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveCase(
+						ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+						types.NewInt64(),
+						[]ast.PrimitiveAlternative{
+							ast.NewPrimitiveAlternative(ast.NewInt64(42), ast.NewInt64(0)),
+						},
+						ast.NewDefaultAlternative("y", ast.NewInt64(1)),
+					),
+					types.NewInt64(),
+				),
+			),
+		},
 		// Primitive case expressions composed only of default alternatives
 		{
 			ast.NewBind(
@@ -280,6 +326,40 @@ func TestCheckTypesError(t *testing.T) {
 				),
 			),
 		},
+		// Wrong primitive operation argument types
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+					ast.NewPrimitiveOperation(
+						ast.AddInt64,
+						[]ast.Atom{ast.NewVariable("x"), ast.NewInt64(42)},
+					),
+					types.NewInt64(),
+				),
+			),
+		},
+		// Wrong primitive alternative literal types
+		{
+			ast.NewBind(
+				"f",
+				ast.NewFunctionLambda(
+					nil,
+					[]ast.Argument{ast.NewArgument("x", types.NewInt64())},
+					ast.NewPrimitiveCase(
+						ast.NewFunctionApplication(ast.NewVariable("x"), nil),
+						types.NewInt64(),
+						[]ast.PrimitiveAlternative{
+							ast.NewPrimitiveAlternative(ast.NewFloat64(42), ast.NewInt64(0)),
+						},
+						ast.NewDefaultAlternative("y", ast.NewInt64(1)),
+					),
+					types.NewInt64(),
+				),
+			),
+		},
 		// Wrong function argument types
 		{
 			ast.NewBind(
@@ -421,3 +501,35 @@ func TestCheckTypesWithDeclarations(t *testing.T) {
 		),
 	)
 }
+
+func TestCheckTypesWithForeignDeclarations(t *testing.T) {
+	assert.Nil(
+		t,
+		tcheck.CheckTypes(
+			ast.NewModuleWithForeignDeclarations(
+				[]ast.ForeignDeclaration{
+					ast.NewForeignDeclaration(
+						"f",
+						"sin",
+						types.NewFunction([]types.Type{types.NewFloat64()}, types.NewFloat64()),
+					),
+				},
+				nil,
+				[]ast.Bind{
+					ast.NewBind(
+						"g",
+						ast.NewFunctionLambda(
+							nil,
+							[]ast.Argument{ast.NewArgument("x", types.NewFloat64())},
+							ast.NewFunctionApplication(
+								ast.NewVariable("f"),
+								[]ast.Atom{ast.NewVariable("x")},
+							),
+							types.NewFloat64(),
+						),
+					),
+				},
+			),
+		),
+	)
+}
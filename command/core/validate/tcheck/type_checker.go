@@ -16,7 +16,9 @@ func newTypeChecker() typeChecker {
 }
 
 func (c typeChecker) Check(m ast.Module) error {
-	_, err := c.addDeclarations(m.Declarations()).checkBinds(m.Binds())
+	_, err := c.addForeignDeclarations(m.ForeignDeclarations()).
+		addDeclarations(m.Declarations()).
+		checkBinds(m.Binds())
 	return err
 }
 
@@ -187,11 +189,13 @@ func (c typeChecker) checkPrimitiveCase(pc ast.PrimitiveCase) (types.Type, error
 		return nil, err
 	}
 
-	for _, a := range pc.Alternatives()[1:] {
+	for _, a := range pc.Alternatives() {
 		if err := c.checkTypes(c.getLiteralType(a.Literal()), types.Unbox(pc.Type())); err != nil {
 			return nil, err
 		}
+	}
 
+	for _, a := range pc.Alternatives()[1:] {
 		if tt, err := c.checkExpression(a.Expression()); err != nil {
 			return nil, err
 		} else if err := c.checkTypes(t, tt); err != nil {
@@ -209,17 +213,50 @@ func (c typeChecker) checkPrimitiveCase(pc ast.PrimitiveCase) (types.Type, error
 }
 
 func (c typeChecker) checkPrimitiveOperation(o ast.PrimitiveOperation) (types.Type, error) {
-	for _, a := range o.Arguments() {
-		t, err := c.checkAtom(a)
+	ts, t := getPrimitiveOperatorType(o.PrimitiveOperator())
+
+	if len(o.Arguments()) != len(ts) {
+		return nil, errors.New("invalid number of arguments to a primitive operation")
+	}
+
+	for i, a := range o.Arguments() {
+		tt, err := c.checkAtom(a)
 
 		if err != nil {
 			return nil, err
-		} else if err := c.checkTypes(t, types.NewFloat64()); err != nil {
+		} else if err := c.checkTypes(tt, ts[i]); err != nil {
 			return nil, err
 		}
 	}
 
-	return types.NewFloat64(), nil
+	return t, nil
+}
+
+func getPrimitiveOperatorType(o ast.PrimitiveOperator) ([]types.Type, types.Type) {
+	f, i := types.NewFloat64(), types.NewInt64()
+
+	switch o {
+	case ast.Float64ToInt64:
+		return []types.Type{f}, i
+	case ast.Int64ToFloat64:
+		return []types.Type{i}, f
+	case ast.AddInt64,
+		ast.SubtractInt64,
+		ast.MultiplyInt64,
+		ast.DivideInt64,
+		ast.ModuloInt64,
+		ast.RemainderInt64,
+		ast.PowerInt64,
+		ast.EqualInt64,
+		ast.NotEqualInt64,
+		ast.LessThanInt64,
+		ast.LessThanOrEqualInt64,
+		ast.GreaterThanInt64,
+		ast.GreaterThanOrEqualInt64:
+		return []types.Type{i, i}, i
+	}
+
+	return []types.Type{f, f}, f
 }
 
 func (c typeChecker) checkDefaultAlternative(d ast.DefaultAlternative, t types.Type) (types.Type, error) {
@@ -247,8 +284,10 @@ func (c typeChecker) checkAtom(a ast.Atom) (types.Type, error) {
 
 func (c typeChecker) getLiteralType(l ast.Literal) types.Type {
 	switch l.(type) {
-	case ast.Literal:
+	case ast.Float64:
 		return types.NewFloat64()
+	case ast.Int64:
+		return types.NewInt64()
 	}
 
 	panic("unreachable")
@@ -278,6 +317,20 @@ func (c typeChecker) addArguments(l ast.Lambda) typeChecker {
 	return typeChecker{vs}
 }
 
+func (c typeChecker) addForeignDeclarations(ds []ast.ForeignDeclaration) typeChecker {
+	vs := make(map[string]types.Type, len(c.variables)+len(ds))
+
+	for k, v := range c.variables {
+		vs[k] = v
+	}
+
+	for _, d := range ds {
+		vs[d.Name()] = d.Type()
+	}
+
+	return typeChecker{vs}
+}
+
 func (c typeChecker) addDeclarations(ds []ast.Declaration) typeChecker {
 	vs := make(map[string]types.Type, len(c.variables)+len(ds))
 
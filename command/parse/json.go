@@ -0,0 +1,251 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/debug"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+var jsonNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+// parseJSON parses a JSON file as a module which exports fields of a top-level
+// object as typed constants. Keys of objects are converted into camel case
+// names and words in them are separated by '_' or '-'.
+func parseJSON(s string, n ast.ModuleName) (ast.Module, error) {
+	var x interface{}
+
+	if err := json.Unmarshal([]byte(s), &x); err != nil {
+		return ast.Module{}, newJSONError(err, s, n)
+	}
+
+	d := newJSONDecoder(s, n)
+	i := d.debugInformation()
+
+	if t, err := d.decoder.Token(); err != nil {
+		return ast.Module{}, newJSONError(err, s, n)
+	} else if t != json.Delim('{') {
+		return ast.Module{}, newError("top-level JSON value must be an object", i)
+	}
+
+	es, ts, err := d.decodeFields()
+
+	if err != nil {
+		return ast.Module{}, err
+	}
+
+	ss := sortedJSONKeys(es)
+	bs := make([]ast.Bind, 0, len(ss))
+
+	for _, s := range ss {
+		bs = append(bs, ast.NewBind(s, ts[s], es[s]))
+	}
+
+	return ast.NewModule(n, ast.NewExport(ss...), nil, nil, bs).WithoutPrelude(), nil
+}
+
+// jsonDecoder decodes JSON values keeping track of their positions in a
+// source.
+type jsonDecoder struct {
+	decoder *json.Decoder
+	source  string
+	module  ast.ModuleName
+}
+
+func newJSONDecoder(s string, n ast.ModuleName) jsonDecoder {
+	return jsonDecoder{json.NewDecoder(strings.NewReader(s)), s, n}
+}
+
+func (d jsonDecoder) decodeValue() (ast.Expression, types.Type, error) {
+	i := d.debugInformation()
+	t, err := d.decoder.Token()
+
+	if err != nil {
+		return nil, nil, newJSONError(err, d.source, d.module)
+	}
+
+	switch t := t.(type) {
+	case bool:
+		return ast.NewBoolean(t), types.NewBoolean(nil), nil
+	case float64:
+		return ast.NewNumberWithType(t, types.NewNumber(nil)), types.NewNumber(nil), nil
+	case string:
+		return ast.NewString(t), types.NewString(nil), nil
+	case json.Delim:
+		if t == '[' {
+			return d.decodeArray(i)
+		}
+
+		es, ts, err := d.decodeFields()
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fs := make([]ast.RecordField, 0, len(es))
+
+		for _, s := range sortedJSONKeys(es) {
+			fs = append(fs, ast.NewRecordField(s, es[s]))
+		}
+
+		return ast.NewRecord(types.NewUnknown(nil), fs), types.NewRecord(ts, nil), nil
+	}
+
+	return nil, nil, newError("null is not supported in JSON files", i)
+}
+
+// decodeArray decodes a JSON array into a list. Its elements must be of the
+// same type.
+func (d jsonDecoder) decodeArray(i *debug.Information) (ast.Expression, types.Type, error) {
+	as := []ast.ListArgument{}
+	t := types.Type(nil)
+
+	for d.decoder.More() {
+		ii := d.debugInformation()
+		e, tt, err := d.decodeValue()
+
+		if err != nil {
+			return nil, nil, err
+		} else if t == nil {
+			t = tt
+		} else if es, err := t.Unify(tt); err != nil || len(es) != 0 {
+			return nil, nil, newError("elements of JSON arrays must be of the same type", ii)
+		}
+
+		as = append(as, ast.NewListArgument(e, false))
+	}
+
+	if _, err := d.decoder.Token(); err != nil {
+		return nil, nil, newJSONError(err, d.source, d.module)
+	} else if len(as) == 0 {
+		return nil, nil, newError("element types of empty JSON arrays cannot be inferred", i)
+	}
+
+	return ast.NewList(types.NewUnknown(nil), as), types.NewList(t, nil), nil
+}
+
+// decodeFields decodes fields of a JSON object whose opening brace is already
+// consumed.
+func (d jsonDecoder) decodeFields() (map[string]ast.Expression, map[string]types.Type, error) {
+	es := map[string]ast.Expression{}
+	ts := map[string]types.Type{}
+	ks := map[string]string{}
+
+	for d.decoder.More() {
+		i := d.debugInformation()
+		t, err := d.decoder.Token()
+
+		if err != nil {
+			return nil, nil, newJSONError(err, d.source, d.module)
+		}
+
+		k := t.(string)
+		s, err := jsonKeyToName(k, i)
+
+		if err != nil {
+			return nil, nil, err
+		} else if kk, ok := ks[s]; ok && kk != k {
+			return nil, nil, newError(
+				fmt.Sprintf("keys '%v' and '%v' in JSON file are converted into the same name", kk, k),
+				i,
+			)
+		}
+
+		ks[s] = k
+
+		e, tt, err := d.decodeValue()
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		es[s] = e
+		ts[s] = tt
+	}
+
+	if _, err := d.decoder.Token(); err != nil {
+		return nil, nil, newJSONError(err, d.source, d.module)
+	}
+
+	return es, ts, nil
+}
+
+// debugInformation returns debug information of a next token skipping
+// separators before it.
+func (d jsonDecoder) debugInformation() *debug.Information {
+	o := d.decoder.InputOffset()
+
+	for o < int64(len(d.source)) && strings.ContainsRune(" \t\r\n,:", rune(d.source[o])) {
+		o++
+	}
+
+	return newJSONDebugInformation(d.source, d.module, o)
+}
+
+// jsonKeyToName converts a key of a JSON object into a camel case name.
+func jsonKeyToName(k string, i *debug.Information) (string, error) {
+	ss := strings.FieldsFunc(k, func(r rune) bool { return r == '_' || r == '-' })
+
+	for i := 1; i < len(ss); i++ {
+		ss[i] = strings.ToUpper(ss[i][:1]) + ss[i][1:]
+	}
+
+	s := strings.Join(ss, "")
+
+	if !jsonNamePattern.MatchString(s) {
+		return "", newError(
+			fmt.Sprintf(
+				"invalid key '%v' in JSON file: keys must consist of alphanumeric words "+
+					"separated by '_' or '-' and start with letters",
+				k,
+			),
+			i,
+		)
+	} else if _, ok := keywords[keyword(s)]; ok {
+		return "", newError(fmt.Sprintf("key '%v' in JSON file is a keyword", k), i)
+	}
+
+	return s, nil
+}
+
+func sortedJSONKeys(es map[string]ast.Expression) []string {
+	ss := make([]string, 0, len(es))
+
+	for s := range es {
+		ss = append(ss, s)
+	}
+
+	sort.Strings(ss)
+
+	return ss
+}
+
+func newJSONError(err error, s string, n ast.ModuleName) error {
+	// Offsets of syntax errors are right after invalid characters.
+	if e, ok := err.(*json.SyntaxError); ok && e.Offset > 0 {
+		return newError(e.Error(), newJSONDebugInformation(s, n, e.Offset-1))
+	}
+
+	return newError(err.Error(), nil)
+}
+
+func newJSONDebugInformation(s string, n ast.ModuleName, o int64) *debug.Information {
+	if o > int64(len(s)) {
+		o = int64(len(s))
+	}
+
+	l := strings.Count(s[:o], "\n")
+	i := strings.LastIndex(s[:o], "\n") + 1
+
+	return debug.NewInformation(
+		string(n),
+		l+1,
+		len([]rune(s[i:o]))+1,
+		strings.Split(s, "\n")[l],
+	)
+}
@@ -0,0 +1,129 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/debug"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSON(t *testing.T) {
+	m, err := parseJSON(
+		`{ "foo": 42, "bar": ["baz"], "qux": { "flag": true } }`,
+		"config.json",
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		ast.NewModule(
+			"config.json",
+			ast.NewExport("bar", "foo", "qux"),
+			nil,
+			nil,
+			[]ast.Bind{
+				ast.NewBind(
+					"bar",
+					types.NewList(types.NewString(nil), nil),
+					ast.NewList(
+						types.NewUnknown(nil),
+						[]ast.ListArgument{ast.NewListArgument(ast.NewString("baz"), false)},
+					),
+				),
+				ast.NewBind(
+					"foo",
+					types.NewNumber(nil),
+					ast.NewNumberWithType(42, types.NewNumber(nil)),
+				),
+				ast.NewBind(
+					"qux",
+					types.NewRecord(map[string]types.Type{"flag": types.NewBoolean(nil)}, nil),
+					ast.NewRecord(
+						types.NewUnknown(nil),
+						[]ast.RecordField{ast.NewRecordField("flag", ast.NewBoolean(true))},
+					),
+				),
+			},
+		).WithoutPrelude(),
+		m,
+	)
+}
+
+func TestParseJSONWithKeys(t *testing.T) {
+	m, err := parseJSON(
+		`{ "snake_case": 1, "kebab-case": 2, "_private": 3, "foo": { "bar_baz": 4 } }`,
+		"config.json",
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"foo", "kebabCase", "private", "snakeCase"}, m.Export().Names())
+	assert.Equal(
+		t,
+		types.NewRecord(map[string]types.Type{"barBaz": types.NewNumber(nil)}, nil),
+		m.Binds()[0].Type(),
+	)
+}
+
+func TestParseJSONError(t *testing.T) {
+	for _, c := range []struct {
+		source, message, debugInformation string
+	}{
+		{"42", "top-level JSON value must be an object", "config.json:1:1:\t42"},
+		{
+			`{ "foo": null }`,
+			"null is not supported",
+			"config.json:1:10:\t{ \"foo\": null }",
+		},
+		{
+			`{ "foo": [] }`,
+			"element types of empty JSON arrays cannot be inferred",
+			"config.json:1:10:\t{ \"foo\": [] }",
+		},
+		{
+			`{ "foo": [42, "bar"] }`,
+			"elements of JSON arrays must be of the same type",
+			"config.json:1:15:\t{ \"foo\": [42, \"bar\"] }",
+		},
+		{
+			`{ "foo": [{ "bar": 42 }, { "baz": 42 }] }`,
+			"elements of JSON arrays must be of the same type",
+			"config.json:1:26:\t{ \"foo\": [{ \"bar\": 42 }, { \"baz\": 42 }] }",
+		},
+		{
+			`{ "foo bar": 42 }`,
+			"invalid key 'foo bar'",
+			"config.json:1:3:\t{ \"foo bar\": 42 }",
+		},
+		{`{ "_": 42 }`, "invalid key '_'", "config.json:1:3:\t{ \"_\": 42 }"},
+		{`{ "case": 42 }`, "key 'case' in JSON file is a keyword", "config.json:1:3:\t{ \"case\": 42 }"},
+		{
+			`{ "foo_bar": 1, "fooBar": 2 }`,
+			"keys 'foo_bar' and 'fooBar' in JSON file are converted into the same name",
+			"config.json:1:17:\t{ \"foo_bar\": 1, \"fooBar\": 2 }",
+		},
+		{
+			"{\n  \"foo\": {\n    \"42\": 42\n  }\n}",
+			"invalid key '42'",
+			"config.json:3:5:\t    \"42\": 42",
+		},
+	} {
+		_, err := parseJSON(c.source, "config.json")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), c.message)
+		assert.Equal(t, c.debugInformation, err.(debug.Error).DebugInformation().String())
+	}
+}
+
+func TestParseJSONSyntaxError(t *testing.T) {
+	_, err := parseJSON("{\n  \"foo\": 42,\n}", "config.json")
+
+	assert.Error(t, err)
+	assert.Equal(
+		t,
+		"config.json:3:1:\t}",
+		err.(debug.Error).DebugInformation().String(),
+	)
+}
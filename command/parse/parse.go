@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"path"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/raviqqe/lazy-ein/command/ast"
 	"github.com/raviqqe/lazy-ein/command/debug"
@@ -16,45 +19,78 @@ import (
 type sign string
 
 const (
-	bindSign               sign = "="
-	commaSign                   = ","
-	ellipsisSign                = "..."
-	typeDefinitionSign          = ":"
-	mapSign                     = "->"
-	openParenthesisSign         = "("
-	closeParenthesisSign        = ")"
-	openBraceSign               = "{"
-	closeBraceSign              = "}"
-	openBracketSign             = "["
-	closeBracketSign            = "]"
-	doubleQuoteSign             = "\""
-	additionOperator            = sign(ast.Add)
-	subtractionOperator         = sign(ast.Subtract)
-	multiplicationOperator      = sign(ast.Multiply)
-	divisionOperator            = sign(ast.Divide)
+	bindSign                   sign = "="
+	commaSign                       = ","
+	ellipsisSign                    = "..."
+	typeDefinitionSign              = ":"
+	mapSign                         = "->"
+	openParenthesisSign             = "("
+	closeParenthesisSign            = ")"
+	openBraceSign                   = "{"
+	closeBraceSign                  = "}"
+	openBracketSign                 = "["
+	closeBracketSign                = "]"
+	doubleQuoteSign                 = "\""
+	verticalBarSign                 = "|"
+	backslashSign                   = "\\"
+	lineCommentSign                 = "--"
+	openBlockCommentSign            = "{-"
+	closeBlockCommentSign           = "-}"
+	additionOperator                = sign(ast.Add)
+	subtractionOperator             = sign(ast.Subtract)
+	multiplicationOperator          = sign(ast.Multiply)
+	divisionOperator                = sign(ast.Divide)
+	moduloOperator                  = sign(ast.Modulo)
+	powerOperator                   = sign(ast.Power)
+	equalOperator                   = sign(ast.Equal)
+	notEqualOperator                = sign(ast.NotEqual)
+	lessThanOperator                = sign(ast.LessThan)
+	lessThanOrEqualOperator         = sign(ast.LessThanOrEqual)
+	greaterThanOperator             = sign(ast.GreaterThan)
+	greaterThanOrEqualOperator      = sign(ast.GreaterThanOrEqual)
 )
 
 type keyword string
 
 const (
-	caseKeyword   keyword = "case"
-	exportKeyword         = "export"
-	importKeyword         = "import"
-	inKeyword             = "in"
-	letKeyword            = "let"
-	ofKeyword             = "of"
+	asKeyword      keyword = "as"
+	caseKeyword            = "case"
+	elseKeyword            = "else"
+	exportKeyword          = "export"
+	falseKeyword           = "False"
+	foreignKeyword         = "foreign"
+	ifKeyword              = "if"
+	importKeyword          = "import"
+	inKeyword              = "in"
+	letKeyword             = "let"
+	moduleKeyword          = "module"
+	ofKeyword              = "of"
+	preludeKeyword         = "prelude"
+	thenKeyword            = "then"
+	trueKeyword            = "True"
+	typeKeyword            = "type"
 )
 
 var keywords = map[keyword]struct{}{
-	caseKeyword:   {},
-	exportKeyword: {},
-	importKeyword: {},
-	inKeyword:     {},
-	letKeyword:    {},
-	ofKeyword:     {},
+	asKeyword:      {},
+	caseKeyword:    {},
+	elseKeyword:    {},
+	exportKeyword:  {},
+	falseKeyword:   {},
+	foreignKeyword: {},
+	ifKeyword:      {},
+	importKeyword:  {},
+	inKeyword:      {},
+	letKeyword:     {},
+	moduleKeyword:  {},
+	ofKeyword:      {},
+	preludeKeyword: {},
+	thenKeyword:    {},
+	trueKeyword:    {},
+	typeKeyword:    {},
 }
 
-// Parse parses a module file.
+// Parse parses a module file. JSON files are parsed as modules of constants.
 func Parse(f, rootDir string) (ast.Module, error) {
 	bs, err := ioutil.ReadFile(f)
 
@@ -66,6 +102,8 @@ func Parse(f, rootDir string) (ast.Module, error) {
 
 	if err != nil {
 		return ast.Module{}, err
+	} else if n.IsJSON() {
+		return parseJSON(string(bs), n)
 	}
 
 	return parse(string(bs), n)
@@ -96,39 +134,90 @@ func (s *state) module(n ast.ModuleName) parcom.Parser {
 	return s.App(
 		func(x interface{}) (interface{}, error) {
 			xs := x.([]interface{})
-			e := ast.NewExport()
+			ss := []string(nil)
+			ns := []ast.ModuleName(nil)
+			i := (*debug.Information)(nil)
 
-			if ee, ok := xs[0].(ast.Export); ok {
-				e = ee
+			for _, x := range xs[0].([]interface{}) {
+				e := x.(ast.Export)
+				ss = append(ss, e.Names()...)
+				ns = append(ns, e.Modules()...)
+
+				if i == nil {
+					i = e.DebugInformation()
+				}
 			}
 
+			e := ast.NewExportWithModules(ns, ss...).WithDebugInformation(i)
 			ys := xs[1].([]interface{})
 			is := make([]ast.Import, 0, len(ys))
+			p := true
 
 			for _, y := range ys {
-				is = append(is, y.(ast.Import))
+				if i, ok := y.(ast.Import); ok {
+					is = append(is, i)
+				} else {
+					p = false
+				}
 			}
 
 			zs := xs[2].([]interface{})
+			ds := []ast.TypeDefinition{}
 			bs := make([]ast.Bind, 0, len(zs))
 
 			for _, z := range zs {
-				bs = append(bs, z.(ast.Bind))
+				switch z := z.(type) {
+				case ast.TypeDefinition:
+					ds = append(ds, z)
+				case ast.Bind:
+					bs = append(bs, z)
+				}
+			}
+
+			x, err := resolveTypes(ast.NewModule(n, e, is, ds, bs))
+
+			if err != nil {
+				return nil, err
+			} else if !p {
+				return x.(ast.Module).WithoutPrelude(), nil
 			}
 
-			return ast.NewModule(n, e, is, bs), nil
+			return x, nil
 		},
 		s.Exhaust(
-			s.HeteroBlock(
-				s.Maybe(s.export()),
-				s.Block(s.importModule()),
-				s.ExhaustiveBlock(s.bind()),
+			s.Prefix(
+				s.And(s.blank(), s.noIndent()),
+				s.HeteroBlock(
+					s.Block(s.WithPosition(s.Or(s.exportModule(), s.export()))),
+					s.Block(s.Or(s.importPrelude(), s.importModule())),
+					s.ExhaustiveBlock(s.Or(s.typeDefinition(), s.foreignBind(), s.bind())),
+				),
 			),
 		),
 	)
 }
 
 func (s *state) export() parcom.Parser {
+	return s.withDebugInformation(
+		s.Prefix(s.keyword(exportKeyword), s.names()),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			return ast.NewExport(x.([]string)...).WithDebugInformation(i), nil
+		},
+	)
+}
+
+func (s *state) exportModule() parcom.Parser {
+	return s.withDebugInformation(
+		s.Prefix(s.And(s.keyword(exportKeyword), s.keyword(moduleKeyword)), s.rawStringLiteral()),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			return ast.NewExportWithModules(
+				[]ast.ModuleName{ast.ModuleName(path.Clean(x.(string)))},
+			).WithDebugInformation(i), nil
+		},
+	)
+}
+
+func (s *state) names() parcom.Parser {
 	return s.App(
 		func(x interface{}) (interface{}, error) {
 			xs := x.([]interface{})
@@ -143,27 +232,145 @@ func (s *state) export() parcom.Parser {
 				ss = append(ss, s)
 			}
 
-			return ast.NewExport(ss...), nil
+			return ss, nil
 		},
 		s.Wrap(
-			s.And(s.keyword(exportKeyword), s.sign(openBraceSign)),
+			s.sign(openBraceSign),
 			s.And(s.Many(s.Suffix(s.identifier(), s.sign(commaSign))), s.Maybe(s.identifier())),
 			s.sign(closeBraceSign),
 		),
 	)
 }
 
+// importPrelude parses an import of nothing from the prelude module which
+// opts out of its implicit import.
+func (s *state) importPrelude() parcom.Parser {
+	return s.WithPosition(
+		s.And(
+			s.keyword(importKeyword),
+			s.keyword(preludeKeyword),
+			s.sign(openBraceSign),
+			s.sign(closeBraceSign),
+		),
+	)
+}
+
 func (s *state) importModule() parcom.Parser {
+	return s.withDebugInformation(
+		s.WithPosition(
+			s.And(
+				s.Prefix(s.keyword(importKeyword), s.rawStringLiteral()),
+				s.Maybe(s.Or(s.Prefix(s.keyword(asKeyword), s.identifier()), s.names())),
+			),
+		),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			xs := x.([]interface{})
+			n := ast.ModuleName(path.Clean(xs[0].(string)))
+
+			switch x := xs[1].(type) {
+			case string:
+				if strings.Contains(x, ".") {
+					return nil, newError(fmt.Sprintf("invalid import alias '%v'", x), i)
+				}
+
+				return ast.NewAliasedImport(n, x, i), nil
+			case []string:
+				for _, s := range x {
+					if strings.Contains(s, ".") {
+						return nil, newError(fmt.Sprintf("invalid imported name '%v'", s), i)
+					}
+				}
+
+				return ast.NewSelectiveImport(n, x, i), nil
+			}
+
+			return ast.NewImport(n, i), nil
+		},
+	)
+}
+
+func (s *state) typeDefinition() parcom.Parser {
+	return s.withDebugInformation(
+		s.WithPosition(
+			s.And(
+				s.keyword(typeKeyword),
+				s.capitalizedIdentifier(),
+				s.sign(bindSign),
+				s.constructorDefinition(),
+				s.Many(s.Prefix(s.sign(verticalBarSign), s.constructorDefinition())),
+			),
+		),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			xs := x.([]interface{})
+			ys := xs[4].([]interface{})
+			cs := make([]types.Constructor, 0, len(ys)+1)
+			cs = append(cs, xs[3].(types.Constructor))
+
+			for _, y := range ys {
+				cs = append(cs, y.(types.Constructor))
+			}
+
+			n := xs[1].(string)
+
+			return ast.NewTypeDefinition(n, types.NewAlgebraic(n, cs, i)), nil
+		},
+	)
+}
+
+func (s *state) constructorDefinition() parcom.Parser {
 	return s.App(
 		func(x interface{}) (interface{}, error) {
-			return ast.NewImport(ast.ModuleName(x.(string))), nil
+			xs := x.([]interface{})
+			ys := xs[1].([]interface{})
+			ts := make([]types.Type, 0, len(ys))
+
+			for _, y := range ys {
+				ts = append(ts, y.(types.Type))
+			}
+
+			return types.NewConstructor(xs[0].(string), ts), nil
 		},
-		s.WithPosition(s.Prefix(s.keyword(importKeyword), s.rawStringLiteral())),
+		s.And(s.capitalizedIdentifier(), s.Many(s.argumentType())),
 	)
 }
 
+// foreignBind parses a bind of a function imported from a foreign language.
+func (s *state) foreignBind() parcom.Parser {
+	return s.withComment(s.withDebugInformation(
+		s.WithPosition(
+			s.And(
+				s.Prefix(s.And(s.keyword(foreignKeyword), s.keyword(importKeyword)), s.rawStringLiteral()),
+				s.identifier(),
+				s.sign(typeDefinitionSign),
+				s.typ(),
+			),
+		),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			xs := x.([]interface{})
+			n := xs[1].(string)
+
+			if strings.Contains(n, ".") {
+				return nil, newError(fmt.Sprintf("invalid foreign function name '%v'", n), i)
+			}
+
+			return ast.NewBind(n, xs[3].(types.Type), ast.NewForeignFunction(xs[0].(string))), nil
+		},
+	))
+}
+
+// bind parses a top-level bind whose type signature is optional.
 func (s *state) bind() parcom.Parser {
-	return s.withDebugInformation(
+	return func() (interface{}, error) {
+		if s.lookAhead(s.WithPosition(s.And(s.identifier(), s.sign(typeDefinitionSign)))) {
+			return s.typedBind()()
+		}
+
+		return s.untypedBind()()
+	}
+}
+
+func (s *state) typedBind() parcom.Parser {
+	return s.withComment(s.withDebugInformation(
 		s.HeteroBlock(
 			s.WithPosition(s.And(s.identifier(), s.sign(typeDefinitionSign), s.typ())),
 			s.WithPosition(s.And(s.identifier(), s.arguments(), s.sign(bindSign), s.expression())),
@@ -188,7 +395,7 @@ func (s *state) bind() parcom.Parser {
 
 			return ast.NewBind(zs[0].(string), ys[2].(types.Type), e), nil
 		},
-	)
+	))
 }
 
 func (s *state) arguments() parcom.Parser {
@@ -231,11 +438,15 @@ func (s *state) expressionWithOptions(b, a bool) parcom.Parser {
 			ps,
 			s.numberLiteral(),
 			s.listLiteral(s.expression()),
-			// TODO: Add string literal.
+			s.recordLiteral(),
+			s.stringLiteral(),
+			s.booleanLiteral(),
+			s.lambda(),
 			s.let(),
+			s.ifThenElse(),
 			s.caseOf(),
 			s.variable(),
-			s.parenthesesed(s.expression()),
+			s.tupleLiteralOrParenthesesed(),
 		)...,
 	)
 }
@@ -249,6 +460,10 @@ func (s *state) numberLiteral() parcom.Parser {
 				return nil, err
 			}
 
+			if strings.Contains(x.(string), ".") {
+				return ast.NewNumberWithType(n, types.NewNumber(nil)), nil
+			}
+
 			return ast.NewNumber(n), nil
 		},
 		s.token(
@@ -266,6 +481,15 @@ func (s *state) numberLiteral() parcom.Parser {
 	)
 }
 
+func (s *state) booleanLiteral() parcom.Parser {
+	return s.App(
+		func(x interface{}) (interface{}, error) {
+			return ast.NewBoolean(x.(string) == string(trueKeyword)), nil
+		},
+		s.Or(s.keyword(trueKeyword), s.keyword(falseKeyword)),
+	)
+}
+
 func (s *state) listLiteral(p parcom.Parser) parcom.Parser {
 	p = s.listArgument(p)
 
@@ -299,6 +523,121 @@ func (s *state) listLiteral(p parcom.Parser) parcom.Parser {
 	)
 }
 
+func (s *state) tupleLiteral(p parcom.Parser) parcom.Parser {
+	return s.withDebugInformation(
+		s.parenthesesed(s.And(p, s.Many1(s.Prefix(s.sign(commaSign), p)))),
+		newTupleLiteral,
+	)
+}
+
+// tupleLiteralOrParenthesesed parses a tuple literal or a parenthesesed
+// expression at once to avoid parsing the same expression twice.
+func (s *state) tupleLiteralOrParenthesesed() parcom.Parser {
+	return s.withDebugInformation(
+		s.parenthesesed(
+			s.And(s.expression(), s.Many(s.Prefix(s.sign(commaSign), s.expression()))),
+		),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			xs := x.([]interface{})
+
+			if len(xs[1].([]interface{})) == 0 {
+				return xs[0], nil
+			}
+
+			return newTupleLiteral(x, i)
+		},
+	)
+}
+
+func newTupleLiteral(x interface{}, i *debug.Information) (interface{}, error) {
+	xs := x.([]interface{})
+	es := []ast.Expression{xs[0].(ast.Expression)}
+
+	for _, x := range xs[1].([]interface{}) {
+		es = append(es, x.(ast.Expression))
+	}
+
+	return ast.NewTuple(types.NewUnknown(i), es), nil
+}
+
+func (s *state) recordLiteral() parcom.Parser {
+	f := s.recordField()
+
+	return s.withDebugInformation(
+		s.Wrap(
+			s.sign(openBraceSign),
+			s.Or(
+				s.And(
+					s.Maybe(s.Wrap(s.sign(ellipsisSign), s.expression(), s.sign(commaSign))),
+					f,
+					s.Many(s.Prefix(s.sign(commaSign), f)),
+					s.Maybe(s.sign(commaSign)),
+				),
+				s.None(),
+			),
+			s.sign(closeBraceSign),
+		),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			if x == nil {
+				return ast.NewRecord(types.NewUnknown(i), nil), nil
+			}
+
+			xs := x.([]interface{})
+			fs := []ast.RecordField{xs[1].(ast.RecordField)}
+			ss := map[string]struct{}{fs[0].Name(): {}}
+
+			for _, x := range xs[2].([]interface{}) {
+				f := x.(ast.RecordField)
+
+				if _, ok := ss[f.Name()]; ok {
+					return nil, newError(fmt.Sprintf("field '%v' is defined more than once", f.Name()), i)
+				}
+
+				fs = append(fs, f)
+				ss[f.Name()] = struct{}{}
+			}
+
+			if e, ok := xs[0].(ast.Expression); ok {
+				return ast.NewRecordUpdate(types.NewUnknown(i), e, fs), nil
+			}
+
+			return ast.NewRecord(types.NewUnknown(i), fs), nil
+		},
+	)
+}
+
+func (s *state) recordField() parcom.Parser {
+	return s.App(
+		func(x interface{}) (interface{}, error) {
+			xs := x.([]interface{})
+			return ast.NewRecordField(xs[0].(string), xs[2].(ast.Expression)), nil
+		},
+		s.And(s.fieldName(), s.sign(typeDefinitionSign), s.expression()),
+	)
+}
+
+func (s *state) fieldName() parcom.Parser {
+	return s.withDebugInformation(
+		s.identifier(),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			if strings.Contains(x.(string), ".") {
+				return nil, newError(fmt.Sprintf("invalid field name '%v'", x), i)
+			}
+
+			return x, nil
+		},
+	)
+}
+
+func (s *state) stringLiteral() parcom.Parser {
+	return s.App(
+		func(x interface{}) (interface{}, error) {
+			return ast.NewString(x.(string)), nil
+		},
+		s.rawStringLiteral(),
+	)
+}
+
 func (s *state) rawStringLiteral() parcom.Parser {
 	p := s.Str(doubleQuoteSign)
 
@@ -373,6 +712,23 @@ func (s *state) application() parcom.Parser {
 	)
 }
 
+func (s *state) lambda() parcom.Parser {
+	return s.App(
+		func(x interface{}) (interface{}, error) {
+			xs := x.([]interface{})
+			ys := xs[1].([]interface{})
+			ss := make([]string, 0, len(ys))
+
+			for _, y := range ys {
+				ss = append(ss, y.(string))
+			}
+
+			return ast.NewLambda(ss, xs[3].(ast.Expression)), nil
+		},
+		s.And(s.sign(backslashSign), s.Many1(s.identifier()), s.sign(mapSign), s.expression()),
+	)
+}
+
 func (s *state) let() parcom.Parser {
 	return s.App(
 		func(x interface{}) (interface{}, error) {
@@ -387,13 +743,35 @@ func (s *state) let() parcom.Parser {
 			return ast.NewLet(bs, xs[2].(ast.Expression)), nil
 		},
 		s.And(
-			s.WithBlock1(s.keyword(letKeyword), s.untypedBind()),
+			s.WithBlock1(s.keyword(letKeyword), s.Or(s.untypedBind(), s.patternBind())),
 			s.keyword(inKeyword),
 			s.expression(),
 		),
 	)
 }
 
+func (s *state) ifThenElse() parcom.Parser {
+	return s.App(
+		func(x interface{}) (interface{}, error) {
+			xs := x.([]interface{})
+
+			return ast.NewIf(
+				xs[1].(ast.Expression),
+				xs[3].(ast.Expression),
+				xs[5].(ast.Expression),
+			), nil
+		},
+		s.And(
+			s.keyword(ifKeyword),
+			s.expression(),
+			s.keyword(thenKeyword),
+			s.expression(),
+			s.keyword(elseKeyword),
+			s.expression(),
+		),
+	)
+}
+
 func (s *state) caseOf() parcom.Parser {
 	return s.withDebugInformation(
 		s.WithBlock1(
@@ -435,7 +813,9 @@ func (s *state) alternative() parcom.Parser {
 			xs := x.([]interface{})
 			return ast.NewAlternative(xs[0].(ast.Expression), xs[2].(ast.Expression)), nil
 		},
-		s.WithPosition(s.And(s.pattern(), s.sign(mapSign), s.expression())),
+		s.WithPosition(
+			s.And(s.Or(s.pattern(), s.constructorPattern()), s.sign(mapSign), s.expression()),
+		),
 	)
 }
 
@@ -453,7 +833,26 @@ func (s *state) pattern() parcom.Parser {
 	return s.Or(
 		s.numberLiteral(),
 		s.listLiteral(s.innerPattern()),
-		// TODO: Add string literal.
+		s.tupleLiteral(s.variable()),
+		s.stringLiteral(),
+		s.booleanLiteral(),
+	)
+}
+
+func (s *state) constructorPattern() parcom.Parser {
+	return s.App(
+		func(x interface{}) (interface{}, error) {
+			xs := x.([]interface{})
+			ys := xs[1].([]interface{})
+			vs := make([]ast.Variable, 0, len(ys))
+
+			for _, y := range ys {
+				vs = append(vs, y.(ast.Variable))
+			}
+
+			return ast.NewConstructorPattern(xs[0].(string), vs), nil
+		},
+		s.And(s.capitalizedIdentifier(), s.Many(s.variable())),
 	)
 }
 
@@ -473,9 +872,20 @@ func (s *state) binaryOperation() parcom.Parser {
 		func(x interface{}) (interface{}, error) {
 			xs := x.([]interface{})
 			os := []ast.BinaryOperator{}
-			es := []ast.Expression{xs[0].(ast.Expression)}
+			es := []ast.Expression{xs[1].(ast.Expression)}
+
+			// Negation is subtraction from zero so that it has the same priority
+			// as subtraction.
+			if xs[0] != nil {
+				if n, ok := es[0].(ast.Number); ok {
+					es[0] = ast.NewNumberWithType(-n.Value(), n.Type())
+				} else {
+					os = append(os, ast.Subtract)
+					es = append([]ast.Expression{ast.NewNumber(0)}, es...)
+				}
+			}
 
-			for _, y := range xs[1].([]interface{}) {
+			for _, y := range xs[2].([]interface{}) {
 				ys := y.([]interface{})
 
 				os = append(os, ast.BinaryOperator(ys[0].(string)))
@@ -488,20 +898,34 @@ func (s *state) binaryOperation() parcom.Parser {
 
 			return es[0], nil
 		},
-		s.And(
-			s.expressionWithOptions(false, true),
-			s.Many1(
-				s.And(
-					s.Or(
-						s.sign(additionOperator),
-						s.sign(subtractionOperator),
-						s.sign(multiplicationOperator),
-						s.sign(divisionOperator),
-					),
-					s.expressionWithOptions(false, true),
-				),
+		s.Or(
+			s.And(
+				s.sign(subtractionOperator),
+				s.expressionWithOptions(false, true),
+				s.Many(s.binaryOperand()),
 			),
+			s.And(s.None(), s.expressionWithOptions(false, true), s.Many1(s.binaryOperand())),
+		),
+	)
+}
+
+func (s *state) binaryOperand() parcom.Parser {
+	return s.And(
+		s.Or(
+			s.sign(additionOperator),
+			s.sign(subtractionOperator),
+			s.sign(multiplicationOperator),
+			s.sign(notEqualOperator),
+			s.sign(divisionOperator),
+			s.sign(moduloOperator),
+			s.sign(powerOperator),
+			s.sign(equalOperator),
+			s.sign(lessThanOrEqualOperator),
+			s.sign(lessThanOperator),
+			s.sign(greaterThanOrEqualOperator),
+			s.sign(greaterThanOperator),
 		),
+		s.expressionWithOptions(false, true),
 	)
 }
 
@@ -511,7 +935,9 @@ func reduceBinaryOperations(
 ) ([]ast.Expression, []ast.BinaryOperator) {
 	if len(es) == 1 {
 		return es, nil
-	} else if len(es) == 2 || os[0].Priority() >= os[1].Priority() {
+	} else if len(es) == 2 ||
+		os[0].Priority() > os[1].Priority() ||
+		os[0].Priority() == os[1].Priority() && !os[0].IsRightAssociative() {
 		return append(
 			[]ast.Expression{ast.NewBinaryOperation(os[0], es[0], es[1])},
 			es[2:]...,
@@ -524,7 +950,7 @@ func reduceBinaryOperations(
 }
 
 func (s *state) untypedBind() parcom.Parser {
-	return s.withDebugInformation(
+	return s.withComment(s.withDebugInformation(
 		s.WithPosition(s.And(s.identifier(), s.arguments(), s.sign(bindSign), s.expression())),
 		func(x interface{}, i *debug.Information) (interface{}, error) {
 			xs := x.([]interface{})
@@ -536,6 +962,18 @@ func (s *state) untypedBind() parcom.Parser {
 
 			return ast.NewBind(xs[0].(string), types.NewUnknown(i), e), nil
 		},
+	))
+}
+
+func (s *state) patternBind() parcom.Parser {
+	return s.App(
+		func(x interface{}) (interface{}, error) {
+			xs := x.([]interface{})
+			return ast.NewPatternBind(xs[0].(ast.Expression), xs[2].(ast.Expression)), nil
+		},
+		s.WithPosition(
+			s.And(s.tupleLiteral(s.variable()), s.sign(bindSign), s.expression()),
+		),
 	)
 }
 
@@ -545,7 +983,10 @@ func (s *state) typ() parcom.Parser {
 			return s.Or(
 				s.functionType(),
 				s.listType(),
+				s.recordType(),
+				s.tupleTypeOrParenthesesed(),
 				s.scalarType(),
+				s.typeParameter(),
 			)
 		},
 	)
@@ -554,15 +995,44 @@ func (s *state) typ() parcom.Parser {
 func (s *state) argumentType() parcom.Parser {
 	return s.Or(
 		s.scalarType(),
-		s.parenthesesed(s.functionType()),
+		s.typeParameter(),
+		s.listType(),
+		s.recordType(),
+		s.tupleTypeOrParenthesesed(),
 	)
 }
 
 func (s *state) scalarType() parcom.Parser {
 	return s.withDebugInformation(
-		s.token(s.Str("Number")),
-		func(_ interface{}, i *debug.Information) (interface{}, error) {
-			return types.NewNumber(i), nil
+		s.capitalizedIdentifier(),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			switch x.(string) {
+			case "Int":
+				return types.NewInt(i), nil
+			case "Number":
+				return types.NewNumber(i), nil
+			case "String":
+				return types.NewString(i), nil
+			case "Bool":
+				return types.NewBoolean(i), nil
+			}
+
+			return types.NewReference(x.(string), i), nil
+		},
+	)
+}
+
+func (s *state) typeParameter() parcom.Parser {
+	return s.withDebugInformation(
+		s.identifier(),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			n := x.(string)
+
+			if r, _ := utf8.DecodeRuneInString(n); !unicode.IsLower(r) || strings.Contains(n, ".") {
+				return nil, newError(fmt.Sprintf("'%v' is not a type parameter", n), i)
+			}
+
+			return types.NewParameter(n, i), nil
 		},
 	)
 }
@@ -576,6 +1046,66 @@ func (s *state) listType() parcom.Parser {
 	)
 }
 
+func (s *state) recordType() parcom.Parser {
+	f := s.And(s.fieldName(), s.sign(typeDefinitionSign), s.typ())
+
+	return s.withDebugInformation(
+		s.Wrap(
+			s.sign(openBraceSign),
+			s.Or(
+				s.And(f, s.Many(s.Prefix(s.sign(commaSign), f)), s.Maybe(s.sign(commaSign))),
+				s.None(),
+			),
+			s.sign(closeBraceSign),
+		),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			fs := map[string]types.Type{}
+
+			if x == nil {
+				return types.NewRecord(fs, i), nil
+			}
+
+			xs := x.([]interface{})
+
+			for _, x := range append([]interface{}{xs[0]}, xs[1].([]interface{})...) {
+				ys := x.([]interface{})
+				s := ys[0].(string)
+
+				if _, ok := fs[s]; ok {
+					return nil, newError(fmt.Sprintf("field '%v' is defined more than once", s), i)
+				}
+
+				fs[s] = ys[2].(types.Type)
+			}
+
+			return types.NewRecord(fs, i), nil
+		},
+	)
+}
+
+// tupleTypeOrParenthesesed parses a tuple type or a parenthesesed type at once
+// to avoid parsing the same type twice.
+func (s *state) tupleTypeOrParenthesesed() parcom.Parser {
+	return s.withDebugInformation(
+		s.parenthesesed(s.And(s.typ(), s.Many(s.Prefix(s.sign(commaSign), s.typ())))),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			xs := x.([]interface{})
+
+			if len(xs[1].([]interface{})) == 0 {
+				return xs[0], nil
+			}
+
+			ts := []types.Type{xs[0].(types.Type)}
+
+			for _, x := range xs[1].([]interface{}) {
+				ts = append(ts, x.(types.Type))
+			}
+
+			return types.NewTuple(ts, i), nil
+		},
+	)
+}
+
 func (s *state) functionType() parcom.Parser {
 	return s.Lazy(
 		func() parcom.Parser {
@@ -590,6 +1120,14 @@ func (s *state) functionType() parcom.Parser {
 	)
 }
 
+func (s *state) lookAhead(p parcom.Parser) bool {
+	ss := *s.PositionalState
+	_, err := p()
+	*s.PositionalState = ss
+
+	return err == nil
+}
+
 func (s *state) withDebugInformation(
 	p parcom.Parser,
 	f func(interface{}, *debug.Information) (interface{}, error),
@@ -606,6 +1144,31 @@ func (s *state) withDebugInformation(
 	}
 }
 
+func (s *state) noIndent() parcom.Parser {
+	return func() (interface{}, error) {
+		if s.Column() != 1 && !s.lookAhead(s.Char('\x00')) {
+			return nil, parcom.NewError("invalid indent", &s.State)
+		}
+
+		return nil, nil
+	}
+}
+
+func (s *state) withComment(p parcom.Parser) parcom.Parser {
+	return func() (interface{}, error) {
+		c := s.comment()
+		x, err := p()
+
+		if err != nil {
+			return nil, err
+		}
+
+		b := x.(ast.Bind)
+
+		return ast.NewBindWithComment(b.Name(), b.Type(), b.Expression(), c), nil
+	}
+}
+
 func (s *state) parenthesesed(p parcom.Parser) parcom.Parser {
 	return s.Wrap(s.sign(openParenthesisSign), p, s.sign(closeParenthesisSign))
 }
@@ -625,6 +1188,21 @@ func (s *state) identifier() parcom.Parser {
 	)
 }
 
+func (s *state) capitalizedIdentifier() parcom.Parser {
+	return s.withDebugInformation(
+		s.identifier(),
+		func(x interface{}, i *debug.Information) (interface{}, error) {
+			ss := strings.Split(x.(string), ".")
+
+			if r, _ := utf8.DecodeRuneInString(ss[len(ss)-1]); !unicode.IsUpper(r) {
+				return nil, newError(fmt.Sprintf("'%v' is not capitalized", x), i)
+			}
+
+			return x, nil
+		},
+	)
+}
+
 func (s *state) alphabet() parcom.Parser {
 	as := ""
 
@@ -654,5 +1232,32 @@ func (s *state) sign(sg sign) parcom.Parser {
 }
 
 func (s *state) token(p parcom.Parser) parcom.Parser {
-	return s.Suffix(s.SameLineOrIndent(p), s.Many(s.Chars(" \t\n\r")))
+	return s.Suffix(s.SameLineOrIndent(p), s.blank())
+}
+
+func (s *state) blank() parcom.Parser {
+	return s.Many(s.Or(s.Chars(" \t\n\r"), s.lineComment(), s.blockComment()))
+}
+
+func (s *state) lineComment() parcom.Parser {
+	return s.And(s.Str(lineCommentSign), s.Many(s.NotChars("\n\x00")))
+}
+
+func (s *state) blockComment() parcom.Parser {
+	return s.And(
+		s.Str(openBlockCommentSign),
+		s.Many(
+			s.Or(
+				s.Lazy(s.blockComment),
+				func() (interface{}, error) {
+					if s.lookAhead(s.Str(closeBlockCommentSign)) {
+						return nil, parcom.NewError("unexpected end of block comment", &s.State)
+					}
+
+					return s.NotChars("\x00")()
+				},
+			),
+		),
+		s.Str(closeBlockCommentSign),
+	)
 }
@@ -13,7 +13,7 @@ import (
 func TestParseWithEmptySource(t *testing.T) {
 	x, err := parse("", "")
 
-	assert.Equal(t, ast.NewModule("", ast.NewExport(), []ast.Import{}, []ast.Bind{}), x)
+	assert.Equal(t, ast.NewModule("", ast.NewExport(), []ast.Import{}, nil, []ast.Bind{}), x)
 	assert.Nil(t, err)
 }
 
@@ -36,12 +36,97 @@ func TestStateModule(t *testing.T) {
 		"import \"foo\"\nimport \"bar\"\nx : Number\nx = 42",
 		"export { x }\nimport \"foo\"\nx : Number\nx = 42",
 		"export { x }\nimport \"foo\"\nimport \"bar\"\nx : Number\nx = 42",
+		"type Foo = Foo\nx : Foo\nx = Foo",
+		"type Foo = Foo\ntype Bar = Bar Foo\nx : Bar\nx = Bar Foo",
+		"type Foo = Bar Number Foo | Baz\nx : Foo\nx = Baz",
+		"x : { foo : Number }\nx = { foo: 42 }\ny : Number\ny = x.foo",
+		"x = 42",
+		"f x = x\ny : Number\ny = f 42\nz = f y",
 	} {
 		_, err := newState(s, "").module("")()
 		assert.Nil(t, err)
 	}
 }
 
+func TestStateModuleWithTypeDefinitions(t *testing.T) {
+	s := "type Foo = Bar | Baz Foo\nx : Foo\nx = Bar"
+	m, err := newState(s, "").module("")()
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]ast.TypeDefinition{
+			ast.NewTypeDefinition(
+				"Foo",
+				types.NewAlgebraic(
+					"Foo",
+					[]types.Constructor{
+						types.NewConstructor("Bar", []types.Type{}),
+						types.NewConstructor(
+							"Baz",
+							[]types.Type{types.NewReference("Foo", debug.NewInformation("", 1, 22, "type Foo = Bar | Baz Foo"))},
+						),
+					},
+					debug.NewInformation("", 1, 1, "type Foo = Bar | Baz Foo"),
+				),
+			),
+		},
+		m.(ast.Module).TypeDefinitions(),
+	)
+	assert.Equal(
+		t,
+		m.(ast.Module).TypeDefinitions()[0].Type(),
+		m.(ast.Module).Binds()[0].Type(),
+	)
+}
+
+func TestStateModuleErrorWithTypeDefinitions(t *testing.T) {
+	for _, c := range []struct{ source, message string }{
+		{"type Foo = Foo\ntype Foo = Bar\nx : Foo\nx = Foo", "TypeError: type 'Foo' is defined more than once"},
+		{
+			"type Foo = Foo Bar\ntype Bar = Bar Foo\nx : Foo\nx = Foo",
+			"TypeError: mutually recursive types are not supported",
+		},
+		{"x : Foo\nx = Foo", "TypeError: type 'Foo' not found"},
+		{
+			"type Foo = Foo a\nx : Foo\nx = Foo",
+			"TypeError: type parameters in type definitions are not supported",
+		},
+	} {
+		_, err := newState(c.source, "").module("")()
+
+		assert.Error(t, err)
+		assert.Equal(t, c.message, err.Error())
+	}
+}
+
+func TestStateTypeDefinition(t *testing.T) {
+	for _, s := range []string{
+		"type Foo = Foo",
+		"type Foo = Bar | Baz",
+		"type Foo = Bar Number",
+		"type Foo = Bar Number [String] (Number -> Number)",
+		"type Foo = Bar Foo | Baz",
+		"type Foo =\n  Bar Number\n  | Baz",
+	} {
+		_, err := newState(s, "").typeDefinition()()
+		assert.Nil(t, err)
+	}
+}
+
+func TestStateTypeDefinitionError(t *testing.T) {
+	for _, s := range []string{
+		"type foo = Foo",
+		"type Foo = foo",
+		"type Foo = Foo |",
+		"type Foo",
+	} {
+		s := newState(s, "")
+		_, err := s.Exhaust(s.typeDefinition())()
+		assert.Error(t, err)
+	}
+}
+
 func TestStateModuleWithResult(t *testing.T) {
 	m, err := newState(
 		"export { x }\nimport \"foo\"\nx : Number\nx = 42",
@@ -53,8 +138,11 @@ func TestStateModuleWithResult(t *testing.T) {
 		t,
 		ast.NewModule(
 			"",
-			ast.NewExport("x"),
-			[]ast.Import{ast.NewImport("foo")},
+			ast.NewExport("x").WithDebugInformation(debug.NewInformation("module", 1, 1, "export { x }")),
+			[]ast.Import{
+				ast.NewImport("foo", debug.NewInformation("module", 2, 1, "import \"foo\"")),
+			},
+			nil,
 			[]ast.Bind{
 				ast.NewBind(
 					"x",
@@ -67,6 +155,99 @@ func TestStateModuleWithResult(t *testing.T) {
 	)
 }
 
+func TestStateImportModule(t *testing.T) {
+	for _, c := range []struct {
+		source string
+		ast    func(*debug.Information) ast.Import
+	}{
+		{
+			`import "foo"`,
+			func(i *debug.Information) ast.Import { return ast.NewImport("foo", i) },
+		},
+		{
+			`import "./foo/../bar"`,
+			func(i *debug.Information) ast.Import { return ast.NewImport("bar", i) },
+		},
+		{
+			`import "foo/bar" as baz`,
+			func(i *debug.Information) ast.Import { return ast.NewAliasedImport("foo/bar", "baz", i) },
+		},
+		{
+			`import "foo/bar" {}`,
+			func(i *debug.Information) ast.Import {
+				return ast.NewSelectiveImport("foo/bar", []string{}, i)
+			},
+		},
+		{
+			`import "foo/bar" { x }`,
+			func(i *debug.Information) ast.Import {
+				return ast.NewSelectiveImport("foo/bar", []string{"x"}, i)
+			},
+		},
+		{
+			`import "foo/bar" { x, y, }`,
+			func(i *debug.Information) ast.Import {
+				return ast.NewSelectiveImport("foo/bar", []string{"x", "y"}, i)
+			},
+		},
+	} {
+		s := newState(c.source, "")
+		x, err := s.Exhaust(s.importModule())()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.ast(debug.NewInformation("", 1, 1, c.source)), x)
+	}
+}
+
+func TestStateImportModuleError(t *testing.T) {
+	for _, s := range []string{
+		`import foo`,
+		`import "foo" as`,
+		`import "foo" as foo.bar`,
+		`import "foo" { x.y }`,
+		`import "foo" as foo { x }`,
+	} {
+		ss := newState(s, "")
+		_, err := ss.Exhaust(ss.importModule())()
+		assert.Error(t, err)
+	}
+}
+
+func TestStateModuleWithComments(t *testing.T) {
+	for _, s := range []string{
+		"-- foo",
+		"{- foo -}",
+		"-- foo\nx : Number\nx = 42",
+		"x : Number -- foo\nx = 42 -- foo",
+		"x : Number\nx = -- foo\n  42",
+		"x : Number\nx = {- foo -} 42",
+		"x : Number\nx = {- foo\n-} 42",
+		"x : Number\nx = {- {- foo -} -} 42",
+		"x : Number\nx = {- -- foo -} 42",
+		"x : Number\nx = {- - } -} 42",
+		"x : Number\nx =\n  let\n-- foo\n    y = 42\n  in y",
+		"{- foo -}\nx : Number\nx = 42",
+		"x : Number\nx = 42\n{- foo\n  x = 42 -}",
+	} {
+		_, err := newState(s, "").module("")()
+		assert.Nil(t, err)
+	}
+}
+
+func TestStateModuleErrorWithComments(t *testing.T) {
+	for _, s := range []string{
+		"{- foo",
+		"{- {- foo -}",
+		"foo -}",
+		"x : Number\nx = 42 {- foo",
+		"x : Number\nx = -- 42",
+		"  -- foo\n  x : Number\n  x = 42",
+	} {
+		_, err := newState(s, "").module("")()
+		assert.Error(t, err)
+	}
+}
+
 func TestStateModuleError(t *testing.T) {
 	for _, s := range []string{
 		"x : Number",
@@ -103,15 +284,75 @@ func TestStateExport(t *testing.T) {
 func TestStateExportWithCommas(t *testing.T) {
 	e, err := newState("export { foo }", "").export()()
 	assert.Nil(t, err)
-	assert.Equal(t, ast.NewExport("foo"), e)
+	assert.Equal(t, []string{"foo"}, e.(ast.Export).Names())
 
 	e, err = newState("export { foo, bar }", "").export()()
 	assert.Nil(t, err)
-	assert.Equal(t, ast.NewExport("foo", "bar"), e)
+	assert.Equal(t, []string{"foo", "bar"}, e.(ast.Export).Names())
 
 	e, err = newState("export { foo, bar, }", "").export()()
 	assert.Nil(t, err)
-	assert.Equal(t, ast.NewExport("foo", "bar"), e)
+	assert.Equal(t, []string{"foo", "bar"}, e.(ast.Export).Names())
+}
+
+func TestStateExportModule(t *testing.T) {
+	s := `export module "foo/bar"`
+	e, err := newState(s, "").exportModule()()
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		ast.NewExportWithModules([]ast.ModuleName{"foo/bar"}).WithDebugInformation(
+			debug.NewInformation("", 1, 1, s),
+		),
+		e,
+	)
+}
+
+func TestStateModuleWithReexports(t *testing.T) {
+	m, err := newState(
+		"export { x, foo.y }\nexport module \"bar\"\nimport \"foo\"\nimport \"bar\"\nx = 42",
+		"",
+	).module("")()
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		ast.NewExportWithModules([]ast.ModuleName{"bar"}, "x", "foo.y").WithDebugInformation(
+			debug.NewInformation("", 1, 1, "export { x, foo.y }"),
+		),
+		m.(ast.Module).Export(),
+	)
+}
+
+func TestStateModuleWithoutPrelude(t *testing.T) {
+	for _, c := range []struct {
+		source  string
+		prelude bool
+	}{
+		{"x = 42", true},
+		{"import \"foo\"\nx = 42", true},
+		{"import prelude {}\nx = 42", false},
+		{"import \"foo\"\nimport prelude {}\nx = 42", false},
+		{"export { x }\nimport prelude {}\nimport \"foo\"\nx = 42", false},
+	} {
+		m, err := newState(c.source, "").module("")()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.prelude, m.(ast.Module).ImportsPrelude())
+	}
+}
+
+func TestStateModuleErrorWithoutPrelude(t *testing.T) {
+	for _, s := range []string{
+		"import prelude\nx = 42",
+		"import prelude { x }\nx = 42",
+		"import \"foo\" prelude {}\nx = 42",
+		"x = 42\nimport prelude {}",
+		"prelude = 42",
+	} {
+		_, err := newState(s, "").module("")()
+		assert.Error(t, err)
+	}
 }
 
 func TestStateImport(t *testing.T) {
@@ -132,6 +373,8 @@ func TestStateBind(t *testing.T) {
 		"f : Number -> Number -> Number\nf x y = 42",
 		"x :\n Number\nx = 42",
 		"x : Number\nx =\n 42",
+		"x = 42",
+		"f x = x",
 	} {
 		_, err := newState(s, "").bind()()
 		assert.Nil(t, err)
@@ -153,6 +396,79 @@ func TestStateBindWithVariableBind(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestStateBindWithoutTypeSignature(t *testing.T) {
+	x, err := newState("f x = x", "").bind()()
+
+	assert.Equal(
+		t,
+		ast.NewBind(
+			"f",
+			types.NewUnknown(debug.NewInformation("", 1, 1, "f x = x")),
+			ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+		),
+		x,
+	)
+	assert.Nil(t, err)
+}
+
+func TestStateBindWithComments(t *testing.T) {
+	for _, c := range []struct {
+		source  string
+		comment string
+	}{
+		{"x = 42", ""},
+		{"-- foo\nx = 42", "foo"},
+		{"--foo\nx = 42", "foo"},
+		{"-- foo\n-- bar\nx = 42", "foo\nbar"},
+		{"-- foo\n--\n--   bar\nx = 42", "foo\n\n  bar"},
+		{"-- foo\n\nx = 42", ""},
+		{"-- foo\nx : Number\nx = 42", "foo"},
+		{"{- foo -}\nx = 42", ""},
+	} {
+		s := newState(c.source, "")
+		_, err := s.blank()()
+		assert.Nil(t, err)
+
+		x, err := s.bind()()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.comment, x.(ast.Bind).Comment())
+	}
+}
+
+func TestStateForeignBind(t *testing.T) {
+	x, err := newState(`foreign import "sin" sin : Number -> Number`, "").foreignBind()()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "sin", x.(ast.Bind).Name())
+	assert.Equal(t, ast.NewForeignFunction("sin"), x.(ast.Bind).Expression())
+	assert.IsType(t, types.Function{}, x.(ast.Bind).Type())
+}
+
+func TestStateForeignBindError(t *testing.T) {
+	for _, s := range []string{
+		`foreign import sin sin : Number -> Number`,
+		`foreign import "sin" sin`,
+		`foreign import "sin" Math.sin : Number -> Number`,
+		`foreign "sin" sin : Number -> Number`,
+	} {
+		ss := newState(s, "")
+		_, err := ss.Exhaust(ss.foreignBind())()
+		assert.Error(t, err)
+	}
+}
+
+func TestStateModuleWithForeignBinds(t *testing.T) {
+	x, err := newState(
+		"foreign import \"sin\" sin : Number -> Number\nx = sin 42",
+		"",
+	).module("")()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(x.(ast.Module).Binds()))
+	assert.Equal(t, ast.NewForeignFunction("sin"), x.(ast.Module).Binds()[0].Expression())
+}
+
 func TestStateBindErrorWithInvalidIndents(t *testing.T) {
 	_, err := newState("", "x : Number\n x = 42").bind()()
 	assert.Error(t, err)
@@ -197,6 +513,17 @@ func TestStateNumberLiteral(t *testing.T) {
 	}
 }
 
+func TestStateNumberLiteralWithType(t *testing.T) {
+	for s, n := range map[string]ast.Number{
+		"42":   ast.NewNumber(42),
+		"42.0": ast.NewNumberWithType(42, types.NewNumber(nil)),
+	} {
+		x, err := newState(s, "").numberLiteral()()
+		assert.Nil(t, err)
+		assert.Equal(t, n, x)
+	}
+}
+
 func TestStateNumberLiteralError(t *testing.T) {
 	for _, s := range []string{
 		"- 42",
@@ -235,6 +562,73 @@ func TestStateListLiteralError(t *testing.T) {
 	}
 }
 
+func TestStateTupleLiteral(t *testing.T) {
+	for _, s := range []string{
+		"(42, 42)",
+		"(x, f x, [42])",
+		"((x, y), z)",
+	} {
+		ss := newState(s, "")
+		_, err := ss.Exhaust(ss.expression())()
+		assert.Nil(t, err)
+	}
+}
+
+func TestStateRecordLiteral(t *testing.T) {
+	for _, c := range []struct {
+		source     string
+		expression ast.Expression
+	}{
+		{"{}", ast.NewRecord(types.NewUnknown(debug.NewInformation("", 1, 1, "{}")), nil)},
+		{
+			"{ foo: 42 }",
+			ast.NewRecord(
+				types.NewUnknown(debug.NewInformation("", 1, 1, "{ foo: 42 }")),
+				[]ast.RecordField{ast.NewRecordField("foo", ast.NewNumber(42))},
+			),
+		},
+		{
+			`{ foo: 42, bar: "baz", }`,
+			ast.NewRecord(
+				types.NewUnknown(debug.NewInformation("", 1, 1, `{ foo: 42, bar: "baz", }`)),
+				[]ast.RecordField{
+					ast.NewRecordField("foo", ast.NewNumber(42)),
+					ast.NewRecordField("bar", ast.NewString("baz")),
+				},
+			),
+		},
+		{
+			"{ ...x, foo: 42 }",
+			ast.NewRecordUpdate(
+				types.NewUnknown(debug.NewInformation("", 1, 1, "{ ...x, foo: 42 }")),
+				ast.NewVariable("x"),
+				[]ast.RecordField{ast.NewRecordField("foo", ast.NewNumber(42))},
+			),
+		},
+	} {
+		e, err := newState(c.source, "").recordLiteral()()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expression, e)
+	}
+}
+
+func TestStateRecordLiteralError(t *testing.T) {
+	for _, s := range []string{
+		"{,}",
+		"{ foo }",
+		"{ foo: 42,, }",
+		"{ foo: 42, foo: 42 }",
+		"{ foo.bar: 42 }",
+		"{ ...x }",
+		"{ foo: 42, ...x }",
+	} {
+		ss := newState(s, "")
+		_, err := ss.Exhaust(ss.recordLiteral())()
+		assert.Error(t, err)
+	}
+}
+
 func TestStateRawStringLiteral(t *testing.T) {
 	for _, ss := range [][2]string{
 		{`"foo"`, "foo"},
@@ -252,6 +646,37 @@ func TestStateRawStringLiteral(t *testing.T) {
 	}
 }
 
+func TestStateStringLiteral(t *testing.T) {
+	for _, ss := range [][2]string{
+		{`""`, ""},
+		{`"foo"`, "foo"},
+		{`"foo\nbar"`, "foo\nbar"},
+		{`"ein言語"`, "ein言語"},
+	} {
+		s := newState(ss[0], "")
+		x, err := s.Exhaust(s.stringLiteral())()
+
+		assert.Nil(t, err)
+		assert.Equal(t, ast.NewString(ss[1]), x)
+	}
+}
+
+func TestStateBooleanLiteral(t *testing.T) {
+	for _, c := range []struct {
+		source  string
+		boolean ast.Boolean
+	}{
+		{"True", ast.NewBoolean(true)},
+		{"False", ast.NewBoolean(false)},
+	} {
+		s := newState(c.source, "")
+		x, err := s.Exhaust(s.booleanLiteral())()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.boolean, x)
+	}
+}
+
 func TestStateVariable(t *testing.T) {
 	_, err := newState("x", "").variable()()
 	assert.Nil(t, err)
@@ -275,6 +700,13 @@ func TestStateApplication(t *testing.T) {
 			ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
 			[]ast.Expression{ast.NewVariable("x")},
 		),
+		"f (\\x -> x) y": ast.NewApplication(
+			ast.NewVariable("f"),
+			[]ast.Expression{
+				ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+				ast.NewVariable("y"),
+			},
+		),
 	} {
 		aa, err := newState(s, "").application()()
 
@@ -283,12 +715,64 @@ func TestStateApplication(t *testing.T) {
 	}
 }
 
+func TestStateLambda(t *testing.T) {
+	for _, c := range []struct {
+		source string
+		ast    ast.Lambda
+	}{
+		{
+			"\\x -> x",
+			ast.NewLambda([]string{"x"}, ast.NewVariable("x")),
+		},
+		{
+			"\\x y -> x + y",
+			ast.NewLambda(
+				[]string{"x", "y"},
+				ast.NewBinaryOperation(ast.Add, ast.NewVariable("x"), ast.NewVariable("y")),
+			),
+		},
+		{
+			"\\x -> \\y -> f x y",
+			ast.NewLambda(
+				[]string{"x"},
+				ast.NewLambda(
+					[]string{"y"},
+					ast.NewApplication(
+						ast.NewVariable("f"),
+						[]ast.Expression{ast.NewVariable("x"), ast.NewVariable("y")},
+					),
+				),
+			),
+		},
+	} {
+		s := newState(c.source, "")
+		l, err := s.Exhaust(s.lambda())()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.ast, l)
+	}
+}
+
+func TestStateLambdaError(t *testing.T) {
+	for _, ss := range []string{
+		"\\ -> 42",
+		"\\x 42",
+		"\\let -> 42",
+	} {
+		s := newState(ss, "")
+		_, err := s.Exhaust(s.lambda())()
+		assert.Error(t, err)
+	}
+}
+
 func TestStateLet(t *testing.T) {
 	for _, s := range []string{
 		"let x = 42 in 42",
 		"let\n x = 42 in 42",
 		"let x = 42\n    y = 42 in 42",
 		"let x = 42\nin 42",
+		"let (x, y) = z in x",
+		"let (x, y, z) = w in x",
 	} {
 		s := newState(s, "")
 		_, err := s.Exhaust(s.let())()
@@ -308,6 +792,56 @@ func TestStateLetError(t *testing.T) {
 	}
 }
 
+func TestStateIfThenElse(t *testing.T) {
+	for _, c := range []struct {
+		source string
+		ast    ast.If
+	}{
+		{
+			"if True then 1 else 2",
+			ast.NewIf(ast.NewBoolean(true), ast.NewNumber(1), ast.NewNumber(2)),
+		},
+		{
+			"if x < 1 then 1 else if x < 2 then 2 else 3",
+			ast.NewIf(
+				ast.NewBinaryOperation(ast.LessThan, ast.NewVariable("x"), ast.NewNumber(1)),
+				ast.NewNumber(1),
+				ast.NewIf(
+					ast.NewBinaryOperation(ast.LessThan, ast.NewVariable("x"), ast.NewNumber(2)),
+					ast.NewNumber(2),
+					ast.NewNumber(3),
+				),
+			),
+		},
+		{
+			"if f x\n  then 1\n  else 2",
+			ast.NewIf(
+				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+				ast.NewNumber(1),
+				ast.NewNumber(2),
+			),
+		},
+	} {
+		s := newState(c.source, "")
+		a, err := s.Exhaust(s.ifThenElse())()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.ast, a)
+	}
+}
+
+func TestStateIfThenElseError(t *testing.T) {
+	for _, ss := range []string{
+		"if True then 1",
+		"if True else 1",
+		"if then 1 else 2",
+	} {
+		s := newState(ss, "")
+		_, err := s.Exhaust(s.ifThenElse())()
+		assert.Error(t, err)
+	}
+}
+
 func TestStateCaseOf(t *testing.T) {
 	for _, c := range []struct {
 		source string
@@ -384,16 +918,44 @@ func TestStatePattern(t *testing.T) {
 		"[42, [42]]",
 		"[x]",
 		"[x, ...xs]",
+		`"foo"`,
+		`["foo", x]`,
+		"True",
+		"[False]",
+		"(x, y)",
+		"(x, y, z)",
+		"[(x, y), ...xs]",
 	} {
 		_, err := newState(s, "").pattern()()
 		assert.Nil(t, err)
 	}
 }
 
+func TestStateConstructorPattern(t *testing.T) {
+	for _, c := range []struct {
+		source  string
+		pattern ast.ConstructorPattern
+	}{
+		{"Foo", ast.NewConstructorPattern("Foo", []ast.Variable{})},
+		{"Foo x", ast.NewConstructorPattern("Foo", []ast.Variable{ast.NewVariable("x")})},
+		{
+			"foo.Bar x y",
+			ast.NewConstructorPattern("foo.Bar", []ast.Variable{ast.NewVariable("x"), ast.NewVariable("y")}),
+		},
+	} {
+		s := newState(c.source, "")
+		p, err := s.Exhaust(s.constructorPattern())()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.pattern, p)
+	}
+}
+
 func TestStatePatternError(t *testing.T) {
 	for _, s := range []string{
 		"x",
 		"[f x]",
+		"(x, 42)",
 	} {
 		_, err := newState(s, "").pattern()()
 		assert.Error(t, err)
@@ -477,6 +1039,42 @@ func TestStateExpressionWithOperators(t *testing.T) {
 				ast.NewNumber(3),
 			),
 		},
+		{
+			"1 + 2 % 3",
+			ast.NewBinaryOperation(
+				ast.Add,
+				ast.NewNumber(1),
+				ast.NewBinaryOperation(ast.Modulo, ast.NewNumber(2), ast.NewNumber(3)),
+			),
+		},
+		{
+			"1 * 2 ^ 3",
+			ast.NewBinaryOperation(
+				ast.Multiply,
+				ast.NewNumber(1),
+				ast.NewBinaryOperation(ast.Power, ast.NewNumber(2), ast.NewNumber(3)),
+			),
+		},
+		{
+			"1 ^ 2 ^ 3",
+			ast.NewBinaryOperation(
+				ast.Power,
+				ast.NewNumber(1),
+				ast.NewBinaryOperation(ast.Power, ast.NewNumber(2), ast.NewNumber(3)),
+			),
+		},
+		{
+			"1 ^ 2 ^ 3 * 4",
+			ast.NewBinaryOperation(
+				ast.Multiply,
+				ast.NewBinaryOperation(
+					ast.Power,
+					ast.NewNumber(1),
+					ast.NewBinaryOperation(ast.Power, ast.NewNumber(2), ast.NewNumber(3)),
+				),
+				ast.NewNumber(4),
+			),
+		},
 		{
 			"f x + 1",
 			ast.NewBinaryOperation(
@@ -523,20 +1121,155 @@ func TestStateExpressionWithOperators(t *testing.T) {
 	}
 }
 
+func TestStateExpressionWithNegation(t *testing.T) {
+	for _, c := range []struct {
+		source     string
+		expression ast.Expression
+	}{
+		{"- 1", ast.NewNumber(-1)},
+		{"-x", ast.NewBinaryOperation(ast.Subtract, ast.NewNumber(0), ast.NewVariable("x"))},
+		{
+			"-(f x)",
+			ast.NewBinaryOperation(
+				ast.Subtract,
+				ast.NewNumber(0),
+				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+			),
+		},
+		{
+			"- f x",
+			ast.NewBinaryOperation(
+				ast.Subtract,
+				ast.NewNumber(0),
+				ast.NewApplication(ast.NewVariable("f"), []ast.Expression{ast.NewVariable("x")}),
+			),
+		},
+		{
+			"-x ^ 2",
+			ast.NewBinaryOperation(
+				ast.Subtract,
+				ast.NewNumber(0),
+				ast.NewBinaryOperation(ast.Power, ast.NewVariable("x"), ast.NewNumber(2)),
+			),
+		},
+		{
+			"-x + 1",
+			ast.NewBinaryOperation(
+				ast.Add,
+				ast.NewBinaryOperation(ast.Subtract, ast.NewNumber(0), ast.NewVariable("x")),
+				ast.NewNumber(1),
+			),
+		},
+	} {
+		s := newState(c.source, "")
+		e, err := s.Exhaust(s.expression())()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expression, e)
+	}
+}
+
+func TestStateExpressionWithComparisonOperators(t *testing.T) {
+	for _, c := range []struct {
+		source     string
+		expression ast.Expression
+	}{
+		{"1 == 2", ast.NewBinaryOperation(ast.Equal, ast.NewNumber(1), ast.NewNumber(2))},
+		{"1 /= 2", ast.NewBinaryOperation(ast.NotEqual, ast.NewNumber(1), ast.NewNumber(2))},
+		{"1 < 2", ast.NewBinaryOperation(ast.LessThan, ast.NewNumber(1), ast.NewNumber(2))},
+		{"1 <= 2", ast.NewBinaryOperation(ast.LessThanOrEqual, ast.NewNumber(1), ast.NewNumber(2))},
+		{"1 > 2", ast.NewBinaryOperation(ast.GreaterThan, ast.NewNumber(1), ast.NewNumber(2))},
+		{"1 >= 2", ast.NewBinaryOperation(ast.GreaterThanOrEqual, ast.NewNumber(1), ast.NewNumber(2))},
+		{
+			"1 / 2 /= 3",
+			ast.NewBinaryOperation(
+				ast.NotEqual,
+				ast.NewBinaryOperation(ast.Divide, ast.NewNumber(1), ast.NewNumber(2)),
+				ast.NewNumber(3),
+			),
+		},
+		{
+			"1 + 2 < 3 * 4",
+			ast.NewBinaryOperation(
+				ast.LessThan,
+				ast.NewBinaryOperation(ast.Add, ast.NewNumber(1), ast.NewNumber(2)),
+				ast.NewBinaryOperation(ast.Multiply, ast.NewNumber(3), ast.NewNumber(4)),
+			),
+		},
+	} {
+		s := newState(c.source, "")
+		e, err := s.Exhaust(s.expression())()
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expression, e)
+	}
+}
+
 func TestStateType(t *testing.T) {
 	for _, s := range []string{
 		"Number",
+		"Int",
+		"Int -> Number",
 		"Number -> Number",
 		"Number -> Number -> Number",
 		"(Number -> Number) -> Number",
 		"[Number]",
 		"[[Number]]",
+		"String",
+		"String -> Number",
+		"[String]",
+		"Bool",
+		"Number -> Bool",
+		"Foo",
+		"Foo -> [foo.Bar]",
+		"{}",
+		"{ foo : Number }",
+		"{ foo : Number, bar : [String], }",
+		"{ foo : { bar : Number } } -> Number",
+		"a",
+		"a -> a",
+		"(a -> b) -> [a] -> [b]",
+		"{ foo : a } -> a",
+		"(Number, [Number])",
+		"(Number, Number) -> Number",
+		"(a, (b, c))",
 	} {
 		_, err := newState(s, "").typ()()
 		assert.Nil(t, err)
 	}
 }
 
+func TestStateTypeError(t *testing.T) {
+	for _, s := range []string{
+		"{ foo }",
+		"{ foo : Number, foo : Number }",
+		"foo.bar",
+	} {
+		ss := newState(s, "")
+		_, err := ss.Exhaust(ss.typ())()
+		assert.Error(t, err)
+	}
+}
+
+func TestStateTypeWithTypeParameters(t *testing.T) {
+	s := "a -> [b]"
+	x, err := newState(s, "").typ()()
+
+	assert.Equal(
+		t,
+		types.NewFunction(
+			types.NewParameter("a", debug.NewInformation("", 1, 1, s)),
+			types.NewList(
+				types.NewParameter("b", debug.NewInformation("", 1, 7, s)),
+				debug.NewInformation("", 1, 6, s),
+			),
+			debug.NewInformation("", 1, 1, s),
+		),
+		x,
+	)
+	assert.Nil(t, err)
+}
+
 func TestStateTypeWithMultipleArguments(t *testing.T) {
 	s := "Number -> Number -> Number"
 	x, err := newState(s, "").typ()()
@@ -0,0 +1,176 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/raviqqe/lazy-ein/command/ast"
+	"github.com/raviqqe/lazy-ein/command/types"
+)
+
+type typeResolver struct {
+	definitions map[string]ast.TypeDefinition
+	types       map[string]types.Type
+	resolving   map[string]struct{}
+}
+
+// resolveTypes resolves references to named types in type definitions and
+// bind types of a module.
+func resolveTypes(m ast.Module) (interface{}, error) {
+	r := typeResolver{
+		make(map[string]ast.TypeDefinition, len(m.TypeDefinitions())),
+		make(map[string]types.Type, len(m.TypeDefinitions())),
+		map[string]struct{}{},
+	}
+
+	for _, d := range m.TypeDefinitions() {
+		if _, ok := r.definitions[d.Name()]; ok {
+			return nil, types.NewTypeError(
+				fmt.Sprintf("type '%v' is defined more than once", d.Name()),
+				d.Type().DebugInformation(),
+			)
+		}
+
+		r.definitions[d.Name()] = d
+	}
+
+	ds := []ast.TypeDefinition(nil)
+
+	for _, d := range m.TypeDefinitions() {
+		t, err := r.resolveDefinition(d.Name())
+
+		if err != nil {
+			return nil, err
+		}
+
+		ds = append(ds, ast.NewTypeDefinition(d.Name(), t))
+	}
+
+	bs := make([]ast.Bind, 0, len(m.Binds()))
+
+	for _, b := range m.Binds() {
+		t, err := r.resolve(b.Type(), "")
+
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, ast.NewBindWithComment(b.Name(), t, b.Expression(), b.Comment()))
+	}
+
+	return ast.NewModule(m.Name(), m.Export(), m.Imports(), ds, bs), nil
+}
+
+func (r typeResolver) resolveDefinition(s string) (types.Type, error) {
+	if t, ok := r.types[s]; ok {
+		return t, nil
+	}
+
+	r.resolving[s] = struct{}{}
+	defer delete(r.resolving, s)
+
+	t, err := r.resolve(r.definitions[s].Type(), s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.types[s] = t
+
+	return t, nil
+}
+
+// resolve resolves type references in a type. References to a parent type
+// of a given name are kept as they are.
+func (r typeResolver) resolve(t types.Type, parent string) (types.Type, error) {
+	switch t := t.(type) {
+	case types.Algebraic:
+		cs := make([]types.Constructor, 0, len(t.Constructors()))
+
+		for _, c := range t.Constructors() {
+			ts := make([]types.Type, 0, len(c.Elements()))
+
+			for _, t := range c.Elements() {
+				t, err := r.resolve(t, parent)
+
+				if err != nil {
+					return nil, err
+				}
+
+				ts = append(ts, t)
+			}
+
+			cs = append(cs, types.NewConstructor(c.Name(), ts))
+		}
+
+		return types.NewAlgebraic(t.Name(), cs, t.DebugInformation()), nil
+	case types.Function:
+		a, err := r.resolve(t.Argument(), parent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rr, err := r.resolve(t.Result(), parent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewFunction(a, rr, t.DebugInformation()), nil
+	case types.List:
+		e, err := r.resolve(t.Element(), parent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NewList(e, t.DebugInformation()), nil
+	case types.Record:
+		fs := make(map[string]types.Type, len(t.Fields()))
+
+		for _, s := range t.FieldNames() {
+			tt, err := r.resolve(t.Fields()[s], parent)
+
+			if err != nil {
+				return nil, err
+			}
+
+			fs[s] = tt
+		}
+
+		return types.NewRecord(fs, t.DebugInformation()), nil
+	case types.Tuple:
+		ts := make([]types.Type, 0, len(t.Elements()))
+
+		for _, tt := range t.Elements() {
+			tt, err := r.resolve(tt, parent)
+
+			if err != nil {
+				return nil, err
+			}
+
+			ts = append(ts, tt)
+		}
+
+		return types.NewTuple(ts, t.DebugInformation()), nil
+	case types.Parameter:
+		if parent != "" {
+			return nil, types.NewTypeError(
+				"type parameters in type definitions are not supported",
+				t.DebugInformation(),
+			)
+		}
+	case types.Reference:
+		if t.Name() == parent {
+			return t, nil
+		} else if _, ok := r.resolving[t.Name()]; ok {
+			return nil, types.NewTypeError("mutually recursive types are not supported", t.DebugInformation())
+		} else if _, ok := r.definitions[t.Name()]; !ok {
+			return nil, types.NewTypeError(fmt.Sprintf("type '%v' not found", t.Name()), t.DebugInformation())
+		}
+
+		return r.resolveDefinition(t.Name())
+	}
+
+	return t, nil
+}
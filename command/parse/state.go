@@ -10,12 +10,12 @@ import (
 
 type state struct {
 	*parcom.PositionalState
-	source     string
+	lines      []string
 	moduleName ast.ModuleName
 }
 
 func newState(s string, n ast.ModuleName) *state {
-	return &state{parcom.NewPositionalState(s), s, n}
+	return &state{parcom.NewPositionalState(s), strings.Split(s, "\n"), n}
 }
 
 func (s state) debugInformation() *debug.Information {
@@ -23,6 +23,28 @@ func (s state) debugInformation() *debug.Information {
 		string(s.moduleName),
 		s.Line(),
 		s.Column(),
-		strings.Split(s.source, "\n")[s.Line()-1],
+		s.lines[s.Line()-1],
 	)
 }
+
+// comment returns a documentation comment which consists of line comments
+// right above a current position.
+func (s state) comment() string {
+	if strings.TrimSpace(string([]rune(s.lines[s.Line()-1])[:s.Column()-1])) != "" {
+		return ""
+	}
+
+	cs := []string{}
+
+	for i := s.Line() - 2; i >= 0; i-- {
+		l := strings.TrimSpace(s.lines[i])
+
+		if !strings.HasPrefix(l, lineCommentSign) {
+			break
+		}
+
+		cs = append([]string{strings.TrimPrefix(strings.TrimPrefix(l, lineCommentSign), " ")}, cs...)
+	}
+
+	return strings.Join(cs, "\n")
+}
@@ -0,0 +1,189 @@
+package types
+
+import (
+	"fmt"
+
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// Algebraic is a user-defined algebraic type.
+// Algebraic types are compared by their names.
+type Algebraic struct {
+	name             string
+	constructors     []Constructor
+	debugInformation *debug.Information
+}
+
+// NewAlgebraic creates an algebraic type.
+func NewAlgebraic(n string, cs []Constructor, i *debug.Information) Algebraic {
+	return Algebraic{n, cs, i}
+}
+
+// Name returns a name.
+func (a Algebraic) Name() string {
+	return a.name
+}
+
+// Constructors returns constructors.
+func (a Algebraic) Constructors() []Constructor {
+	return a.constructors
+}
+
+// ConstructorIndex returns an index of a constructor.
+func (a Algebraic) ConstructorIndex(s string) (int, bool) {
+	for i, c := range a.constructors {
+		if c.Name() == s {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// ConstructorElements returns element types of a constructor with recursive
+// references replaced with the algebraic type itself.
+func (a Algebraic) ConstructorElements(i int) []Type {
+	ts := make([]Type, 0, len(a.constructors[i].Elements()))
+
+	for _, t := range a.constructors[i].Elements() {
+		ts = append(ts, a.unfold(t))
+	}
+
+	return ts
+}
+
+func (a Algebraic) unfold(t Type) Type {
+	switch t := t.(type) {
+	case Function:
+		return NewFunction(a.unfold(t.Argument()), a.unfold(t.Result()), t.DebugInformation())
+	case List:
+		return NewList(a.unfold(t.Element()), t.DebugInformation())
+	case Record:
+		fs := make(map[string]Type, len(t.Fields()))
+
+		for s, t := range t.Fields() {
+			fs[s] = a.unfold(t)
+		}
+
+		return NewRecord(fs, t.DebugInformation())
+	case Tuple:
+		ts := make([]Type, 0, len(t.Elements()))
+
+		for _, t := range t.Elements() {
+			ts = append(ts, a.unfold(t))
+		}
+
+		return NewTuple(ts, t.DebugInformation())
+	case Reference:
+		if t.Name() == a.name {
+			return a
+		}
+	}
+
+	return t
+}
+
+// Unify unifies itself with another type.
+func (a Algebraic) Unify(t Type) ([]Equation, error) {
+	if aa, ok := t.(Algebraic); ok && a.name == aa.name {
+		return nil, nil
+	}
+
+	return fallbackToVariable(a, t, NewTypeError(fmt.Sprintf("not a %v", a.name), t.DebugInformation()))
+}
+
+// SubstituteVariable substitutes type variables.
+func (a Algebraic) SubstituteVariable(v Variable, t Type) Type {
+	return a
+}
+
+// DebugInformation returns debug information.
+func (a Algebraic) DebugInformation() *debug.Information {
+	return a.debugInformation
+}
+
+// ToCore returns a type in the core language.
+func (a Algebraic) ToCore() coretypes.Type {
+	return coretypes.NewBoxed(a.toCore(nil))
+}
+
+func (a Algebraic) toCore(ss []string) coretypes.Algebraic {
+	ss = append(append([]string(nil), ss...), a.name)
+	cs := make([]coretypes.Constructor, 0, len(a.constructors))
+
+	for _, c := range a.constructors {
+		var ts []coretypes.Type
+
+		for _, t := range c.Elements() {
+			ts = append(ts, toCoreInAlgebraic(t, ss))
+		}
+
+		cs = append(cs, coretypes.NewConstructor(ts...))
+	}
+
+	return coretypes.NewAlgebraic(cs[0], cs[1:]...)
+}
+
+// toCoreInAlgebraic converts a type into one in the core language resolving
+// recursive references with names of parent algebraic types.
+func toCoreInAlgebraic(t Type, ss []string) coretypes.Type {
+	switch t := t.(type) {
+	case Algebraic:
+		return coretypes.NewBoxed(t.toCore(ss))
+	case Function:
+		as := []coretypes.Type{}
+
+		for {
+			as = append(as, toCoreInAlgebraic(t.Argument(), ss))
+			f, ok := t.Result().(Function)
+
+			if !ok {
+				return coretypes.NewFunction(as, toCoreInAlgebraic(t.Result(), ss))
+			}
+
+			t = f
+		}
+	case List:
+		return coretypes.NewBoxed(
+			coretypes.NewAlgebraic(
+				coretypes.NewConstructor(
+					toCoreInAlgebraic(t.Element(), append(append([]string(nil), ss...), "")),
+					coretypes.NewBoxed(coretypes.NewIndex(0)),
+				),
+				coretypes.NewConstructor(),
+			),
+		)
+	case Record:
+		ss := append(append([]string(nil), ss...), "")
+
+		return coretypes.NewBoxed(
+			t.toCore(func(t Type) coretypes.Type { return toCoreInAlgebraic(t, ss) }),
+		)
+	case Tuple:
+		ss := append(append([]string(nil), ss...), "")
+
+		return coretypes.NewBoxed(
+			t.toCore(func(t Type) coretypes.Type { return toCoreInAlgebraic(t, ss) }),
+		)
+	case Reference:
+		for i := len(ss) - 1; i >= 0; i-- {
+			if ss[i] == t.Name() {
+				return coretypes.NewBoxed(coretypes.NewIndex(len(ss) - 1 - i))
+			}
+		}
+	}
+
+	return t.ToCore()
+}
+
+// CoreConstructor returns a constructor in the core language.
+func (a Algebraic) CoreConstructor(i int) coreast.Constructor {
+	return coreast.NewConstructor(a.toCore(nil), i)
+}
+
+// VisitTypes visits types.
+func (a Algebraic) VisitTypes(f func(Type) error) error {
+	return f(a)
+}
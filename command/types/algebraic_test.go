@@ -0,0 +1,121 @@
+package types_test
+
+import (
+	"testing"
+
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlgebraicUnify(t *testing.T) {
+	a := types.NewAlgebraic("Foo", []types.Constructor{types.NewConstructor("Bar", nil)}, nil)
+
+	_, err := a.Unify(a)
+	assert.Nil(t, err)
+}
+
+func TestAlgebraicUnifyError(t *testing.T) {
+	a := types.NewAlgebraic("Foo", []types.Constructor{types.NewConstructor("Bar", nil)}, nil)
+
+	for _, tt := range []types.Type{
+		types.NewNumber(nil),
+		types.NewAlgebraic("Baz", []types.Constructor{types.NewConstructor("Bar", nil)}, nil),
+	} {
+		_, err := a.Unify(tt)
+		assert.Error(t, err)
+	}
+}
+
+func TestAlgebraicConstructorIndex(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Foo",
+		[]types.Constructor{types.NewConstructor("Bar", nil), types.NewConstructor("Baz", nil)},
+		nil,
+	)
+
+	i, ok := a.ConstructorIndex("Baz")
+	assert.True(t, ok)
+	assert.Equal(t, 1, i)
+
+	_, ok = a.ConstructorIndex("Qux")
+	assert.False(t, ok)
+}
+
+func TestAlgebraicConstructorElements(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Foo",
+		[]types.Constructor{
+			types.NewConstructor(
+				"Bar",
+				[]types.Type{types.NewNumber(nil), types.NewList(types.NewReference("Foo", nil), nil)},
+			),
+		},
+		nil,
+	)
+
+	assert.Equal(
+		t,
+		[]types.Type{types.NewNumber(nil), types.NewList(a, nil)},
+		a.ConstructorElements(0),
+	)
+}
+
+func TestAlgebraicToCore(t *testing.T) {
+	for _, c := range []struct {
+		constructors []types.Constructor
+		coreType     coretypes.Type
+	}{
+		{
+			[]types.Constructor{types.NewConstructor("Bar", nil)},
+			coretypes.NewBoxed(coretypes.NewAlgebraic(coretypes.NewConstructor())),
+		},
+		{
+			[]types.Constructor{
+				types.NewConstructor("Bar", []types.Type{types.NewNumber(nil), types.NewReference("Foo", nil)}),
+				types.NewConstructor("Baz", nil),
+			},
+			coretypes.NewBoxed(
+				coretypes.NewAlgebraic(
+					coretypes.NewConstructor(
+						types.NewNumber(nil).ToCore(),
+						coretypes.NewBoxed(coretypes.NewIndex(0)),
+					),
+					coretypes.NewConstructor(),
+				),
+			),
+		},
+		{
+			[]types.Constructor{
+				types.NewConstructor("Bar", []types.Type{types.NewList(types.NewReference("Foo", nil), nil)}),
+			},
+			coretypes.NewBoxed(
+				coretypes.NewAlgebraic(
+					coretypes.NewConstructor(
+						coretypes.NewBoxed(
+							coretypes.NewAlgebraic(
+								coretypes.NewConstructor(
+									coretypes.NewBoxed(coretypes.NewIndex(1)),
+									coretypes.NewBoxed(coretypes.NewIndex(0)),
+								),
+								coretypes.NewConstructor(),
+							),
+						),
+					),
+				),
+			),
+		},
+	} {
+		assert.Equal(t, c.coreType, types.NewAlgebraic("Foo", c.constructors, nil).ToCore())
+	}
+}
+
+func TestAlgebraicCoreConstructor(t *testing.T) {
+	a := types.NewAlgebraic(
+		"Foo",
+		[]types.Constructor{types.NewConstructor("Bar", nil), types.NewConstructor("Baz", nil)},
+		nil,
+	)
+
+	assert.Equal(t, 1, a.CoreConstructor(1).Index())
+}
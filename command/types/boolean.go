@@ -0,0 +1,57 @@
+package types
+
+import (
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// Boolean is a boolean type.
+type Boolean struct {
+	debugInformation *debug.Information
+}
+
+// NewBoolean creates a boolean type.
+func NewBoolean(i *debug.Information) Boolean {
+	return Boolean{i}
+}
+
+// Unify unifies itself with another type.
+func (b Boolean) Unify(t Type) ([]Equation, error) {
+	if _, ok := t.(Boolean); ok {
+		return nil, nil
+	}
+
+	return fallbackToVariable(b, t, NewTypeError("not a boolean", t.DebugInformation()))
+}
+
+// SubstituteVariable substitutes type variables.
+func (b Boolean) SubstituteVariable(v Variable, t Type) Type {
+	return b
+}
+
+// DebugInformation returns debug information.
+func (b Boolean) DebugInformation() *debug.Information {
+	return b.debugInformation
+}
+
+// ToCore returns a type in the core language.
+func (b Boolean) ToCore() coretypes.Type {
+	return coretypes.NewBoxed(coretypes.NewAlgebraic(coretypes.NewConstructor(), coretypes.NewConstructor()))
+}
+
+// CoreConstructor returns a constructor of a boolean value in the core language.
+func (b Boolean) CoreConstructor(v bool) coreast.Constructor {
+	i := 0
+
+	if v {
+		i = 1
+	}
+
+	return coreast.NewConstructor(coretypes.Unbox(b.ToCore()).(coretypes.Algebraic), i)
+}
+
+// VisitTypes visits types.
+func (b Boolean) VisitTypes(f func(Type) error) error {
+	return f(b)
+}
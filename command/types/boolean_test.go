@@ -0,0 +1,28 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/debug"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBooleanUnify(t *testing.T) {
+	_, err := types.NewBoolean(nil).Unify(types.NewBoolean(nil))
+	assert.Nil(t, err)
+}
+
+func TestBooleanUnifyError(t *testing.T) {
+	_, err := types.NewBoolean(nil).Unify(types.NewNumber(nil))
+	assert.Error(t, err)
+}
+
+func TestBooleanDebugInformation(t *testing.T) {
+	assert.Equal(t, (*debug.Information)(nil), types.NewBoolean(nil).DebugInformation())
+}
+
+func TestBooleanCoreConstructor(t *testing.T) {
+	assert.Equal(t, 0, types.NewBoolean(nil).CoreConstructor(false).Index())
+	assert.Equal(t, 1, types.NewBoolean(nil).CoreConstructor(true).Index())
+}
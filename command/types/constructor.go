@@ -0,0 +1,22 @@
+package types
+
+// Constructor is a constructor of an algebraic type.
+type Constructor struct {
+	name     string
+	elements []Type
+}
+
+// NewConstructor creates a constructor.
+func NewConstructor(n string, ts []Type) Constructor {
+	return Constructor{n, ts}
+}
+
+// Name returns a name.
+func (c Constructor) Name() string {
+	return c.name
+}
+
+// Elements returns element types.
+func (c Constructor) Elements() []Type {
+	return c.elements
+}
@@ -91,9 +91,9 @@ func (f Function) ToCore() coretypes.Type {
 
 // VisitTypes visits types.
 func (f Function) VisitTypes(ff func(Type) error) error {
-	if err := ff(f.argument); err != nil {
+	if err := f.argument.VisitTypes(ff); err != nil {
 		return err
-	} else if err := ff(f.result); err != nil {
+	} else if err := f.result.VisitTypes(ff); err != nil {
 		return err
 	}
 
@@ -0,0 +1,53 @@
+package types
+
+import (
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// Int is an integer type.
+type Int struct {
+	debugInformation *debug.Information
+}
+
+// NewInt creates an integer type.
+func NewInt(i *debug.Information) Int {
+	return Int{i}
+}
+
+// Unify unifies itself with another type.
+func (i Int) Unify(t Type) ([]Equation, error) {
+	if _, ok := t.(Int); ok {
+		return nil, nil
+	}
+
+	return fallbackToVariable(i, t, NewTypeError("not an integer", t.DebugInformation()))
+}
+
+// SubstituteVariable substitutes type variables.
+func (i Int) SubstituteVariable(v Variable, t Type) Type {
+	return i
+}
+
+// DebugInformation returns debug information.
+func (i Int) DebugInformation() *debug.Information {
+	return i.debugInformation
+}
+
+// ToCore returns a type in the core language.
+func (i Int) ToCore() coretypes.Type {
+	return coretypes.NewBoxed(
+		coretypes.NewAlgebraic(coretypes.NewConstructor(coretypes.NewInt64())),
+	)
+}
+
+// CoreConstructor returns a constructor in the core language.
+func (i Int) CoreConstructor() coreast.Constructor {
+	return coreast.NewConstructor(coretypes.Unbox(i.ToCore()).(coretypes.Algebraic), 0)
+}
+
+// VisitTypes visits types.
+func (i Int) VisitTypes(f func(Type) error) error {
+	return f(i)
+}
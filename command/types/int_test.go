@@ -0,0 +1,25 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/debug"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntUnify(t *testing.T) {
+	_, err := types.NewInt(nil).Unify(types.NewInt(nil))
+	assert.Nil(t, err)
+}
+
+func TestIntUnifyError(t *testing.T) {
+	_, err := types.NewInt(nil).Unify(
+		types.NewFunction(types.NewInt(nil), types.NewInt(nil), nil),
+	)
+	assert.Error(t, err)
+}
+
+func TestIntDebugInformation(t *testing.T) {
+	assert.Equal(t, (*debug.Information)(nil), types.NewInt(nil).DebugInformation())
+}
@@ -57,7 +57,7 @@ func (l List) ToCore() coretypes.Type {
 
 // VisitTypes visits types.
 func (l List) VisitTypes(f func(Type) error) error {
-	if err := f(l.element); err != nil {
+	if err := l.element.VisitTypes(f); err != nil {
 		return err
 	}
 
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// Parameter is a type parameter in type signatures.
+type Parameter struct {
+	name             string
+	debugInformation *debug.Information
+}
+
+// NewParameter creates a type parameter.
+func NewParameter(n string, i *debug.Information) Parameter {
+	return Parameter{n, i}
+}
+
+// Name returns a name.
+func (p Parameter) Name() string {
+	return p.name
+}
+
+// Unify unifies itself with another type.
+func (p Parameter) Unify(t Type) ([]Equation, error) {
+	if pp, ok := t.(Parameter); ok && pp.name == p.name {
+		return nil, nil
+	}
+
+	return fallbackToVariable(
+		p,
+		t,
+		NewTypeError(fmt.Sprintf("not a type parameter '%v'", p.name), t.DebugInformation()),
+	)
+}
+
+// SubstituteVariable substitutes type variables.
+func (p Parameter) SubstituteVariable(Variable, Type) Type {
+	return p
+}
+
+// DebugInformation returns debug information.
+func (p Parameter) DebugInformation() *debug.Information {
+	return p.debugInformation
+}
+
+// ToCore returns a type in the core language.
+func (Parameter) ToCore() coretypes.Type {
+	panic("unreachable")
+}
+
+// VisitTypes visits types.
+func (p Parameter) VisitTypes(f func(Type) error) error {
+	return f(p)
+}
@@ -0,0 +1,22 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameterUnify(t *testing.T) {
+	for _, tt := range []types.Type{types.NewParameter("a", nil), types.NewVariable(0, nil)} {
+		_, err := types.NewParameter("a", nil).Unify(tt)
+		assert.Nil(t, err)
+	}
+}
+
+func TestParameterUnifyError(t *testing.T) {
+	for _, tt := range []types.Type{types.NewParameter("b", nil), types.NewNumber(nil)} {
+		_, err := types.NewParameter("a", nil).Unify(tt)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// Record is a record type.
+type Record struct {
+	fields           map[string]Type
+	debugInformation *debug.Information
+}
+
+// NewRecord creates a record type.
+func NewRecord(fs map[string]Type, i *debug.Information) Record {
+	return Record{fs, i}
+}
+
+// Fields returns fields.
+func (r Record) Fields() map[string]Type {
+	return r.fields
+}
+
+// FieldNames returns field names in a stable order.
+func (r Record) FieldNames() []string {
+	ss := make([]string, 0, len(r.fields))
+
+	for s := range r.fields {
+		ss = append(ss, s)
+	}
+
+	sort.Strings(ss)
+
+	return ss
+}
+
+// FieldIndex returns an index of a field in a stable order.
+func (r Record) FieldIndex(s string) (int, bool) {
+	for i, ss := range r.FieldNames() {
+		if ss == s {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// Unify unifies itself with another type.
+func (r Record) Unify(t Type) ([]Equation, error) {
+	rr, ok := t.(Record)
+
+	if !ok {
+		return fallbackToVariable(r, t, NewTypeError("not a record", t.DebugInformation()))
+	} else if len(r.fields) != len(rr.fields) {
+		return nil, NewTypeError("record fields mismatch", t.DebugInformation())
+	}
+
+	es := []Equation{}
+
+	for _, s := range r.FieldNames() {
+		t, ok := rr.fields[s]
+
+		if !ok {
+			return nil, NewTypeError(fmt.Sprintf("field '%v' not found", s), rr.debugInformation)
+		}
+
+		ees, err := r.fields[s].Unify(t)
+
+		if err != nil {
+			return nil, err
+		}
+
+		es = append(es, ees...)
+	}
+
+	return es, nil
+}
+
+// SubstituteVariable substitutes type variables.
+func (r Record) SubstituteVariable(v Variable, t Type) Type {
+	fs := make(map[string]Type, len(r.fields))
+
+	for s, tt := range r.fields {
+		fs[s] = tt.SubstituteVariable(v, t)
+	}
+
+	return NewRecord(fs, r.debugInformation)
+}
+
+// DebugInformation returns debug information.
+func (r Record) DebugInformation() *debug.Information {
+	return r.debugInformation
+}
+
+// ToCore returns a type in the core language.
+func (r Record) ToCore() coretypes.Type {
+	return coretypes.NewBoxed(r.toCore(func(t Type) coretypes.Type { return t.ToCore() }))
+}
+
+func (r Record) toCore(f func(Type) coretypes.Type) coretypes.Algebraic {
+	var ts []coretypes.Type
+
+	for _, s := range r.FieldNames() {
+		ts = append(ts, f(r.fields[s]))
+	}
+
+	return coretypes.NewAlgebraic(coretypes.NewConstructor(ts...))
+}
+
+// CoreConstructor returns a constructor in the core language.
+func (r Record) CoreConstructor() coreast.Constructor {
+	return coreast.NewConstructor(coretypes.Unbox(r.ToCore()).(coretypes.Algebraic), 0)
+}
+
+// VisitTypes visits types.
+func (r Record) VisitTypes(f func(Type) error) error {
+	for _, s := range r.FieldNames() {
+		if err := r.fields[s].VisitTypes(f); err != nil {
+			return err
+		}
+	}
+
+	return f(r)
+}
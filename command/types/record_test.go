@@ -0,0 +1,91 @@
+package types_test
+
+import (
+	"testing"
+
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordUnify(t *testing.T) {
+	r := types.NewRecord(
+		map[string]types.Type{"foo": types.NewNumber(nil), "bar": types.NewString(nil)},
+		nil,
+	)
+
+	es, err := r.Unify(r)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(es))
+}
+
+func TestRecordUnifyWithVariables(t *testing.T) {
+	es, err := types.NewRecord(map[string]types.Type{"foo": types.NewNumber(nil)}, nil).Unify(
+		types.NewRecord(map[string]types.Type{"foo": types.NewVariable(0, nil)}, nil),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]types.Equation{types.NewEquation(types.NewNumber(nil), types.NewVariable(0, nil))},
+		es,
+	)
+}
+
+func TestRecordUnifyError(t *testing.T) {
+	r := types.NewRecord(map[string]types.Type{"foo": types.NewNumber(nil)}, nil)
+
+	for _, tt := range []types.Type{
+		types.NewNumber(nil),
+		types.NewRecord(map[string]types.Type{}, nil),
+		types.NewRecord(map[string]types.Type{"bar": types.NewNumber(nil)}, nil),
+		types.NewRecord(map[string]types.Type{"foo": types.NewString(nil)}, nil),
+	} {
+		_, err := r.Unify(tt)
+		assert.Error(t, err)
+	}
+}
+
+func TestRecordFieldNames(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"bar", "baz", "foo"},
+		types.NewRecord(
+			map[string]types.Type{
+				"foo": types.NewNumber(nil),
+				"bar": types.NewNumber(nil),
+				"baz": types.NewNumber(nil),
+			},
+			nil,
+		).FieldNames(),
+	)
+}
+
+func TestRecordFieldIndex(t *testing.T) {
+	r := types.NewRecord(
+		map[string]types.Type{"foo": types.NewNumber(nil), "bar": types.NewNumber(nil)},
+		nil,
+	)
+
+	i, ok := r.FieldIndex("foo")
+	assert.True(t, ok)
+	assert.Equal(t, 1, i)
+
+	_, ok = r.FieldIndex("baz")
+	assert.False(t, ok)
+}
+
+func TestRecordToCore(t *testing.T) {
+	assert.Equal(
+		t,
+		coretypes.NewBoxed(
+			coretypes.NewAlgebraic(
+				coretypes.NewConstructor(types.NewString(nil).ToCore(), types.NewNumber(nil).ToCore()),
+			),
+		),
+		types.NewRecord(
+			map[string]types.Type{"foo": types.NewNumber(nil), "bar": types.NewString(nil)},
+			nil,
+		).ToCore(),
+	)
+}
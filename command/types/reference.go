@@ -0,0 +1,53 @@
+package types
+
+import (
+	"fmt"
+
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// Reference is a reference to a named type.
+// It is resolved into an actual type after parsing unless it refers to its
+// parent algebraic type recursively.
+type Reference struct {
+	name             string
+	debugInformation *debug.Information
+}
+
+// NewReference creates a reference.
+func NewReference(n string, i *debug.Information) Reference {
+	return Reference{n, i}
+}
+
+// Name returns a name.
+func (r Reference) Name() string {
+	return r.name
+}
+
+// Unify unifies itself with another type.
+// It always fails because references are resolved before type inference.
+func (r Reference) Unify(Type) ([]Equation, error) {
+	return nil, NewTypeError(fmt.Sprintf("type '%v' not resolved", r.name), r.debugInformation)
+}
+
+// SubstituteVariable substitutes type variables.
+func (r Reference) SubstituteVariable(Variable, Type) Type {
+	return r
+}
+
+// DebugInformation returns debug information.
+func (r Reference) DebugInformation() *debug.Information {
+	return r.debugInformation
+}
+
+// ToCore returns a type in the core language.
+// Modules with unresolved references are rejected on type inference.
+func (Reference) ToCore() coretypes.Type {
+	panic("unreachable")
+}
+
+// VisitTypes visits types.
+func (r Reference) VisitTypes(f func(Type) error) error {
+	return f(r)
+}
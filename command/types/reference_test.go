@@ -0,0 +1,20 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/debug"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferenceUnifyError(t *testing.T) {
+	i := debug.NewInformation("", 1, 1, "")
+
+	for _, tt := range []types.Type{types.NewReference("Foo", nil), types.NewNumber(nil)} {
+		_, err := types.NewReference("Foo", i).Unify(tt)
+
+		assert.Error(t, err)
+		assert.Equal(t, i, err.(debug.Error).DebugInformation())
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// String is a string type.
+type String struct {
+	debugInformation *debug.Information
+}
+
+// NewString creates a string type.
+func NewString(i *debug.Information) String {
+	return String{i}
+}
+
+// Unify unifies itself with another type.
+func (s String) Unify(t Type) ([]Equation, error) {
+	if _, ok := t.(String); ok {
+		return nil, nil
+	}
+
+	return fallbackToVariable(s, t, NewTypeError("not a string", t.DebugInformation()))
+}
+
+// SubstituteVariable substitutes type variables.
+func (s String) SubstituteVariable(v Variable, t Type) Type {
+	return s
+}
+
+// DebugInformation returns debug information.
+func (s String) DebugInformation() *debug.Information {
+	return s.debugInformation
+}
+
+// ToCore returns a type in the core language.
+// Strings are represented as lazy lists of unboxed code points.
+func (s String) ToCore() coretypes.Type {
+	return coretypes.NewBoxed(
+		coretypes.NewAlgebraic(
+			coretypes.NewConstructor(
+				coretypes.NewFloat64(),
+				coretypes.NewBoxed(coretypes.NewIndex(0)),
+			),
+			coretypes.NewConstructor(),
+		),
+	)
+}
+
+// VisitTypes visits types.
+func (s String) VisitTypes(f func(Type) error) error {
+	return f(s)
+}
@@ -0,0 +1,28 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/debug"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringUnify(t *testing.T) {
+	_, err := types.NewString(nil).Unify(types.NewString(nil))
+	assert.Nil(t, err)
+}
+
+func TestStringUnifyError(t *testing.T) {
+	for _, tt := range []types.Type{
+		types.NewNumber(nil),
+		types.NewList(types.NewNumber(nil), nil),
+	} {
+		_, err := types.NewString(nil).Unify(tt)
+		assert.Error(t, err)
+	}
+}
+
+func TestStringDebugInformation(t *testing.T) {
+	assert.Equal(t, (*debug.Information)(nil), types.NewString(nil).DebugInformation())
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	coreast "github.com/raviqqe/lazy-ein/command/core/ast"
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/debug"
+)
+
+// Tuple is a tuple type.
+type Tuple struct {
+	elements         []Type
+	debugInformation *debug.Information
+}
+
+// NewTuple creates a tuple type.
+func NewTuple(ts []Type, i *debug.Information) Tuple {
+	return Tuple{ts, i}
+}
+
+// Elements returns element types.
+func (t Tuple) Elements() []Type {
+	return t.elements
+}
+
+// Unify unifies itself with another type.
+func (t Tuple) Unify(tt Type) ([]Equation, error) {
+	ttt, ok := tt.(Tuple)
+
+	if !ok {
+		return fallbackToVariable(t, tt, NewTypeError("not a tuple", tt.DebugInformation()))
+	} else if len(t.elements) != len(ttt.elements) {
+		return nil, NewTypeError("tuple elements mismatch", tt.DebugInformation())
+	}
+
+	es := []Equation{}
+
+	for i, t := range t.elements {
+		ees, err := t.Unify(ttt.elements[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		es = append(es, ees...)
+	}
+
+	return es, nil
+}
+
+// SubstituteVariable substitutes type variables.
+func (t Tuple) SubstituteVariable(v Variable, tt Type) Type {
+	ts := make([]Type, 0, len(t.elements))
+
+	for _, t := range t.elements {
+		ts = append(ts, t.SubstituteVariable(v, tt))
+	}
+
+	return NewTuple(ts, t.debugInformation)
+}
+
+// DebugInformation returns debug information.
+func (t Tuple) DebugInformation() *debug.Information {
+	return t.debugInformation
+}
+
+// ToCore returns a type in the core language.
+func (t Tuple) ToCore() coretypes.Type {
+	return coretypes.NewBoxed(t.toCore(func(t Type) coretypes.Type { return t.ToCore() }))
+}
+
+func (t Tuple) toCore(f func(Type) coretypes.Type) coretypes.Algebraic {
+	ts := make([]coretypes.Type, 0, len(t.elements))
+
+	for _, t := range t.elements {
+		ts = append(ts, f(t))
+	}
+
+	return coretypes.NewAlgebraic(coretypes.NewConstructor(ts...))
+}
+
+// CoreConstructor returns a constructor in the core language.
+func (t Tuple) CoreConstructor() coreast.Constructor {
+	return coreast.NewConstructor(coretypes.Unbox(t.ToCore()).(coretypes.Algebraic), 0)
+}
+
+// VisitTypes visits types.
+func (t Tuple) VisitTypes(f func(Type) error) error {
+	for _, tt := range t.elements {
+		if err := tt.VisitTypes(f); err != nil {
+			return err
+		}
+	}
+
+	return f(t)
+}
@@ -0,0 +1,59 @@
+package types_test
+
+import (
+	"testing"
+
+	coretypes "github.com/raviqqe/lazy-ein/command/core/types"
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTupleUnify(t *testing.T) {
+	tt := types.NewTuple([]types.Type{types.NewNumber(nil), types.NewString(nil)}, nil)
+
+	es, err := tt.Unify(tt)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(es))
+}
+
+func TestTupleUnifyWithVariables(t *testing.T) {
+	es, err := types.NewTuple([]types.Type{types.NewNumber(nil), types.NewNumber(nil)}, nil).Unify(
+		types.NewTuple([]types.Type{types.NewNumber(nil), types.NewVariable(0, nil)}, nil),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]types.Equation{types.NewEquation(types.NewNumber(nil), types.NewVariable(0, nil))},
+		es,
+	)
+}
+
+func TestTupleUnifyError(t *testing.T) {
+	tt := types.NewTuple([]types.Type{types.NewNumber(nil), types.NewNumber(nil)}, nil)
+
+	for _, ttt := range []types.Type{
+		types.NewNumber(nil),
+		types.NewTuple([]types.Type{types.NewNumber(nil)}, nil),
+		types.NewTuple([]types.Type{types.NewNumber(nil), types.NewString(nil)}, nil),
+		types.NewTuple(
+			[]types.Type{types.NewNumber(nil), types.NewNumber(nil), types.NewNumber(nil)},
+			nil,
+		),
+	} {
+		_, err := tt.Unify(ttt)
+		assert.Error(t, err)
+	}
+}
+
+func TestTupleToCore(t *testing.T) {
+	assert.Equal(
+		t,
+		coretypes.NewBoxed(
+			coretypes.NewAlgebraic(
+				coretypes.NewConstructor(types.NewNumber(nil).ToCore(), types.NewString(nil).ToCore()),
+			),
+		),
+		types.NewTuple([]types.Type{types.NewNumber(nil), types.NewString(nil)}, nil).ToCore(),
+	)
+}
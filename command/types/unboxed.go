@@ -56,7 +56,7 @@ func (u Unboxed) ToCore() coretypes.Type {
 
 // VisitTypes visits types.
 func (u Unboxed) VisitTypes(f func(Type) error) error {
-	if err := f(u.content); err != nil {
+	if err := u.content.VisitTypes(f); err != nil {
 		return err
 	}
 
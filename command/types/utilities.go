@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 // Box boxes a type.
 func Box(t Type) Type {
 	if u, ok := t.(Unboxed); ok {
@@ -9,6 +11,17 @@ func Box(t Type) Type {
 	return t
 }
 
+// IsPolymorphic checks if a type has type parameters.
+func IsPolymorphic(t Type) bool {
+	return t.VisitTypes(func(t Type) error {
+		if _, ok := t.(Parameter); ok {
+			return fmt.Errorf("type parameter found")
+		}
+
+		return nil
+	}) != nil
+}
+
 func fallbackToVariable(t, tt Type, err error) ([]Equation, error) {
 	if _, ok := tt.(Variable); ok {
 		return []Equation{NewEquation(t, tt)}, nil
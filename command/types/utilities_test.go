@@ -0,0 +1,26 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/raviqqe/lazy-ein/command/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPolymorphic(t *testing.T) {
+	for _, tt := range []types.Type{
+		types.NewParameter("a", nil),
+		types.NewFunction(types.NewNumber(nil), types.NewParameter("a", nil), nil),
+		types.NewList(types.NewParameter("a", nil), nil),
+	} {
+		assert.True(t, types.IsPolymorphic(tt))
+	}
+
+	for _, tt := range []types.Type{
+		types.NewNumber(nil),
+		types.NewFunction(types.NewNumber(nil), types.NewNumber(nil), nil),
+		types.NewVariable(0, nil),
+	} {
+		assert.False(t, types.IsPolymorphic(tt))
+	}
+}
@@ -0,0 +1,75 @@
+Feature: Algebraic data types
+  Scenario: Use algebraic data types
+    Given a file named "main.ein" with:
+    """
+    type Shape = Circle Number | Rectangle Number Number
+
+    width : Shape -> Number
+    width s =
+      case s of
+        Circle r -> 2 * r
+        Rectangle w h -> w
+
+    main : Number -> [Number]
+    main x = [width (Circle 20) + width (Rectangle 2 x)]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Use recursive algebraic data types
+    Given a file named "main.ein" with:
+    """
+    type Tree = Leaf | Node Tree Number Tree
+
+    sum : Tree -> Number
+    sum t =
+      case t of
+        Leaf -> 0
+        Node l x r -> sum l + x + sum r
+
+    main : Number -> [Number]
+    main x = [sum (Node (Node Leaf 10 Leaf) 30 (Node Leaf (x - 40) Leaf))]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Use default alternatives in algebraic case expressions
+    Given a file named "main.ein" with:
+    """
+    type Color = Red | Green | Blue
+
+    f : Color -> Number
+    f c =
+      case c of
+        Red -> 13
+        c -> 42
+
+    main : Number -> [Number]
+    main x = [f Blue]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Fail to export binds of algebraic data types
+    Given a file named "foo.ein" with:
+    """
+    export { x }
+
+    type Color = Red | Green | Blue
+
+    x : Color
+    x = Red
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/foo"
+
+    main : Number -> [Number]
+    main x = [42]
+    """
+    When I run `ein build main.ein`
+    Then the exit status should not be 0
+    And the stderr should contain "bind 'x' cannot be exported because its type contains module-local type 'Color'"
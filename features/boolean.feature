@@ -0,0 +1,38 @@
+Feature: Boolean
+  Scenario Outline: Use comparison operators
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x =
+      case <comparison> of
+        True -> [42]
+        False -> [13]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+    Examples:
+      | comparison |
+      | x == 42    |
+      | x /= 0     |
+      | 1 < 2      |
+      | 1 <= 1     |
+      | 2 > 1      |
+      | 1 >= 1     |
+      | 1 + 1 == 2 |
+
+  Scenario: Define boolean variables
+    Given a file named "main.ein" with:
+    """
+    b : Bool
+    b = False
+
+    main : Number -> [Number]
+    main x =
+      case b of
+        True -> [13]
+        b -> [42]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
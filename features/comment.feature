@@ -0,0 +1,24 @@
+Feature: Comment
+  Scenario: Write comments
+    Given a file named "main.ein" with:
+    """
+    -- This is a module.
+
+    {-
+      This is a {- nested -} block comment.
+    -}
+
+    -- Adds two numbers.
+    add : Number -> Number -> Number
+    add x y = x + y -- in a line
+
+    main : Number -> [Number]
+    main x =
+      let
+        -- in a let expression
+        y = {- in an expression -} add x 0
+      in [y]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
@@ -0,0 +1,42 @@
+Feature: Foreign functions
+  Scenario: Call foreign functions
+    Given a file named "main.ein" with:
+    """
+    foreign import "sin" sin : Number -> Number
+    foreign import "pow" power : Number -> Number -> Number
+
+    main : Number -> [Number]
+    main x = [sin 0, power 2 10]
+    """
+    When I successfully run `ein build -l m main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[0,1024]"
+
+  Scenario: Export foreign functions
+    Given a file named "math.ein" with:
+    """
+    export { sin }
+
+    foreign import "sin" sin : Number -> Number
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/math"
+
+    main : Number -> [Number]
+    main x = [math.sin 0]
+    """
+    When I successfully run `ein build -l m main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[0]"
+
+  Scenario: Fail to declare foreign functions of invalid types
+    Given a file named "main.ein" with:
+    """
+    foreign import "strlen" length : String -> Number
+
+    main : Number -> [Number]
+    main x = [length "foo"]
+    """
+    When I run `ein build main.ein`
+    Then the exit status should not be 0
@@ -19,7 +19,7 @@ Feature: Function
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
     Examples:
       | expression     |
       | 42             |
@@ -53,7 +53,7 @@ Feature: Function
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
     Examples:
       | bind                         |
       | main = f                     |
@@ -0,0 +1,32 @@
+Feature: If
+  Scenario Outline: Use if expressions
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x = [<if expression>]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+    Examples:
+      | if expression                                 |
+      | if True then 42 else 13                       |
+      | if False then 13 else 42                      |
+      | if x == 42 then x else 13                     |
+      | if x < 0 then 13 else if x > 0 then 42 else 0 |
+
+  Scenario: Use multi-line if expressions
+    Given a file named "main.ein" with:
+    """
+    f : Number -> Number
+    f x =
+      if x > 0
+        then x
+        else 0 - x
+
+    main : Number -> [Number]
+    main x = [f (0 - x)]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
@@ -0,0 +1,173 @@
+Feature: Import
+  Background:
+    Given a file named "a/util.ein" with:
+    """
+    export { x }
+
+    x : Number
+    x = 21
+    """
+    And a file named "b/util.ein" with:
+    """
+    export { x }
+
+    x : Number
+    x = 2
+    """
+
+  Scenario: Import modules with aliases
+    Given a file named "main.ein" with:
+    """
+    import "tmp/aruba/a/util"
+    import "tmp/aruba/b/util" as butil
+
+    main : Number -> [Number]
+    main x = [util.x * butil.x]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Import specific names from modules
+    Given a file named "main.ein" with:
+    """
+    import "tmp/aruba/a/util" { x }
+    import "tmp/aruba/b/util" as butil
+
+    main : Number -> [Number]
+    main y = [x * butil.x]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Re-export names from imported modules
+    Given a file named "facade.ein" with:
+    """
+    export { util.x, y }
+
+    import "tmp/aruba/a/util"
+
+    y : Number
+    y = 2
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/facade" { x, y }
+
+    main : Number -> [Number]
+    main z = [x * y]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Re-export whole modules
+    Given a file named "facade.ein" with:
+    """
+    export module "tmp/aruba/b/util"
+
+    import "tmp/aruba/b/util"
+
+    y : Number
+    y = util.x
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/a/util"
+    import "tmp/aruba/b/util" as butil
+    import "tmp/aruba/facade"
+
+    main : Number -> [Number]
+    main z = [util.x * facade.x * butil.x / 2]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Fail to import modules with the same qualifier
+    Given a file named "main.ein" with:
+    """
+    import "tmp/aruba/a/util"
+    import "tmp/aruba/b/util"
+
+    main : Number -> [Number]
+    main x = [util.x]
+    """
+    When I run `ein build main.ein`
+    Then the exit status should not be 0
+    And the stderr should contain "import qualifier 'util' is duplicate"
+
+  Scenario: Fail to build modules importing each other
+    Given a file named "foo.ein" with:
+    """
+    export { x }
+
+    import "tmp/aruba/bar"
+
+    x : Number
+    x = bar.y
+    """
+    And a file named "bar.ein" with:
+    """
+    export { y }
+
+    import "tmp/aruba/foo"
+
+    y : Number
+    y = foo.x
+    """
+    When I run `ein build foo.ein`
+    Then the exit status should not be 0
+    And the stderr should contain "circular imports detected: tmp/aruba/foo -> tmp/aruba/bar -> tmp/aruba/foo"
+
+  Scenario: Import JSON files
+    Given a file named "config.json" with:
+    """
+    { "name": "foo", "ports": [21, 21], "server": { "debug": true } }
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/config.json"
+    import "tmp/aruba/config.json" { server }
+
+    main : Number -> [Number]
+    main x =
+      case config.ports of
+        [p, q] -> if server.debug then [p + q] else []
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Import JSON files with snake case and kebab case keys
+    Given a file named "config.json" with:
+    """
+    { "max_count": 40, "min-count": 2 }
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/config.json"
+
+    main : Number -> [Number]
+    main x = [config.maxCount + config.minCount]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Fail to import JSON files with null
+    Given a file named "config.json" with:
+    """
+    { "x": null }
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/config.json"
+
+    main : Number -> [Number]
+    main x = [x]
+    """
+    When I run `ein build main.ein`
+    Then the exit status should not be 0
+    And the stderr should contain "null is not supported"
@@ -0,0 +1,28 @@
+Feature: Lambda expressions
+  Scenario: Apply lambda expressions
+    Given a file named "main.ein" with:
+    """
+    apply : (Number -> Number) -> Number -> Number
+    apply f x = f x
+
+    main : Number -> [Number]
+    main x = [apply (\y -> y + 1) (x - 1)]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Return lambda expressions capturing variables
+    Given a file named "main.ein" with:
+    """
+    add : Number -> Number -> Number
+    add x = \y -> x + y
+
+    main : Number -> [Number]
+    main x =
+      let f = if x > 0 then \y z -> y * z else add
+      in [f (add x 0) 1]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
@@ -10,7 +10,7 @@ Feature: List
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
 
   Scenario Outline: Use list case expressions with single alternatives
     Given a file named "main.ein" with:
@@ -20,7 +20,7 @@ Feature: List
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
     Examples:
       | case expression                     |
       | case [42] of [42] -> 42             |
@@ -44,7 +44,7 @@ Feature: List
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
 
   Scenario: Use complex list case expressions
     Given a file named "main.ein" with:
@@ -58,4 +58,31 @@ Feature: List
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Expand lists in list literals
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x =
+      let xs = [x - 1]
+      in [...xs, x, ...[], ...xs, x + 1, ...xs]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[41,42,41,43,41]"
+
+  Scenario: Append lists lazily
+    Given a file named "main.ein" with:
+    """
+    repeat : Number -> [Number]
+    repeat x = [...[x], ...repeat x]
+
+    main : Number -> [Number]
+    main x =
+      case repeat x of
+        [y, ...ys] -> [y]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
@@ -0,0 +1,43 @@
+Feature: Main function
+  Scenario: Read stdin
+    Given a file named "main.ein" with:
+    """
+    main : [String] -> String -> String
+    main args s = s
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c 'printf héllo | ./a.out'`
+    Then the stdout from "sh -c 'printf héllo | ./a.out'" should contain exactly "héllo"
+
+  Scenario: Read stdin lazily
+    Given a file named "main.ein" with:
+    """
+    main : [String] -> String -> String
+    main args s = "42"
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c 'yes | ./a.out'`
+    Then the stdout from "sh -c 'yes | ./a.out'" should contain exactly "42"
+
+  Scenario: Read command-line arguments
+    Given a file named "main.ein" with:
+    """
+    main : [String] -> String -> String
+    main args s =
+      case args of
+        [a, ...bs] -> a
+        [] -> "no arguments"
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c './a.out foo bar'`
+    Then the stdout from "sh -c './a.out foo bar'" should contain exactly "foo"
+
+  Scenario: Fail to build main functions of invalid types
+    Given a file named "main.ein" with:
+    """
+    main : String -> Number
+    main s = 42
+    """
+    When I run `ein build main.ein`
+    Then the exit status should not be 0
+    And the stderr should contain "main function must be of type"
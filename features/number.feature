@@ -10,14 +10,23 @@ Feature: Number
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
     Examples:
-      | expression          |
-      | 42                  |
-      | 40 + 2              |
-      | 21 + 7 * 3          |
-      | 7 + 12 / 3 * 10 - 5 |
-      | f 40 + 2            |
+      | expression                             |
+      | 42                                     |
+      | 40 + 2                                 |
+      | 21 + 7 * 3                             |
+      | 7 + 12 / 3 * 10 - 5                    |
+      | f 40 + 2                               |
+      | 44 % 5 * 10 + 2                        |
+      | 0 - (-44 % 5) * 10 + 2                 |
+      | 44 % (-5) * 10 + 2                     |
+      | toNumber (toInt (-44) % 5 * (-10) + 2) |
+      | toNumber (toInt 44 % (-5) * 10 + 2)    |
+      | 2 ^ 5 + 10                             |
+      | 2 ^ 3 ^ 0 * 21                         |
+      | -(f 2) + 44                            |
+      | - 8 + 50                               |
 
   Scenario: Use case expressions
     Given a file named "main.ein" with:
@@ -27,7 +36,7 @@ Feature: Number
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
 
   Scenario: Use default alternatives in case expressions
     Given a file named "main.ein" with:
@@ -40,7 +49,7 @@ Feature: Number
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
 
   Scenario: Use nested case expressions
     Given a file named "main.ein" with:
@@ -55,7 +64,7 @@ Feature: Number
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "42"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
 
   Scenario: Sum numbers up to 100
     Given a file named "main.ein" with:
@@ -71,4 +80,4 @@ Feature: Number
     """
     When I successfully run `ein build main.ein`
     And I successfully run `sh -c ./a.out`
-    Then the stdout from "sh -c ./a.out" should contain exactly "5050"
+    Then the stdout from "sh -c ./a.out" should contain exactly "[5050]"
@@ -0,0 +1,73 @@
+Feature: Polymorphism
+  Scenario: Use polymorphic functions
+    Given a file named "main.ein" with:
+    """
+    id : a -> a
+    id x = x
+
+    const : a -> b -> a
+    const x y = x
+
+    main : Number -> [Number]
+    main x = [const (id x) (id "foo")]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Use polymorphic functions on lists
+    Given a file named "main.ein" with:
+    """
+    length : [a] -> Number
+    length xs =
+      case xs of
+        [] -> 0
+        [y, ...ys] -> 1 + length ys
+
+    main : Number -> [Number]
+    main x = [x + length ["foo"] - length [x]]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Generalize let binds
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x =
+      let f y = y
+          s = f "foo"
+      in [f x]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Use polymorphic functions in other modules
+    Given a file named "list.ein" with:
+    """
+    export { length }
+
+    length : [a] -> Number
+    length xs = count 0 xs
+
+    count : Number -> [a] -> Number
+    count n xs =
+      case xs of
+        [] -> n + offset
+        [y, ...ys] -> count (n + 1) ys
+
+    offset : Number
+    offset = 0
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/list"
+
+    main : Number -> [Number]
+    main x = [x + list.length ["foo"] - list.length [x]]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
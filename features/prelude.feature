@@ -0,0 +1,100 @@
+Feature: Prelude
+  Scenario: Use prelude functions
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x = [sum (take 3 (map (\y -> y * 2) (range 6 x)))]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Use prelude functions on lists of any types
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x =
+      let ss = map (\y -> [y]) (reverse (take 2 ["foo", "bar", "baz"]))
+      in [foldl (\n s -> n + length s) x (filter (\s -> length s > 0) ss) - 2]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Shadow prelude functions
+    Given a file named "main.ein" with:
+    """
+    length : [Number] -> Number
+    length xs = 40
+
+    main : Number -> [Number]
+    main x = [length (reverse [x]) + (\sum -> sum) 2]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Shadow prelude functions with imported ones
+    Given a file named "foo.ein" with:
+    """
+    export { sum }
+
+    sum : [Number] -> Number
+    sum xs = 42
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/foo" { sum }
+
+    main : Number -> [Number]
+    main x = [sum [x]]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Use prelude functions in submodules
+    Given a file named "foo.ein" with:
+    """
+    export { double }
+
+    double : [Number] -> [Number]
+    double xs = map (\x -> x * 2) xs
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/foo"
+
+    main : Number -> [Number]
+    main x = filter (\y -> y == 42) (foo.double [x / 2, x])
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Opt out of the prelude
+    Given a file named "main.ein" with:
+    """
+    import prelude {}
+
+    main : Number -> [Number]
+    main x = [sum [x]]
+    """
+    When I run `ein build main.ein`
+    Then the exit status should not be 0
+    And the stderr should contain "variable 'sum' not found"
+
+  Scenario: Define functions of the same names as prelude ones without the prelude
+    Given a file named "main.ein" with:
+    """
+    import prelude {}
+
+    map : Number -> Number
+    map x = x
+
+    main : Number -> [Number]
+    main x = [map x]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
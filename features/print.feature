@@ -0,0 +1,77 @@
+Feature: Print
+  Scenario Outline: Print results of main functions as JSON
+    Given a file named "main.ein" with:
+    """
+    type Tree = Leaf | Node Tree Number Tree
+
+    main : Number -> <type>
+    main x = <expression>
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly:
+    """
+    <output>
+    """
+    Examples:
+      | type                           | expression              | output                      |
+      | Number                         | x                       | 42                          |
+      | Number                         | 0 - x                   | -42                         |
+      | Number                         | 1.5                     | 1.5                         |
+      | Number                         | 0 - 0.05                | -0.05                       |
+      | Number                         | 0.0000000001            | 1e-10                       |
+      | Number                         | 0.1 + 0.2               | 0.30000000000000004         |
+      | Number                         | 1 / 3                   | 0.3333333333333333          |
+      | Number                         | 10 ^ 21                 | 1e+21                       |
+      | Number                         | 2 ^ 70                  | 1.1805916207174113e+21      |
+      | Number                         | 1 / 0                   | null                        |
+      | Bool                           | True                    | true                        |
+      | [Number]                       | [1, 2, 3]               | [1,2,3]                     |
+      | [Number]                       | []                      | []                          |
+      | [String]                       | ["foo", "bar"]          | ["foo","bar"]               |
+      | (Number, Bool)                 | (x, False)              | [42,false]                  |
+      | { foo : Number, bar : [Bool] } | { foo: x, bar: [True] } | {"bar":[true],"foo":42}     |
+      | Tree                           | Node Leaf x Leaf        | {"Node":["Leaf",42,"Leaf"]} |
+
+  Scenario: Print strings with escapes
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [String]
+    main x = ["\"\\\n"]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly:
+    """
+    ["\"\\\u000a"]
+    """
+
+  Scenario: Print strings as they are
+    Given a file named "main.ein" with:
+    """
+    main : Number -> String
+    main x = "foo"
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "foo"
+
+  Scenario: Print infinite lists lazily
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x = let xs = [x, ...xs] in xs
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c './a.out | head -c 9'`
+    Then the stdout from "sh -c './a.out | head -c 9'" should contain exactly "[42,42,42"
+
+  Scenario: Fail to print functions
+    Given a file named "main.ein" with:
+    """
+    main : Number -> Number -> Number
+    main x y = x
+    """
+    When I run `ein build main.ein`
+    Then the exit status should not be 0
+    And the stderr should contain "values of this type cannot be printed"
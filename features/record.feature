@@ -0,0 +1,41 @@
+Feature: Record
+  Scenario: Access record fields
+    Given a file named "main.ein" with:
+    """
+    person : { name : String, age : Number }
+    person = { name: "foo", age: 42 }
+
+    main : Number -> [Number]
+    main x = [person.age]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Update records
+    Given a file named "main.ein" with:
+    """
+    older : { name : String, age : Number } -> { name : String, age : Number }
+    older p = { ...p, age: p.age + 1 }
+
+    main : Number -> [Number]
+    main x =
+      let p = older { name: "foo", age: x - 1 }
+      in [p.age]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Use nested records
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x =
+      let r = { foo: { bar: x } }
+          s = r.foo
+      in [s.bar]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
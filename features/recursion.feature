@@ -0,0 +1,42 @@
+Feature: Recursion
+  Scenario: Recurse deeply with tail calls
+    Given a file named "main.ein" with:
+    """
+    count : Number -> Number
+    count n = if n == 0 then 42 else count (n - 1)
+
+    main : Number -> [Number]
+    main x = [count 1000000]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Recurse deeply with accumulators
+    Given a file named "main.ein" with:
+    """
+    sum : Number -> Number -> Number
+    sum n s = if s < 0 then 0 else if n == 0 then s else sum (n - 1) (s + n)
+
+    main : Number -> [Number]
+    main x = [sum 1000000 0]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[500000500000]"
+
+  Scenario: Recurse deeply with mutually recursive functions
+    Given a file named "main.ein" with:
+    """
+    isEven : Number -> Bool
+    isEven n = if n == 0 then True else isOdd (n - 1)
+
+    isOdd : Number -> Bool
+    isOdd n = if n == 0 then False else isEven (n - 1)
+
+    main : Number -> [Bool]
+    main x = [isEven 1000000]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[true]"
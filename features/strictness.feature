@@ -0,0 +1,13 @@
+Feature: Strictness
+  Scenario: Accumulate arguments without building thunks
+    Given a file named "main.ein" with:
+    """
+    sum : Number -> Number -> Number
+    sum n s = if n == 0 then s else sum (n - 1) (s + n)
+
+    main : Number -> [Number]
+    main x = [sum 1000000 0]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[500000500000]"
@@ -0,0 +1,44 @@
+Feature: String
+  Scenario: Define string variables
+    Given a file named "main.ein" with:
+    """
+    s : String
+    s = "foo"
+
+    main : Number -> [Number]
+    main x = [42]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario Outline: Use string case expressions
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x = [<case expression>]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+    Examples:
+      | case expression               |
+      | case "foo" of "foo" -> 42     |
+      | case "" of "" -> 42           |
+      | case "foo" of s -> 42         |
+      | case ["foo"] of ["foo"] -> 42 |
+
+  Scenario: Use string case expressions with multiple alternatives
+    Given a file named "main.ein" with:
+    """
+    main : Number -> [Number]
+    main x =
+      case "foo" of
+        "fo" -> [13]
+        "bar" -> [13]
+        "foo" -> [42]
+        s -> [13]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
@@ -0,0 +1,32 @@
+Feature: Tuple
+  Scenario: Destructure tuples in case expressions
+    Given a file named "main.ein" with:
+    """
+    divide : Number -> Number -> (Number, Number)
+    divide x y = (x / y, x - y)
+
+    main : Number -> [Number]
+    main x =
+      case divide x 2 of
+        (q, r) -> [q + r - 19]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Destructure tuples in let expressions
+    Given a file named "main.ein" with:
+    """
+    swap : (Number, String) -> (String, Number)
+    swap p =
+      let (x, y) = p
+      in (y, x)
+
+    main : Number -> [Number]
+    main x =
+      let (s, y) = swap (x, "foo")
+      in [y]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
@@ -0,0 +1,52 @@
+Feature: Type inference
+  Scenario: Omit type signatures
+    Given a file named "main.ein" with:
+    """
+    y = 41
+
+    f x = x + 1
+
+    main : Number -> [Number]
+    main x = [f y]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Infer types of mutually recursive functions
+    Given a file named "main.ein" with:
+    """
+    isEven n =
+      case n of
+        0 -> True
+        n -> isOdd (n - 1)
+
+    isOdd n =
+      case n of
+        0 -> False
+        n -> isEven (n - 1)
+
+    main : Number -> [Number]
+    main x = [if isEven x then x else 0]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
+
+  Scenario: Import binds without type signatures
+    Given a file named "foo.ein" with:
+    """
+    export { f }
+
+    f x = x + 1
+    """
+    And a file named "main.ein" with:
+    """
+    import "tmp/aruba/foo"
+
+    main : Number -> [Number]
+    main x = [foo.f (x - 1)]
+    """
+    When I successfully run `ein build main.ein`
+    And I successfully run `sh -c ./a.out`
+    Then the stdout from "sh -c ./a.out" should contain exactly "[42]"
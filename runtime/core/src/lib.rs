@@ -36,3 +36,30 @@ pub extern "C" fn core_panic() {
 
     std::process::exit(1)
 }
+
+// Numbers are printed in their shortest decimal representations which are
+// parsed back into the same numbers. Their significands and exponents are
+// returned separately because foreign functions return single numbers.
+#[no_mangle]
+pub extern "C" fn core_decimal_significand(number: f64) -> i64 {
+    decimal(number).0
+}
+
+#[no_mangle]
+pub extern "C" fn core_decimal_exponent(number: f64) -> i64 {
+    decimal(number).1
+}
+
+fn decimal(number: f64) -> (i64, i64) {
+    let string = format!("{:e}", number.abs());
+    let (significand, exponent) = string.split_at(string.find('e').unwrap());
+    let fraction = significand
+        .find('.')
+        .map(|index| significand.len() - index - 1)
+        .unwrap_or(0);
+
+    (
+        significand.replace('.', "").parse().unwrap(),
+        exponent[1..].parse::<i64>().unwrap() - fraction as i64,
+    )
+}
@@ -43,11 +43,24 @@ impl From<f64> for Number {
     }
 }
 
+impl From<&str> for String {
+    fn from(s: &str) -> String {
+        Closure::new(string_entry, s.into())
+    }
+}
+
+impl String {
+    pub fn stdin() -> String {
+        Closure::new(stdin_entry, algebraic::String::Nil)
+    }
+}
+
 #[repr(C)]
 pub struct Environment(i8); // avoid zero-sized type for compatibility with C
 
 pub type List<T> = closure!(algebraic::List<T>);
 pub type Number = closure!(algebraic::Number);
+pub type String = closure!(algebraic::String);
 
 extern "fastcall" fn list_entry<T: Clone>(
     list: &mut algebraic::List<T>,
@@ -59,6 +72,28 @@ extern "fastcall" fn number_entry(number: &mut algebraic::Number) -> &mut algebr
     number
 }
 
+extern "fastcall" fn string_entry(string: &mut algebraic::String) -> &mut algebraic::String {
+    string
+}
+
+// Reads a character from stdin on the first evaluation and updates the thunk
+// so that it is not read again.
+extern "fastcall" fn stdin_entry(string: &mut algebraic::String) -> &mut algebraic::String {
+    *string = match crate::stdin::read_char() {
+        Some(c) => algebraic::String::Cons(c as u32 as f64, Box::into_raw(Box::new(String::stdin()))),
+        None => algebraic::String::Nil,
+    };
+
+    unsafe {
+        let thunk = (string as *mut algebraic::String as *mut u8)
+            .sub(std::mem::size_of::<extern "fastcall" fn()>()) as *mut String;
+
+        (*thunk).entry = string_entry;
+    }
+
+    string
+}
+
 pub mod algebraic {
     #[derive(Clone, Copy)]
     #[repr(C)]
@@ -73,8 +108,8 @@ pub mod algebraic {
 
             for x in xs.into_iter().rev() {
                 l = List::Cons(
-                    &mut x.clone().into(),
-                    &mut super::Closure::new(super::list_entry, l),
+                    Box::into_raw(Box::new(x.clone().into())),
+                    Box::into_raw(Box::new(super::Closure::new(super::list_entry, l))),
                 )
             }
 
@@ -97,4 +132,27 @@ pub mod algebraic {
             n.0
         }
     }
+
+    // Strings are lists of unboxed code points.
+    #[derive(Clone, Copy)]
+    #[repr(C)]
+    pub enum String {
+        Cons(f64, *mut super::String),
+        Nil,
+    }
+
+    impl From<&str> for String {
+        fn from(s: &str) -> String {
+            let mut l = String::Nil;
+
+            for c in s.chars().rev() {
+                l = String::Cons(
+                    c as u32 as f64,
+                    Box::into_raw(Box::new(super::Closure::new(super::string_entry, l))),
+                )
+            }
+
+            l
+        }
+    }
 }
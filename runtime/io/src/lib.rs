@@ -2,33 +2,69 @@ extern crate gc;
 
 #[macro_use]
 mod core;
+mod stdin;
 
 use crate::core::algebraic;
-use crate::core::{List, Number};
+use crate::core::{List, Number, String};
+use std::io::Write;
 
 #[global_allocator]
 static mut GLOBAL_ALLOCATOR: gc::Allocator = gc::Allocator;
 
-extern "C" {
-    static mut ein_main: closure!(&'static mut List<Number>, &mut Number);
-}
-
-#[no_mangle]
-pub extern "C" fn main() {
+fn initialize() {
     unsafe {
         gc::Allocator::initialize();
         gc::Allocator::enable_gc();
     }
+}
 
-    let mut output = eval!(unsafe { eval!(ein_main, &mut 42.0.into()) });
+// main : Number -> a
+#[no_mangle]
+pub extern "C" fn io_main_number(main: &mut closure!(&'static mut String, &mut Number)) {
+    initialize();
 
-    while let algebraic::List::Cons(elem, list) = *output {
-        let n: f64 = (*eval!(unsafe { &mut *elem })).into();
+    write_string(eval!(eval!(*main, &mut 42.0.into())));
 
-        println!("{}", n);
+    std::process::exit(0)
+}
 
-        output = eval!(unsafe { &mut *list });
-    }
+// main : [String] -> String -> a
+#[no_mangle]
+pub extern "C" fn io_main_string(
+    main: &mut closure!(&'static mut String, &mut List<String>, &mut String),
+) {
+    initialize();
+
+    let arguments: Vec<String> = std::env::args()
+        .skip(1)
+        .map(|s| String::from(s.as_str()))
+        .collect();
+
+    write_string(eval!(eval!(
+        *main,
+        Box::leak(Box::new(List::from(arguments.as_slice()))),
+        Box::leak(Box::new(String::stdin()))
+    )));
 
     std::process::exit(0)
 }
+
+// Results of main functions are converted into strings by compilers, so
+// they are written to stdout as they are.
+fn write_string(mut string: &mut algebraic::String) {
+    let stdout = std::io::stdout();
+    let mut stdout = stdout.lock();
+
+    while let algebraic::String::Cons(c, tail) = *string {
+        write!(
+            stdout,
+            "{}",
+            std::char::from_u32(c as u32).unwrap_or(std::char::REPLACEMENT_CHARACTER)
+        )
+        .unwrap();
+
+        string = eval!(unsafe { &mut *tail });
+    }
+
+    stdout.flush().unwrap();
+}
@@ -0,0 +1,37 @@
+use std::io::Read;
+
+// Reads a UTF-8 character from stdin. Invalid byte sequences are replaced
+// with U+FFFD.
+pub fn read_char() -> Option<char> {
+    let mut bs = [0; 4];
+
+    read_byte(&mut bs[0..1])?;
+
+    let n = match bs[0] {
+        0x00..=0x7f => 1,
+        0xc0..=0xdf => 2,
+        0xe0..=0xef => 3,
+        0xf0..=0xf7 => 4,
+        _ => return Some(std::char::REPLACEMENT_CHARACTER),
+    };
+
+    for i in 1..n {
+        if read_byte(&mut bs[i..i + 1]).is_none() {
+            return Some(std::char::REPLACEMENT_CHARACTER);
+        }
+    }
+
+    Some(
+        std::str::from_utf8(&bs[..n])
+            .ok()
+            .and_then(|s| s.chars().next())
+            .unwrap_or(std::char::REPLACEMENT_CHARACTER),
+    )
+}
+
+fn read_byte(b: &mut [u8]) -> Option<()> {
+    match std::io::stdin().read(b) {
+        Ok(1) => Some(()),
+        _ => None,
+    }
+}